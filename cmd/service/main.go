@@ -8,57 +8,125 @@ import (
 	_ "net/http/pprof"
 	"time"
 
-	prometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
 	core "courier-service/internal/core"
 	interceptor "courier-service/internal/gateway/interceptor"
+	ordergw "courier-service/internal/gateway/order"
+	retryexec "courier-service/internal/gateway/retry"
+	adminhandlers "courier-service/internal/handlers/admin"
+	audithandlers "courier-service/internal/handlers/audit"
+	commonhandlers "courier-service/internal/handlers/common"
 	courierhandlers "courier-service/internal/handlers/courier"
 	deliveryhandlers "courier-service/internal/handlers/delivery"
+	ratelimitmiddleware "courier-service/internal/handlers/middleware/ratelimit"
+	auditRepo "courier-service/internal/repository/audit"
 	courierRepo "courier-service/internal/repository/courier"
+	"courier-service/internal/repository/dbrouter"
 	deliveryRepo "courier-service/internal/repository/delivery"
+	idempotencyRepo "courier-service/internal/repository/idempotency"
+	ratelimitRepo "courier-service/internal/repository/ratelimit"
 	txRunner "courier-service/internal/repository/txrunner"
 	routing "courier-service/internal/routing"
+	auditusecase "courier-service/internal/usecase/audit"
 	courierusecase "courier-service/internal/usecase/courier"
 	deliveryassignusecase "courier-service/internal/usecase/delivery/assign"
+	deliverygetusecase "courier-service/internal/usecase/delivery/get"
 	deliveryunassignusecase "courier-service/internal/usecase/delivery/unassign"
 	deliverycalculator "courier-service/internal/usecase/utils"
 	database "courier-service/pkg/database/postgres"
+	delay "courier-service/pkg/delay/fulljitter"
+	"courier-service/pkg/health"
 	l "courier-service/pkg/logger/zap"
 	metrics "courier-service/pkg/metrics/prometheus"
-	rlimiter "courier-service/pkg/ratelimiter/tokenbucket"
+	pkgratelimiter "courier-service/pkg/ratelimiter"
+	distributedlimiter "courier-service/pkg/ratelimiter/distributed"
+	rlimiter "courier-service/pkg/ratelimiter/keyed"
+	"courier-service/pkg/retrypolicy"
 	shutdown "courier-service/pkg/shutdown"
+	"courier-service/pkg/tracing"
+	orderpb "courier-service/proto/order"
 )
 
+// configPollInterval — как часто проверять, не изменился ли файл конфигурации.
+const configPollInterval = 5 * time.Second
+
 func main() {
 	ctx := shutdown.WaitForShutdown()
 
-	cfg, err := core.LoadConfig()
+	cfg, err := core.LoadConfig(core.AppService)
+	if errors.Is(err, core.ErrExitRequested) {
+		return
+	}
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	logger, err := l.New(cfg.LogLevel)
+	logger, err := l.New(cfg.Logging())
 	if err != nil {
 		log.Fatalf("Failed to create logger: %v", err)
 	}
 
-	ratelimiter := rlimiter.NewTokenBucket(cfg.TokenBucketCapacity, cfg.TokenBucketRefillRate, time.Now)
+	shutdownTracing, err := tracing.Init(ctx, cfg.TracingFor("courier-service"))
+	if err != nil {
+		logger.Fatalf("Failed to init tracing: %v", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Errorf("Failed to flush traces: %v", err)
+		}
+	}()
+
+	rateLimitPolicy := ratelimitmiddleware.NewPolicyStore(newRateLimitPolicy(cfg))
 
-	dbPool := database.MustInitPool(cfg.PostgresDSN(), logger)
+	dbPool, err := database.NewPool(ctx, cfg.Database(), logger)
+	if err != nil {
+		logger.Fatalf("Failed to connect to database: %v", err)
+	}
 	defer dbPool.Close()
 
 	// Создаем метрики до grpcClient, чтобы использовать в interceptor
-	httpMetrics := metrics.NewHTTPMetrics(prometheus.DefaultRegisterer)
+	registry := metrics.NewRegistry()
+	httpMetrics := metrics.NewHTTPMetrics(registry)
 	metricsWriter := metrics.NewMetricsWriter(httpMetrics)
+	businessMetrics := metrics.NewBusinessMetricsWriter(metrics.NewBusinessMetrics(registry))
+	registry.MustRegister(metrics.NewPoolStatsCollector("primary", dbPool))
+
+	gatewayLogger := logger.Named(core.LogModuleGateway)
+	repositoryLogger := logger.Named(core.LogModuleRepository)
+
+	var replicaPool *pgxpool.Pool
+	if replicaCfg, ok := cfg.ReplicaDatabase(); ok {
+		replicaPool, err = database.NewPool(ctx, replicaCfg, logger)
+		if err != nil {
+			logger.Fatalf("Failed to connect to database replica: %v", err)
+		}
+		defer replicaPool.Close()
+		registry.MustRegister(metrics.NewPoolStatsCollector("replica", replicaPool))
+	}
+	dbRouter := dbrouter.NewRouter(dbPool, replicaPool, cfg.DBRouter(), time.Now)
+	go dbRouter.MonitorLag(ctx, cfg.Postgres.Replica.LagCheckInterval, repositoryLogger)
 
 	// Создаем grpcClient с interceptors
 	grpcClient, err := grpc.NewClient(
-		cfg.GRPCServiceOrderServer,
+		cfg.OrderService.Address,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithChainUnaryInterceptor(
-			interceptor.LoggingMetricsInterceptor(logger, metricsWriter),
+			interceptor.RequestIDInterceptor(),
+			interceptor.TracingInterceptor(),
+			interceptor.DeadlineInterceptor(cfg.OrderService.CallTimeout),
+			interceptor.LoggingMetricsInterceptor(gatewayLogger, metricsWriter),
+		),
+		grpc.WithChainStreamInterceptor(
+			interceptor.StreamRequestIDInterceptor(),
+			interceptor.StreamTracingInterceptor(),
+			interceptor.StreamDeadlineInterceptor(cfg.OrderService.StreamTimeout),
+			interceptor.StreamLoggingMetricsInterceptor(gatewayLogger, metricsWriter),
 		),
 	)
 	if err != nil {
@@ -72,50 +140,116 @@ func main() {
 		}
 	}()
 
-	courierRepo := courierRepo.NewCourierRepository(dbPool, logger)
-	deliveryRepo := deliveryRepo.NewDeliveryRepository(dbPool)
-	txRunner := txRunner.NewTxRunner(dbPool)
+	// снимок заказа при назначении; повторы короткие, чтобы не задерживать HTTP ответ
+	orderRetry := retryexec.NewRetryExecutor(retryexec.RetryConfig{
+		MaxAttempts: cfg.OrderService.Retry.MaxAttempts,
+		Strategy:    delay.NewFullJitter(50*time.Millisecond, 500*time.Millisecond, 2.0, nil),
+		ShouldRetry: retrypolicy.IsRetryable,
+	}, gatewayLogger)
+	orderGateway := ordergw.NewGateway(
+		orderpb.NewOrdersServiceClient(grpcClient),
+		orderRetry,
+		gatewayLogger,
+		cfg.OrderService.PageSize,
+	)
+
+	courierRepo := courierRepo.NewCourierRepository(dbRouter, repositoryLogger)
+	deliveryRepo := deliveryRepo.NewDeliveryRepository(dbRouter)
+	auditRepo := auditRepo.NewAuditRepository(dbRouter)
+	idempotencyRepo := idempotencyRepo.NewIdempotencyRepository(dbPool, repositoryLogger)
+	bucketRepo := ratelimitRepo.NewBucketRepository(dbPool, repositoryLogger)
+	txRunner := txRunner.NewTxRunner(dbPool, txRunner.Config{
+		IsoLevel:   pgx.TxIsoLevel(cfg.Tx.IsolationLevel),
+		MaxRetries: cfg.Tx.MaxRetries,
+		Metrics:    businessMetrics,
+	}, repositoryLogger)
 
 	deliveryCalculator := deliverycalculator.NewTimeCalculatorFactory()
+	auditRecorder := auditusecase.NewRecorder(auditRepo)
 	assignUseCase := deliveryassignusecase.NewAssignDelieveryUseCase(
 		courierRepo,
 		deliveryRepo,
 		txRunner,
+		auditRecorder,
 		deliveryCalculator,
+		orderGateway,
+		businessMetrics,
+		logger,
 	)
 	unassignUseCase := deliveryunassignusecase.NewUnassignDelieveryUseCase(
 		courierRepo,
 		deliveryRepo,
 		txRunner,
+		auditRecorder,
 	)
+	getUseCase := deliverygetusecase.NewGetDeliveryUseCase(deliveryRepo)
+	auditUseCase := auditusecase.NewAuditUseCase(auditRepo)
 	courierUseCase := courierusecase.NewCourierUseCase(
 		courierRepo,
+		txRunner,
+		auditRecorder,
 		deliveryCalculator,
+		businessMetrics,
 		logger,
 	)
 
-	go courierUseCase.CheckFreeCouriersWithInterval(ctx, cfg.CheckFreeCouriersInterval)
+	go courierUseCase.CheckFreeCouriersWithInterval(ctx, cfg.Service.CheckFreeCouriersInterval)
+	go runWithInterval(ctx, cfg.Service.Idempotency.CleanupInterval, idempotencyRepo.DeleteExpiredKeys,
+		"Failed to purge expired idempotency keys", logger)
+
+	ratelimiter := newRateLimiter(cfg, bucketRepo, logger)
+	if cfg.Service.RateLimit.Backend == "postgres" {
+		go runWithInterval(ctx, cfg.Service.RateLimit.IdleTimeout, func(ctx context.Context) error {
+			return bucketRepo.DeleteIdleBuckets(ctx, cfg.Service.RateLimit.IdleTimeout)
+		}, "Failed to purge idle rate limit buckets", logger)
+	}
+
+	readiness := health.NewChecker(cfg.Health.CheckTimeout, cfg.Health.CacheTTL, time.Now)
+	readiness.Register("postgres", health.Ping(dbPool))
+	readiness.Register("order_service", health.GRPCConn(grpcClient))
 
 	pathNormalizer := routing.NewChiPathNormalizer()
-	metricsHandler := metrics.NewMetricsHandler()
+	metricsHandler := metrics.NewMetricsHandler(registry)
 	router := routing.Router(
 		logger,
 		ratelimiter,
+		rateLimitPolicy,
 		metricsWriter,
 		metricsHandler,
 		pathNormalizer,
+		idempotencyRepo,
+		cfg.Service.Idempotency.KeyTTL,
+		cfg.Service.Idempotency.LockLease,
 		courierhandlers.NewCourierController(
 			courierUseCase,
+			logger,
 		),
 		deliveryhandlers.NewDeliveryController(
 			assignUseCase,
 			unassignUseCase,
+			getUseCase,
+			logger,
 		),
+		audithandlers.NewAuditController(auditUseCase, logger),
+		commonhandlers.NewProbesController(readiness),
+	)
+	internalRouter := routing.InternalRouter(
+		adminhandlers.NewLogLevelController(logger.Levels()),
+		http.DefaultServeMux,
 	)
+	// лимиты, повторы и интервал проверки курьеров меняются без перезапуска
+	reloader := core.NewReloader(cfg, logger)
+	reloader.OnReload(func(cfg *core.Config) {
+		rateLimitPolicy.Store(newRateLimitPolicy(cfg))
+		orderRetry.SetMaxAttempts(cfg.OrderService.Retry.MaxAttempts)
+		courierUseCase.SetCheckInterval(cfg.Service.CheckFreeCouriersInterval)
+	})
+	go reloader.Run(ctx, configPollInterval)
+
 	logger.Info("Starting service server...")
-	go startServer(ctx, cfg.Port, router, logger)
+	go startServer(ctx, cfg.Service.Addr(), router, logger)
 	logger.Info("Starting pprof server...")
-	go startPprofServer(ctx, cfg.PprofAddress, logger)
+	go startPprofServer(ctx, cfg.Service.PprofAddress, internalRouter, logger)
 	<-ctx.Done()
 	logger.Info("Service stopped gracefully")
 }
@@ -151,11 +285,12 @@ func startServer(ctx context.Context, port string, handler http.Handler, logger
 	}
 }
 
-func startPprofServer(ctx context.Context, addr string, logger *l.Logger) {
+// startPprofServer поднимает служебный listener: pprof и admin-эндпоинты.
+func startPprofServer(ctx context.Context, addr string, handler http.Handler, logger *l.Logger) {
 	logger.Infof("%s", addr)
 	srv := &http.Server{
 		Addr:              addr,
-		Handler:           http.DefaultServeMux,
+		Handler:           handler,
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
@@ -184,3 +319,50 @@ func startPprofServer(ctx context.Context, addr string, logger *l.Logger) {
 		logger.Errorf("error shutting down pprof server: %v", err)
 	}
 }
+
+type rateLimiter interface {
+	Allow(key string, limit pkgratelimiter.Limit) pkgratelimiter.Status
+}
+
+func newRateLimitPolicy(cfg *core.Config) ratelimitmiddleware.Policy {
+	return ratelimitmiddleware.Policy{
+		KeyBy: ratelimitmiddleware.KeyBy(cfg.Service.RateLimit.KeyBy),
+		Default: pkgratelimiter.Limit{
+			Capacity:   cfg.Service.RateLimit.Capacity,
+			RefillRate: cfg.Service.RateLimit.RefillRate,
+		},
+		Routes:     cfg.Service.RateLimit.Routes,
+		TrustProxy: cfg.Service.RateLimit.TrustProxy,
+	}
+}
+
+// newRateLimiter выбирает хранилище бакетов: память процесса или Postgres,
+// общий для всех реплик сервиса.
+func newRateLimiter(cfg *core.Config, store *ratelimitRepo.BucketRepository, logger *l.Logger) rateLimiter {
+	if cfg.Service.RateLimit.Backend == "postgres" {
+		return distributedlimiter.NewLimiter(store, 0, logger)
+	}
+	return rlimiter.NewLimiter(cfg.Service.RateLimit.MaxKeys, cfg.Service.RateLimit.IdleTimeout, time.Now)
+}
+
+func runWithInterval(
+	ctx context.Context,
+	interval time.Duration,
+	fn func(ctx context.Context) error,
+	errMessage string,
+	logger *l.Logger,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				logger.Errorf("%s: %v", errMessage, err)
+			}
+		}
+	}
+}
@@ -8,20 +8,27 @@ import (
 	"time"
 
 	"github.com/IBM/sarama"
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
 	core "courier-service/internal/core"
+	breaker "courier-service/internal/gateway/breaker"
 	interceptor "courier-service/internal/gateway/interceptor"
 	ordergw "courier-service/internal/gateway/order"
+	ordercache "courier-service/internal/gateway/order/cache"
 	retryexec "courier-service/internal/gateway/retry"
+	adminhandlers "courier-service/internal/handlers/admin"
+	commonhandlers "courier-service/internal/handlers/common"
 	orderhandler "courier-service/internal/handlers/queues/order/changed"
 	model "courier-service/internal/model"
+	auditRepo "courier-service/internal/repository/audit"
 	courierRepo "courier-service/internal/repository/courier"
+	"courier-service/internal/repository/dbrouter"
 	deliveryRepo "courier-service/internal/repository/delivery"
 	txRunner "courier-service/internal/repository/txrunner"
+	auditusecase "courier-service/internal/usecase/audit"
 	deliveryassignusecase "courier-service/internal/usecase/delivery/assign"
 	deliverycompleteusecase "courier-service/internal/usecase/delivery/complete"
 	deliveryunassignusecase "courier-service/internal/usecase/delivery/unassign"
@@ -30,46 +37,61 @@ import (
 	deliverycalculator "courier-service/internal/usecase/utils"
 	database "courier-service/pkg/database/postgres"
 	delay "courier-service/pkg/delay/fulljitter"
+	"courier-service/pkg/health"
 	l "courier-service/pkg/logger/zap"
 	metrics "courier-service/pkg/metrics/prometheus"
+	"courier-service/pkg/retrypolicy"
 	shutdown "courier-service/pkg/shutdown"
+	"courier-service/pkg/tracing"
 	orderpb "courier-service/proto/order"
 )
 
+// configPollInterval — как часто проверять, не изменился ли файл конфигурации.
+const configPollInterval = 5 * time.Second
+
 func main() {
 	ctx := shutdown.WaitForShutdown()
-	cfg, err := core.LoadConfig()
-
+	cfg, err := core.LoadConfig(core.AppWorker)
+	if errors.Is(err, core.ErrExitRequested) {
+		return
+	}
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	logger, err := l.New(cfg.LogLevel)
+	logger, err := l.New(cfg.Logging())
 	if err != nil {
 		log.Fatalf("Failed to create logger: %v", err)
 	}
 
-	go initMetricsServer(ctx, ":9101", logger)
+	shutdownTracing, err := tracing.Init(ctx, cfg.TracingFor("courier-worker"))
+	if err != nil {
+		logger.Fatalf("Failed to init tracing: %v", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Errorf("Failed to flush traces: %v", err)
+		}
+	}()
 
 	config := sarama.NewConfig()
 	configureKafkaClient(config)
 	logger.Info("Kafka client configured")
 
-	topic := cfg.KafkaTopic
-	groupID := cfg.KafkaGroupID
-	brokers := cfg.KafkaBrokers
+	topic := cfg.Worker.Kafka.Topic
+	groupID := cfg.Worker.Kafka.GroupID
+	brokers := cfg.Worker.Kafka.Brokers
 
 	// Создаем метрики для worker
-	httpMetrics := metrics.NewHTTPMetrics(prometheus.DefaultRegisterer)
+	registry := metrics.NewRegistry()
+	httpMetrics := metrics.NewHTTPMetrics(registry)
 	metricsWriter := metrics.NewMetricsWriter(httpMetrics)
+	businessMetrics := metrics.NewBusinessMetricsWriter(metrics.NewBusinessMetrics(registry))
 
-	grpcClient, err := grpc.NewClient(
-		cfg.GRPCServiceOrderServer,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithChainUnaryInterceptor(
-			interceptor.LoggingMetricsInterceptor(logger, metricsWriter),
-		),
-	)
+	grpcClient, err := grpc.NewClient(cfg.OrderService.Address,
+		orderClientOptions(cfg, logger.Named(core.LogModuleGateway), metricsWriter)...)
 	if err != nil {
 		logger.Errorf("Failed to create grpc client: %v", err)
 	}
@@ -79,32 +101,160 @@ func main() {
 		}
 	}()
 
-	ordersClient := orderpb.NewOrdersServiceClient(grpcClient)
-	retryCfg := configureRetry(cfg.RetryMaxAttempts)
-	retry := retryexec.NewRetryExecutor(retryCfg, logger)
-	orderGateway := ordergw.NewGateway(ordersClient, retry, logger)
-
-	dbPool := database.MustInitPool(cfg.PostgresDSN(), logger)
+	dbPool, err := database.NewPool(ctx, cfg.Database(), logger)
+	if err != nil {
+		logger.Fatalf("Failed to connect to database: %v", err)
+	}
 	defer dbPool.Close()
-	courierRepository := courierRepo.NewCourierRepository(dbPool, logger)
-	deliveryRepository := deliveryRepo.NewDeliveryRepository(dbPool)
-	transactionRunner := txRunner.NewTxRunner(dbPool)
+	registry.MustRegister(metrics.NewPoolStatsCollector("primary", dbPool))
+
+	var replicaPool *pgxpool.Pool
+	if replicaCfg, ok := cfg.ReplicaDatabase(); ok {
+		replicaPool, err = database.NewPool(ctx, replicaCfg, logger)
+		if err != nil {
+			logger.Fatalf("Failed to connect to database replica: %v", err)
+		}
+		defer replicaPool.Close()
+		registry.MustRegister(metrics.NewPoolStatsCollector("replica", replicaPool))
+	}
+	dbRouter := dbrouter.NewRouter(dbPool, replicaPool, cfg.DBRouter(), time.Now)
+	go dbRouter.MonitorLag(ctx, cfg.Postgres.Replica.LagCheckInterval, logger.Named(core.LogModuleRepository))
+
+	kafkaClient, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		logger.Fatalf("Failed to create kafka client: %v", err)
+	}
+	defer func() {
+		if err := kafkaClient.Close(); err != nil {
+			logger.Errorf("Failed to close kafka client: %v", err)
+		}
+	}()
+
+	readiness := health.NewChecker(cfg.Health.CheckTimeout, cfg.Health.CacheTTL, time.Now)
+	readiness.Register("postgres", health.Ping(dbPool))
+	readiness.Register("order_service", health.GRPCConn(grpcClient))
+	readiness.Register("kafka", health.KafkaMetadata(kafkaClient, topic))
+
+	go initMetricsServer(ctx, cfg.Worker.MetricsAddress, newServiceMux(
+		metrics.NewMetricsHandler(registry),
+		commonhandlers.NewProbesController(readiness),
+	), logger)
+	go initMetricsServer(ctx, cfg.Worker.AdminAddress, newAdminMux(
+		adminhandlers.NewLogLevelController(logger.Levels()),
+	), logger)
+
+	orders := newOrderProcessing(cfg, grpcClient, dbRouter, metricsWriter, businessMetrics, logger)
+
+	// число повторов запросов к сервису заказов меняется без перезапуска
+	reloader := core.NewReloader(cfg, logger)
+	reloader.OnReload(func(cfg *core.Config) {
+		orders.retry.SetMaxAttempts(cfg.OrderService.Retry.MaxAttempts)
+	})
+	go reloader.Run(ctx, configPollInterval)
+
+	go func() {
+		if err := runKafkaConsumer(ctx, logger.Named(core.LogModuleKafka), kafkaClient, groupID, topic, orders.handler); err != nil {
+			logger.Errorf("Kafka consumer stopped with error: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Info("Kafka consumer exited gracefully")
+}
+
+// orderClientOptions — interceptors клиента сервиса заказов: request id и трасса из
+// сообщения Kafka, дедлайн по умолчанию, логи и метрики, pushback сервера.
+func orderClientOptions(cfg *core.Config, logger *l.Logger, metricsWriter *metrics.MetricsWriter) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			interceptor.RequestIDInterceptor(),
+			interceptor.TracingInterceptor(),
+			interceptor.DeadlineInterceptor(cfg.OrderService.CallTimeout),
+			interceptor.LoggingMetricsInterceptor(logger, metricsWriter),
+			interceptor.RetryPushbackInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			interceptor.StreamRequestIDInterceptor(),
+			interceptor.StreamTracingInterceptor(),
+			interceptor.StreamDeadlineInterceptor(cfg.OrderService.StreamTimeout),
+			interceptor.StreamLoggingMetricsInterceptor(logger, metricsWriter),
+		),
+	}
+}
+
+// orderProcessing — обработка заказов worker'а: consumer order.changed и
+// retry запросов к сервису заказов, который перенастраивается при reload.
+type orderProcessing struct {
+	handler *orderhandler.OrderStatusChangedHandler
+	retry   *retryexec.RetryExecutor
+}
+
+// newOrderProcessing собирает обработку заказов: gateway сервиса заказов
+// с retry, circuit breaker и кэшем, репозитории и use case'ы доставки.
+func newOrderProcessing(
+	cfg *core.Config,
+	conn grpc.ClientConnInterface,
+	db *dbrouter.Router,
+	metricsWriter *metrics.MetricsWriter,
+	businessMetrics *metrics.BusinessMetricsWriter,
+	logger *l.Logger,
+) orderProcessing {
+	gatewayLogger := logger.Named(core.LogModuleGateway)
+	repositoryLogger := logger.Named(core.LogModuleRepository)
+
+	ordersClient := orderpb.NewOrdersServiceClient(conn)
+	retryCfg := configureRetry(cfg)
+	retry := retryexec.NewRetryExecutor(retryCfg, gatewayLogger)
+	orderBreaker := breaker.NewCircuitBreaker("order_service", breaker.Config{
+		Window:              cfg.OrderService.Breaker.Window,
+		MinRequests:         cfg.OrderService.Breaker.MinRequests,
+		FailureRatio:        cfg.OrderService.Breaker.FailureRatio,
+		OpenTimeout:         cfg.OrderService.Breaker.OpenTimeout,
+		HalfOpenMaxRequests: cfg.OrderService.Breaker.HalfOpenRequests,
+		// NotFound и InvalidArgument означают, что сервис жив
+		IsFailure: retrypolicy.IsRetryable,
+	}, metricsWriter, gatewayLogger, time.Now)
+	orderGateway := ordercache.NewGateway(
+		ordergw.NewGateway(ordersClient, orderBreaker.Wrap(retry), gatewayLogger, cfg.OrderService.PageSize),
+		cfg.OrderService.Cache.TTL,
+		cfg.OrderService.Cache.MaxSize,
+		cfg.OrderService.Cache.FetchTimeout,
+		metricsWriter,
+		time.Now,
+	)
+
+	courierRepository := courierRepo.NewCourierRepository(db, repositoryLogger)
+	deliveryRepository := deliveryRepo.NewDeliveryRepository(db)
+	transactionRunner := txRunner.NewTxRunner(db.Primary(), txRunner.Config{
+		IsoLevel:   pgx.TxIsoLevel(cfg.Tx.IsolationLevel),
+		MaxRetries: cfg.Tx.MaxRetries,
+		Metrics:    businessMetrics,
+	}, repositoryLogger)
 
 	deliveryCalculator := deliverycalculator.NewTimeCalculatorFactory()
+	auditRecorder := auditusecase.NewRecorder(auditRepo.NewAuditRepository(db))
 
 	assignUseCase := deliveryassignusecase.NewAssignDelieveryUseCase(
 		courierRepository,
 		deliveryRepository,
 		transactionRunner,
+		auditRecorder,
 		deliveryCalculator,
+		orderGateway,
+		businessMetrics,
+		logger,
 	)
 	unassignUseCase := deliveryunassignusecase.NewUnassignDelieveryUseCase(
 		courierRepository,
 		deliveryRepository,
 		transactionRunner,
+		auditRecorder,
 	)
 	completeUseCase := deliverycompleteusecase.NewCompleteDeliveryUseCase(
 		courierRepository,
+		deliveryRepository,
+		transactionRunner,
 	)
 
 	createdProcessor := processor.NewCreatedProcessor(assignUseCase)
@@ -118,16 +268,10 @@ func main() {
 	})
 
 	orderChangedUseCase := changed.NewOrderChangedUseCase(orderChangedFactory, orderGateway, logger)
-	orderChangedHandler := orderhandler.NewOrderStatusChangedHandler(orderChangedUseCase, logger)
-
-	go func() {
-		if err := runKafkaConsumer(ctx, logger, brokers, groupID, topic, config, orderChangedHandler); err != nil {
-			logger.Errorf("Kafka consumer stopped with error: %v", err)
-		}
-	}()
-
-	<-ctx.Done()
-	logger.Info("Kafka consumer exited gracefully")
+	return orderProcessing{
+		handler: orderhandler.NewOrderStatusChangedHandler(orderChangedUseCase, businessMetrics, logger.Named(core.LogModuleKafka)),
+		retry:   retry,
+	}
 }
 
 func configureKafkaClient(config *sarama.Config) {
@@ -140,19 +284,19 @@ func configureKafkaClient(config *sarama.Config) {
 func runKafkaConsumer(
 	ctx context.Context,
 	logger *l.Logger,
-	brokers []string,
+	kafkaClient sarama.Client,
 	groupID string,
 	topic string,
-	config *sarama.Config,
 	handler sarama.ConsumerGroupHandler,
 ) error {
-	client, err := sarama.NewConsumerGroup(brokers, groupID, config)
+	// группа работает поверх общего клиента, который проверяет и readiness проба
+	client, err := sarama.NewConsumerGroupFromClient(groupID, kafkaClient)
 	if err != nil {
 		return err
 	}
 	defer func() {
 		if err := client.Close(); err != nil {
-			logger.Errorf("Failed to close kafka client: %v", err)
+			logger.Errorf("Failed to close kafka consumer group: %v", err)
 		}
 	}()
 
@@ -169,22 +313,49 @@ func runKafkaConsumer(
 	}
 }
 
-func configureRetry(maxAttemps int) retryexec.RetryConfig {
+func configureRetry(cfg *core.Config) retryexec.RetryConfig {
 	fullJitter := delay.NewFullJitter(50*time.Millisecond, 1*time.Second, 2.0, nil)
 	return retryexec.RetryConfig{
-		MaxAttempts: maxAttemps,
+		MaxAttempts: cfg.OrderService.Retry.MaxAttempts,
 		Strategy:    fullJitter,
-		ShouldRetry: nil,
+		ShouldRetry: retrypolicy.IsRetryable,
+		Policy: retrypolicy.Policies{
+			Methods: map[string]retrypolicy.Policy{
+				// список заказов опрашивается по тикеру, следующий тик сам повторит запрос
+				orderpb.OrdersService_GetOrders_FullMethodName: {MaxAttempts: 1},
+			},
+		},
+		Budget: retrypolicy.NewBudget(
+			cfg.OrderService.Retry.BudgetRatio,
+			cfg.OrderService.Retry.BudgetMinRetries,
+			cfg.OrderService.Retry.BudgetWindow,
+			time.Now,
+		),
 	}
 }
 
-func initMetricsServer(ctx context.Context, addr string, logger *l.Logger) {
+// newServiceMux — служебные эндпоинты worker'а: метрики и пробы.
+func newServiceMux(metricsHandler http.Handler, probes *commonhandlers.ProbesController) *http.ServeMux {
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/metrics", metricsHandler)
+	mux.HandleFunc("GET /livez", probes.Livez)
+	mux.HandleFunc("GET /readyz", probes.Readyz)
+	return mux
+}
+
+// newAdminMux — управление уровнем логирования. Эндпоинты без auth, поэтому
+// живут на отдельном listener'е, который не публикуется наружу.
+func newAdminMux(logLevels *adminhandlers.LogLevelController) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/log-level", logLevels.GetLogLevel)
+	mux.HandleFunc("PUT /admin/log-level", logLevels.SetLogLevel)
+	return mux
+}
 
+func initMetricsServer(ctx context.Context, addr string, handler http.Handler, logger *l.Logger) {
 	srv := &http.Server{
 		Addr:              addr,
-		Handler:           mux,
+		Handler:           handler,
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 
@@ -0,0 +1,255 @@
+//go:build integration
+// +build integration
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	core "courier-service/internal/core"
+	"courier-service/internal/gateway/order/fake"
+	"courier-service/internal/model"
+	integration "courier-service/internal/persistence/database/integration"
+	courierRepo "courier-service/internal/repository/courier"
+	"courier-service/internal/repository/dbrouter"
+	deliveryRepo "courier-service/internal/repository/delivery"
+	l "courier-service/pkg/logger/zap"
+	metrics "courier-service/pkg/metrics/prometheus"
+	orderpb "courier-service/proto/order"
+)
+
+const (
+	e2eTopic   = "order.changed"
+	e2eOrderID = "550e8400-e29b-41d4-a716-446655440000"
+)
+
+// WorkerE2ETestSuite прогоняет события order.changed через сборку cmd/worker:
+// настоящий gRPC клиент с interceptors, retry и breaker против fake сервиса
+// заказов на bufconn и Postgres в testcontainers. Kafka заменена на claim,
+// отдающий сообщения из канала.
+type WorkerE2ETestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pool        *pgxpool.Pool
+	db          *dbrouter.Router
+	orders      *fake.Server
+	handler     sarama.ConsumerGroupHandler
+	stop        func()
+	courierRepo *courierRepo.CourierRepository
+	deliveries  *deliveryRepo.DeliveryRepository
+}
+
+func TestWorkerE2ETestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerE2ETestSuite))
+}
+
+func (s *WorkerE2ETestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	_, connStr, err := integration.TestWithMigrations()
+	s.Require().NoError(err)
+
+	s.pool, err = pgxpool.New(s.ctx, connStr)
+	s.Require().NoError(err)
+	s.db = dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil)
+}
+
+func (s *WorkerE2ETestSuite) TearDownSuite() {
+	s.pool.Close()
+}
+
+func (s *WorkerE2ETestSuite) SetupTest() {
+	s.Require().NoError(integration.TruncateAll(s.ctx, s.pool))
+
+	logger, err := l.New(l.Config{Level: l.LogLevelError, Format: l.FormatJSON})
+	s.Require().NoError(err)
+	registry := prometheus.NewRegistry()
+	metricsWriter := metrics.NewMetricsWriter(metrics.NewHTTPMetrics(registry))
+	businessMetrics := metrics.NewBusinessMetricsWriter(metrics.NewBusinessMetrics(registry))
+
+	s.orders = fake.NewServer(&orderpb.Order{
+		Id:           e2eOrderID,
+		RestaurantId: "restaurant-1",
+		Items:        []*orderpb.Item{{Name: "pizza", Price: 700, Quantity: 2}},
+		TotalPrice:   1400,
+		Address:      &orderpb.DeliveryAddress{Street: "Tverskaya", House: "1"},
+		Status:       string(model.OrderStatusCreated),
+		CreatedAt:    timestamppb.Now(),
+	})
+	conn, stop, err := fake.Serve(s.orders, orderClientOptions(e2eConfig(), logger, metricsWriter)...)
+	s.Require().NoError(err)
+	s.stop = stop
+
+	s.handler = newOrderProcessing(e2eConfig(), conn, s.db, metricsWriter, businessMetrics, logger).handler
+	s.courierRepo = courierRepo.NewCourierRepository(s.db, logger)
+	s.deliveries = deliveryRepo.NewDeliveryRepository(s.db)
+}
+
+func (s *WorkerE2ETestSuite) TearDownTest() {
+	s.stop()
+}
+
+func e2eConfig() *core.Config {
+	return &core.Config{
+		Tx: core.TxConfig{MaxRetries: 3},
+		OrderService: core.OrderServiceConfig{
+			CallTimeout:   5 * time.Second,
+			StreamTimeout: time.Minute,
+			PageSize:      10,
+			Retry: core.RetryConfig{
+				MaxAttempts:      3,
+				BudgetRatio:      1,
+				BudgetMinRetries: 10,
+				BudgetWindow:     time.Minute,
+			},
+			Cache: core.OrderCacheConfig{
+				TTL:     time.Minute,
+				MaxSize: 100,
+			},
+			Breaker: core.BreakerConfig{
+				Window:           time.Minute,
+				MinRequests:      10,
+				FailureRatio:     0.5,
+				OpenTimeout:      time.Second,
+				HalfOpenRequests: 1,
+			},
+		},
+	}
+}
+
+func (s *WorkerE2ETestSuite) TestCreatedThenCancelled() {
+	courierID, err := s.courierRepo.CreateCourier(s.ctx, model.Courier{
+		Name:          "Courier",
+		Phone:         "+79990000001",
+		Status:        model.CourierStatusAvailable,
+		TransportType: model.TransportTypeCar,
+	})
+	s.Require().NoError(err)
+
+	// первый запрос заказа падает: событие должно пройти через retry
+	s.orders.Enqueue(orderpb.OrdersService_GetOrderById_FullMethodName,
+		fake.Fault{Code: codes.Unavailable, Message: "connection reset"})
+
+	marked := s.consume(orderEvent(e2eOrderID, model.OrderStatusCreated))
+	s.Equal(1, marked)
+	// повтор после Unavailable; снимок для назначения берется уже из кэша
+	s.Equal(2, s.orders.Calls(orderpb.OrdersService_GetOrderById_FullMethodName))
+
+	delivery, err := s.deliveries.CouriersDelivery(s.ctx, e2eOrderID)
+	s.Require().NoError(err)
+	s.Equal(courierID, delivery.CourierID)
+	s.Require().NotNil(delivery.Order)
+	s.Equal(int64(1400), delivery.Order.TotalPrice)
+	s.Equal("Tverskaya", delivery.Order.Address.Street)
+
+	courier, err := s.courierRepo.GetCourierById(s.ctx, courierID)
+	s.Require().NoError(err)
+	s.Equal(model.CourierStatusBusy, courier.Status)
+
+	s.orders.SetStatus(e2eOrderID, string(model.OrderStatusCancelled))
+	marked = s.consume(orderEvent(e2eOrderID, model.OrderStatusCancelled))
+	s.Equal(1, marked)
+
+	_, err = s.deliveries.CouriersDelivery(s.ctx, e2eOrderID)
+	s.ErrorIs(err, deliveryRepo.ErrOrderIDNotFound)
+
+	courier, err = s.courierRepo.GetCourierById(s.ctx, courierID)
+	s.Require().NoError(err)
+	s.Equal(model.CourierStatusAvailable, courier.Status)
+}
+
+func (s *WorkerE2ETestSuite) TestStatusMismatchIsSkipped() {
+	_, err := s.courierRepo.CreateCourier(s.ctx, model.Courier{
+		Name:          "Courier",
+		Phone:         "+79990000002",
+		Status:        model.CourierStatusAvailable,
+		TransportType: model.TransportTypeCar,
+	})
+	s.Require().NoError(err)
+
+	// событие о создании пришло, когда заказ уже отменен
+	s.orders.SetStatus(e2eOrderID, string(model.OrderStatusCancelled))
+	marked := s.consume(orderEvent(e2eOrderID, model.OrderStatusCreated))
+	s.Equal(1, marked)
+
+	_, err = s.deliveries.CouriersDelivery(s.ctx, e2eOrderID)
+	s.ErrorIs(err, deliveryRepo.ErrOrderIDNotFound)
+}
+
+// consume отдает сообщения обработчику так же, как consumer group sarama,
+// и возвращает число подтвержденных сообщений.
+func (s *WorkerE2ETestSuite) consume(messages ...*sarama.ConsumerMessage) int {
+	ctx, cancel := context.WithTimeout(s.ctx, 30*time.Second)
+	defer cancel()
+
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, len(messages))}
+	for i, m := range messages {
+		m.Topic, m.Offset = e2eTopic, int64(i)
+		claim.messages <- m
+	}
+	close(claim.messages)
+
+	session := &fakeSession{ctx: ctx}
+	s.Require().NoError(s.handler.Setup(session))
+	s.Require().NoError(s.handler.ConsumeClaim(session, claim))
+	s.Require().NoError(s.handler.Cleanup(session))
+
+	return session.markedCount()
+}
+
+func orderEvent(orderID string, status model.OrderStatus) *sarama.ConsumerMessage {
+	value, _ := json.Marshal(map[string]any{
+		"order_id":   orderID,
+		"status":     string(status),
+		"created_at": time.Now(),
+	})
+	return &sarama.ConsumerMessage{Key: []byte(orderID), Value: value}
+}
+
+type fakeSession struct {
+	ctx    context.Context
+	mu     sync.Mutex
+	marked int
+}
+
+func (s *fakeSession) Claims() map[string][]int32 { return map[string][]int32{e2eTopic: {0}} }
+func (s *fakeSession) MemberID() string           { return "e2e" }
+func (s *fakeSession) GenerationID() int32        { return 1 }
+func (s *fakeSession) Context() context.Context   { return s.ctx }
+func (s *fakeSession) Commit()                    {}
+
+func (s *fakeSession) MarkOffset(string, int32, int64, string)  {}
+func (s *fakeSession) ResetOffset(string, int32, int64, string) {}
+
+func (s *fakeSession) MarkMessage(*sarama.ConsumerMessage, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked++
+}
+
+func (s *fakeSession) markedCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.marked
+}
+
+type fakeClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Topic() string                            { return e2eTopic }
+func (c *fakeClaim) Partition() int32                         { return 0 }
+func (c *fakeClaim) InitialOffset() int64                     { return 0 }
+func (c *fakeClaim) HighWaterMarkOffset() int64               { return int64(cap(c.messages)) }
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
@@ -1,135 +1,354 @@
 package core
 
 import (
-	"context"
 	"fmt"
 	"net/url"
-	"os"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/joho/godotenv"
-	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"courier-service/internal/repository/dbrouter"
+	database "courier-service/pkg/database/postgres"
+	logger "courier-service/pkg/logger/zap"
+	"courier-service/pkg/ratelimiter"
+	"courier-service/pkg/tracing"
+)
+
+// App — процесс, для которого загружается конфигурация. Общие секции нужны
+// обоим, секции service и worker проверяются только у своего процесса.
+type App string
+
+const (
+	AppService App = "service"
+	AppWorker  App = "worker"
 )
 
+// Модули с отдельным уровнем логирования.
+const (
+	LogModuleGateway    = "gateway"
+	LogModuleRepository = "repository"
+	LogModuleKafka      = "kafka"
+)
+
+// Config — конфигурация процессов. Теги полей:
+//   - yaml — ключ в файле конфигурации;
+//   - env — переменная окружения;
+//   - default — значение по умолчанию;
+//   - secret — значение маскируется в --print-config;
+//   - reload — поле применяется без перезапуска (см. Reloader).
+//
+// Длительности задаются числом секунд или строкой вида "1m30s".
 type Config struct {
-	Port string
+	Log          LogConfig          `yaml:"log"`
+	Postgres     PostgresConfig     `yaml:"postgres"`
+	Tx           TxConfig           `yaml:"tx"`
+	OrderService OrderServiceConfig `yaml:"order_service"`
+	Health       HealthConfig       `yaml:"health"`
+	Tracing      TracingConfig      `yaml:"tracing"`
+	Service      ServiceConfig      `yaml:"service"`
+	Worker       WorkerConfig       `yaml:"worker"`
+
+	source *source
+}
+
+type LogConfig struct {
+	Level                 logger.LogLevel `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Format                logger.Format   `yaml:"format" env:"LOG_FORMAT" default:"json"`
+	ModuleLevels          LogModuleLevels `yaml:"module_levels" env:"LOG_MODULE_LEVELS"`
+	DebugSampleFirst      int             `yaml:"debug_sample_first" env:"LOG_DEBUG_SAMPLE_FIRST" default:"100"`
+	DebugSampleThereafter int             `yaml:"debug_sample_thereafter" env:"LOG_DEBUG_SAMPLE_THEREAFTER" default:"100"`
+}
+
+type PostgresConfig struct {
+	Host     string             `yaml:"host" env:"POSTGRES_HOST" default:"localhost"`
+	Port     string             `yaml:"port" env:"POSTGRES_PORT" default:"5432"`
+	User     string             `yaml:"user" env:"POSTGRES_USER"`
+	Password string             `yaml:"password" env:"POSTGRES_PASSWORD" secret:"true"`
+	Database string             `yaml:"database" env:"POSTGRES_DB"`
+	SSLMode  string             `yaml:"sslmode" env:"POSTGRES_SSLMODE" default:"disable"`
+	Pool     PostgresPoolConfig `yaml:"pool"`
+	Replica  ReplicaConfig      `yaml:"replica"`
+}
+
+// PostgresPoolConfig — размер пула, время жизни соединений и подключение
+// при старте.
+type PostgresPoolConfig struct {
+	MaxConns          int           `yaml:"max_conns" env:"POSTGRES_POOL_MAX_CONNS" default:"10"`
+	MinConns          int           `yaml:"min_conns" env:"POSTGRES_POOL_MIN_CONNS" default:"2"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime" env:"POSTGRES_POOL_MAX_CONN_LIFETIME_SECONDS" default:"1h"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time" env:"POSTGRES_POOL_MAX_CONN_IDLE_TIME_SECONDS" default:"30m"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period" env:"POSTGRES_POOL_HEALTH_CHECK_PERIOD_SECONDS" default:"1m"`
+	ConnectTimeout    time.Duration `yaml:"connect_timeout" env:"POSTGRES_CONNECT_TIMEOUT_SECONDS" default:"5s"`
+	ConnectAttempts   int           `yaml:"connect_attempts" env:"POSTGRES_CONNECT_ATTEMPTS" default:"5"`
+}
+
+// ReplicaConfig — реплика для запросов только на чтение. Без host все
+// запросы идут на мастер. MaxReceiveAge должен быть больше
+// wal_receiver_timeout/2: так часто реплика запрашивает ответ у мастера, если
+// WAL не приходит.
+type ReplicaConfig struct {
+	Host                 string        `yaml:"host" env:"POSTGRES_REPLICA_HOST"`
+	Port                 string        `yaml:"port" env:"POSTGRES_REPLICA_PORT"`
+	MaxLag               time.Duration `yaml:"max_lag" env:"POSTGRES_REPLICA_MAX_LAG_SECONDS" default:"5s"`
+	MaxReceiveAge        time.Duration `yaml:"max_receive_age" env:"POSTGRES_REPLICA_MAX_RECEIVE_AGE_SECONDS" default:"1m"`
+	ReadYourWritesWindow time.Duration `yaml:"read_your_writes_window" env:"POSTGRES_REPLICA_READ_YOUR_WRITES_SECONDS" default:"2s"`
+	LagCheckInterval     time.Duration `yaml:"lag_check_interval" env:"POSTGRES_REPLICA_LAG_CHECK_INTERVAL_SECONDS" default:"1s"`
+}
 
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBSSLMode  string
+type TxConfig struct {
+	IsolationLevel string `yaml:"isolation_level" env:"TX_ISOLATION_LEVEL"`
+	MaxRetries     int    `yaml:"max_retries" env:"TX_MAX_RETRIES" default:"3"`
+}
 
-	LogLevel string
+// OrderServiceConfig — клиент сервиса заказов: адрес, дедлайны, повторы,
+// кэш и circuit breaker.
+type OrderServiceConfig struct {
+	Address       string           `yaml:"address" env:"GRPC_SERVICE_ORDER_SERVER"`
+	CallTimeout   time.Duration    `yaml:"call_timeout" env:"GRPC_CALL_TIMEOUT_SECONDS" default:"5s"`
+	StreamTimeout time.Duration    `yaml:"stream_timeout" env:"GRPC_STREAM_TIMEOUT_SECONDS" default:"5m"`
+	PageSize      int              `yaml:"page_size" env:"ORDERS_PAGE_SIZE" default:"500"`
+	Retry         RetryConfig      `yaml:"retry"`
+	Cache         OrderCacheConfig `yaml:"cache"`
+	Breaker       BreakerConfig    `yaml:"breaker"`
+}
 
-	CheckFreeCouriersInterval time.Duration
-	OrderCheckCursorDelta     time.Duration
+type RetryConfig struct {
+	MaxAttempts      int           `yaml:"max_attempts" env:"RETRY_MAX_ATTEMPTS" default:"3" reload:"true"`
+	BudgetRatio      float64       `yaml:"budget_ratio" env:"RETRY_BUDGET_RATIO" default:"0.2"`
+	BudgetMinRetries int           `yaml:"budget_min_retries" env:"RETRY_BUDGET_MIN_RETRIES" default:"10"`
+	BudgetWindow     time.Duration `yaml:"budget_window" env:"RETRY_BUDGET_WINDOW_SECONDS" default:"10s"`
+}
 
-	KafkaPort    string
-	KafkaBrokers []string
-	KafkaGroupID string
-	KafkaTopic   string
+type OrderCacheConfig struct {
+	TTL     time.Duration `yaml:"ttl" env:"ORDER_CACHE_TTL_SECONDS" default:"30s"`
+	MaxSize int           `yaml:"max_size" env:"ORDER_CACHE_MAX_SIZE" default:"10000"`
+	// FetchTimeout — дедлайн запроса, общего для конкурентных промахов
+	// одного заказа; покрывает все повторы.
+	FetchTimeout time.Duration `yaml:"fetch_timeout" env:"ORDER_CACHE_FETCH_TIMEOUT_SECONDS" default:"15s"`
+}
 
-	GRPCServiceOrderServer string
+type BreakerConfig struct {
+	Window           time.Duration `yaml:"window" env:"CIRCUIT_BREAKER_WINDOW_SECONDS" default:"1m"`
+	MinRequests      int           `yaml:"min_requests" env:"CIRCUIT_BREAKER_MIN_REQUESTS" default:"10"`
+	FailureRatio     float64       `yaml:"failure_ratio" env:"CIRCUIT_BREAKER_FAILURE_RATIO" default:"0.5"`
+	OpenTimeout      time.Duration `yaml:"open_timeout" env:"CIRCUIT_BREAKER_OPEN_TIMEOUT_SECONDS" default:"30s"`
+	HalfOpenRequests int           `yaml:"half_open_requests" env:"CIRCUIT_BREAKER_HALF_OPEN_REQUESTS" default:"1"`
+}
 
-	TokenBucketCapacity   int
-	TokenBucketRefillRate int
+type HealthConfig struct {
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT_SECONDS" default:"2s"`
+	CacheTTL     time.Duration `yaml:"cache_ttl" env:"HEALTH_CACHE_TTL_SECONDS" default:"5s"`
+}
 
-	RetryMaxAttempts int
+type TracingConfig struct {
+	Exporter    string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none"`
+	File        string  `yaml:"file" env:"TRACING_FILE" default:"traces.jsonl"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" default:"1"`
+}
 
-	PprofAddress string
+// ServiceConfig — настройки только HTTP сервиса.
+type ServiceConfig struct {
+	Port                      string            `yaml:"port" env:"PORT" default:"8080"`
+	PprofAddress              string            `yaml:"pprof_addr" env:"PPROF_ADDR" default:"localhost:6060"`
+	CheckFreeCouriersInterval time.Duration     `yaml:"check_free_couriers_interval" env:"CHECK_FREE_COURIERS_INTERVAL_SECONDS" default:"10s" reload:"true"`
+	RateLimit                 RateLimitConfig   `yaml:"rate_limit"`
+	Idempotency               IdempotencyConfig `yaml:"idempotency"`
 }
 
-func LoadConfig() (*Config, error) {
-	_ = godotenv.Load(".env")
+type RateLimitConfig struct {
+	Backend     string        `yaml:"backend" env:"RATE_LIMIT_BACKEND" default:"memory"`
+	KeyBy       string        `yaml:"key_by" env:"RATE_LIMIT_KEY_BY" default:"ip"`
+	Capacity    int           `yaml:"capacity" env:"TOKEN_BUCKET_CAPACITY" default:"100" reload:"true"`
+	RefillRate  int           `yaml:"refill_rate" env:"TOKEN_BUCKET_REFILL_RATE" default:"10" reload:"true"`
+	Routes      RouteLimits   `yaml:"routes" env:"RATE_LIMIT_ROUTES" reload:"true"`
+	MaxKeys     int           `yaml:"max_keys" env:"RATE_LIMIT_MAX_KEYS" default:"10000"`
+	IdleTimeout time.Duration `yaml:"idle_timeout" env:"RATE_LIMIT_IDLE_TIMEOUT_SECONDS" default:"10m"`
+	TrustProxy  bool          `yaml:"trust_proxy" env:"RATE_LIMIT_TRUST_PROXY" default:"false"`
+}
+
+// IdempotencyConfig — хранение ключей идемпотентности. LockLease — сколько
+// ключ остается занятым незавершенным запросом; должен быть больше времени
+// выполнения самого долгого запроса.
+type IdempotencyConfig struct {
+	KeyTTL          time.Duration `yaml:"key_ttl" env:"IDEMPOTENCY_KEY_TTL_SECONDS" default:"24h"`
+	LockLease       time.Duration `yaml:"lock_lease" env:"IDEMPOTENCY_LOCK_LEASE_SECONDS" default:"30s"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" env:"IDEMPOTENCY_CLEANUP_INTERVAL_SECONDS" default:"1h"`
+}
+
+// WorkerConfig — настройки только worker'а. AdminAddress — listener
+// admin-эндпоинтов без auth, по умолчанию доступный только локально.
+type WorkerConfig struct {
+	MetricsAddress string      `yaml:"metrics_addr" env:"WORKER_METRICS_ADDR" default:":9101"`
+	AdminAddress   string      `yaml:"admin_addr" env:"WORKER_ADMIN_ADDR" default:"localhost:9102"`
+	Kafka          KafkaConfig `yaml:"kafka"`
+}
+
+type KafkaConfig struct {
+	Brokers []string `yaml:"brokers" env:"KAFKA_BROKERS"`
+	GroupID string   `yaml:"group_id" env:"KAFKA_GROUP_ID"`
+	Topic   string   `yaml:"topic" env:"KAFKA_TOPIC"`
+}
 
-	cfg := &Config{}
+// LogModuleLevels — уровни модулей; в окружении задаются строкой вида
+// "gateway=debug,repository=warn".
+type LogModuleLevels map[string]logger.LogLevel
 
-	if err := getCmd(cfg).Run(context.Background(), os.Args); err != nil {
-		return nil, err
+func (m *LogModuleLevels) UnmarshalText(text []byte) error {
+	levels := make(LogModuleLevels)
+	for _, item := range strings.Split(string(text), ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		module, level, ok := strings.Cut(item, "=")
+		if !ok {
+			return fmt.Errorf("invalid entry %q: expected module=level", item)
+		}
+		levels[strings.TrimSpace(module)] = logger.LogLevel(strings.ToLower(strings.TrimSpace(level)))
 	}
-	cfg.Port = ":" + cfg.Port
-
-	cfg.DBHost = os.Getenv("POSTGRES_HOST")
-	cfg.DBPort = os.Getenv("POSTGRES_PORT")
-	cfg.DBUser = os.Getenv("POSTGRES_USER")
-	cfg.DBPassword = os.Getenv("POSTGRES_PASSWORD")
-	cfg.DBName = os.Getenv("POSTGRES_DB")
-	cfg.DBSSLMode = os.Getenv("POSTGRES_SSLMODE")
-
-	cfg.LogLevel = os.Getenv("LOG_LEVEL")
-
-	cfg.OrderCheckCursorDelta = secondsStringToDuration(
-		os.Getenv("ORDER_CHECK_CURSOR_DELTA_SECONDS"))
-	cfg.CheckFreeCouriersInterval = secondsStringToDuration(
-		os.Getenv("CHECK_FREE_COURIERS_INTERVAL_SECONDS"))
-
-	cfg.KafkaBrokers = strings.Split(os.Getenv("KAFKA_BROKERS"), ",")
-	cfg.KafkaGroupID = os.Getenv("KAFKA_GROUP_ID")
-	cfg.KafkaTopic = os.Getenv("KAFKA_TOPIC")
-	cfg.KafkaPort = os.Getenv("KAFKA_PORT")
-
-	cfg.GRPCServiceOrderServer = os.Getenv("GRPC_SERVICE_ORDER_SERVER")
-
-	cfg.TokenBucketCapacity = toInt(os.Getenv("TOKEN_BUCKET_CAPACITY"))
-	cfg.TokenBucketRefillRate = toInt(os.Getenv("TOKEN_BUCKET_REFILL_RATE"))
-	cfg.RetryMaxAttempts = toInt(os.Getenv("RETRY_MAX_ATTEMPTS"))
-
-	cfg.PprofAddress = os.Getenv("PPROF_ADDR")
-	return cfg, nil
-}
-
-func getCmd(cfg *Config) *cli.Command {
-	return &cli.Command{
-		Flags: []cli.Flag{
-			&cli.StringFlag{
-				Name:        "port",
-				Aliases:     []string{"p"},
-				Value:       cfg.Port,
-				Usage:       "server port",
-				Sources:     cli.EnvVars("PORT"),
-				Destination: &cfg.Port,
-			},
-		},
-		Action: func(ctx context.Context, cmd *cli.Command) error { return nil },
+	*m = levels
+	return nil
+}
+
+// RouteLimits — лимиты по маршрутам; в окружении задаются строкой вида
+// "POST /courier=5:1;/delivery/assign=20:5".
+type RouteLimits map[string]ratelimiter.Limit
+
+func (r *RouteLimits) UnmarshalText(text []byte) error {
+	limits := make(RouteLimits)
+	for _, item := range strings.Split(string(text), ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		route, limit, ok := strings.Cut(item, "=")
+		if !ok {
+			return fmt.Errorf("invalid entry %q: expected route=capacity:refill", item)
+		}
+		capacity, refill, ok := strings.Cut(limit, ":")
+		if !ok {
+			return fmt.Errorf("invalid entry %q: expected route=capacity:refill", item)
+		}
+		c, err := strconv.Atoi(strings.TrimSpace(capacity))
+		if err != nil {
+			return fmt.Errorf("invalid capacity in entry %q", item)
+		}
+		rate, err := strconv.Atoi(strings.TrimSpace(refill))
+		if err != nil {
+			return fmt.Errorf("invalid refill rate in entry %q", item)
+		}
+		limits[strings.TrimSpace(route)] = ratelimiter.Limit{Capacity: c, RefillRate: rate}
 	}
+	*r = limits
+	return nil
 }
 
-func toInt(value string) int {
-	integer, err := strconv.Atoi(value)
-	if err != nil {
-		panic(fmt.Sprintf("invalid integer config value %q: %v", value, err))
+// routeLimitYAML — лимит маршрута в файле конфигурации.
+type routeLimitYAML struct {
+	Capacity   int `yaml:"capacity"`
+	RefillRate int `yaml:"refill_rate"`
+}
+
+func (r *RouteLimits) UnmarshalYAML(node *yaml.Node) error {
+	var raw map[string]routeLimitYAML
+	if err := node.Decode(&raw); err != nil {
+		return err
 	}
-	return integer
+	limits := make(RouteLimits, len(raw))
+	for route, limit := range raw {
+		limits[route] = ratelimiter.Limit{Capacity: limit.Capacity, RefillRate: limit.RefillRate}
+	}
+	*r = limits
+	return nil
 }
 
-func secondsStringToDuration(value string) time.Duration {
-	duration := toInt(value)
-	return time.Duration(duration) * time.Second
+func (r RouteLimits) MarshalYAML() (interface{}, error) {
+	raw := make(map[string]routeLimitYAML, len(r))
+	for route, limit := range r {
+		raw[route] = routeLimitYAML{Capacity: limit.Capacity, RefillRate: limit.RefillRate}
+	}
+	return raw, nil
+}
+
+// Addr — адрес, который слушает HTTP сервис.
+func (c ServiceConfig) Addr() string {
+	return ":" + c.Port
 }
 
 func (c *Config) PostgresDSN() string {
-	ssl := c.DBSSLMode
-	if ssl == "" {
-		ssl = "disable"
+	return c.dsn(c.Postgres.Host, c.Postgres.Port)
+}
+
+func (c *Config) dsn(host, port string) string {
+	user := url.QueryEscape(c.Postgres.User)
+	pass := url.QueryEscape(c.Postgres.Password)
+	db := url.PathEscape(c.Postgres.Database)
+
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
+		user, pass, host, port, db, url.QueryEscape(c.Postgres.SSLMode),
+	)
+}
+
+// Database собирает настройки пула соединений с Postgres.
+func (c *Config) Database() database.Config {
+	pool := c.Postgres.Pool
+	return database.Config{
+		DSN:               c.PostgresDSN(),
+		MaxConns:          int32(pool.MaxConns),
+		MinConns:          int32(pool.MinConns),
+		MaxConnLifetime:   pool.MaxConnLifetime,
+		MaxConnIdleTime:   pool.MaxConnIdleTime,
+		HealthCheckPeriod: pool.HealthCheckPeriod,
+		ConnectTimeout:    pool.ConnectTimeout,
+		ConnectAttempts:   pool.ConnectAttempts,
 	}
-	host := c.DBHost
-	if host == "" {
-		host = "localhost"
+}
+
+// ReplicaDatabase собирает настройки пула реплики: те же учетные данные и
+// размер пула, что у мастера, но другой хост. ok ложно, если реплика не задана.
+func (c *Config) ReplicaDatabase() (cfg database.Config, ok bool) {
+	replica := c.Postgres.Replica
+	if replica.Host == "" {
+		return database.Config{}, false
 	}
-	port := c.DBPort
+	cfg = c.Database()
+	port := replica.Port
 	if port == "" {
-		port = "5432"
+		port = c.Postgres.Port
+	}
+	cfg.DSN = c.dsn(replica.Host, port)
+	return cfg, true
+}
+
+// DBRouter собирает правила маршрутизации чтений на реплику.
+func (c *Config) DBRouter() dbrouter.Config {
+	return dbrouter.Config{
+		ReadYourWritesWindow: c.Postgres.Replica.ReadYourWritesWindow,
+		MaxLag:               c.Postgres.Replica.MaxLag,
+		MaxReceiveAge:        c.Postgres.Replica.MaxReceiveAge,
 	}
+}
 
-	user := url.QueryEscape(c.DBUser)
-	pass := url.QueryEscape(c.DBPassword)
-	db := url.PathEscape(c.DBName)
+func (c *Config) Logging() logger.Config {
+	return logger.Config{
+		Level:   c.Log.Level,
+		Format:  c.Log.Format,
+		Modules: c.Log.ModuleLevels,
+		Sampling: logger.Sampling{
+			First:      c.Log.DebugSampleFirst,
+			Thereafter: c.Log.DebugSampleThereafter,
+		},
+	}
+}
 
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		user, pass, host, port, db, url.QueryEscape(ssl),
-	)
+// TracingFor собирает настройки трассировки для процесса serviceName.
+func (c *Config) TracingFor(serviceName string) tracing.Config {
+	return tracing.Config{
+		ServiceName: serviceName,
+		Exporter:    c.Tracing.Exporter,
+		FilePath:    c.Tracing.File,
+		SampleRatio: c.Tracing.SampleRatio,
+	}
 }
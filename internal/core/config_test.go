@@ -0,0 +1,166 @@
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	logger "courier-service/pkg/logger/zap"
+	"courier-service/pkg/ratelimiter"
+)
+
+func envMap(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+var baseEnv = map[string]string{
+	"POSTGRES_USER":             "courier",
+	"POSTGRES_PASSWORD":         "s3cret",
+	"POSTGRES_DB":               "courier",
+	"GRPC_SERVICE_ORDER_SERVER": "localhost:50051",
+	"KAFKA_BROKERS":             "kafka-1:9092, kafka-2:9092",
+	"KAFKA_GROUP_ID":            "courier",
+	"KAFKA_TOPIC":               "order.changed",
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := load(AppService, []string{"service"}, envMap(baseEnv), &bytes.Buffer{})
+	require.NoError(t, err)
+
+	assert.Equal(t, ":8080", cfg.Service.Addr())
+	assert.Equal(t, logger.LogLevelInfo, cfg.Log.Level)
+	assert.Equal(t, 5*time.Second, cfg.OrderService.CallTimeout)
+	assert.Equal(t, 24*time.Hour, cfg.Service.Idempotency.KeyTTL)
+	assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, cfg.Worker.Kafka.Brokers)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfig(t, "config.yaml", `
+log:
+  level: warn
+order_service:
+  call_timeout: 3s
+  retry:
+    max_attempts: 5
+service:
+  port: "9000"
+  rate_limit:
+    routes:
+      POST /courier:
+        capacity: 5
+        refill_rate: 1
+`)
+	env := map[string]string{"CONFIG_FILE": path, "GRPC_CALL_TIMEOUT_SECONDS": "7", "PORT": "9100"}
+	for k, v := range baseEnv {
+		env[k] = v
+	}
+
+	cfg, err := load(AppService, []string{"service", "--log-level", "debug"}, envMap(env), &bytes.Buffer{})
+	require.NoError(t, err)
+
+	// флаг важнее файла
+	assert.Equal(t, logger.LogLevelDebug, cfg.Log.Level)
+	// окружение важнее файла, секунды и строки длительностей равнозначны
+	assert.Equal(t, 7*time.Second, cfg.OrderService.CallTimeout)
+	assert.Equal(t, ":9100", cfg.Service.Addr())
+	// файл важнее значений по умолчанию
+	assert.Equal(t, 5, cfg.OrderService.Retry.MaxAttempts)
+	assert.Equal(t, ratelimiter.Limit{Capacity: 5, RefillRate: 1}, cfg.Service.RateLimit.Routes["POST /courier"])
+	// значение по умолчанию остается, если его никто не переопределил
+	assert.Equal(t, 3, cfg.Tx.MaxRetries)
+}
+
+func TestLoad_ReportsAllErrors(t *testing.T) {
+	path := writeConfig(t, "config.yml", `
+log:
+  levle: debug
+order_service:
+  call_timeout: soon
+`)
+	env := map[string]string{
+		"TX_ISOLATION_LEVEL":            "chaos",
+		"CIRCUIT_BREAKER_FAILURE_RATIO": "2",
+		"POSTGRES_POOL_MIN_CONNS":       "20",
+	}
+
+	_, err := load(AppWorker, []string{"worker", "-c", path}, envMap(env), &bytes.Buffer{})
+	require.Error(t, err)
+
+	for _, msg := range []string{
+		"log.levle: unknown key",
+		`order_service.call_timeout (GRPC_CALL_TIMEOUT_SECONDS): invalid duration "soon"`,
+		`tx.isolation_level (TX_ISOLATION_LEVEL): unknown isolation level "chaos"`,
+		"order_service.breaker.failure_ratio (CIRCUIT_BREAKER_FAILURE_RATIO): must be in (0, 1]",
+		"postgres.user (POSTGRES_USER): required",
+		"postgres.pool.min_conns (POSTGRES_POOL_MIN_CONNS): must be between 0 and max_conns (10)",
+		"order_service.address (GRPC_SERVICE_ORDER_SERVER): required",
+		"worker.kafka.brokers (KAFKA_BROKERS): required",
+		"worker.kafka.topic (KAFKA_TOPIC): required",
+	} {
+		assert.Contains(t, err.Error(), msg)
+	}
+}
+
+func TestLoad_SectionsPerApp(t *testing.T) {
+	env := map[string]string{"RATE_LIMIT_BACKEND": "redis"}
+	for k, v := range baseEnv {
+		env[k] = v
+	}
+	delete(env, "KAFKA_TOPIC")
+
+	_, err := load(AppWorker, []string{"worker"}, envMap(env), &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "worker.kafka.topic")
+	assert.NotContains(t, err.Error(), "service.rate_limit.backend")
+
+	_, err = load(AppService, []string{"service"}, envMap(env), &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service.rate_limit.backend")
+	assert.NotContains(t, err.Error(), "worker.kafka.topic")
+}
+
+func TestLoad_UnsupportedFileFormat(t *testing.T) {
+	path := writeConfig(t, "config.toml", `[log]`)
+
+	_, err := load(AppService, []string{"service", "--config", path}, envMap(baseEnv), &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported format")
+}
+
+func TestLoad_PrintConfig(t *testing.T) {
+	out := &bytes.Buffer{}
+
+	cfg, err := load(AppWorker, []string{"worker", "--print-config"}, envMap(baseEnv), out)
+	require.ErrorIs(t, err, ErrExitRequested)
+	assert.Nil(t, cfg)
+
+	dump := out.String()
+	assert.Contains(t, dump, "password: '******'")
+	assert.NotContains(t, dump, "s3cret")
+	assert.Contains(t, dump, "call_timeout: 5s")
+	assert.Contains(t, dump, "topic: order.changed")
+	assert.NotContains(t, dump, "rate_limit:")
+}
+
+func TestLoad_Help(t *testing.T) {
+	out := &bytes.Buffer{}
+
+	_, err := load(AppService, []string{"service", "--help"}, envMap(baseEnv), out)
+	require.ErrorIs(t, err, ErrExitRequested)
+	assert.Contains(t, out.String(), "--print-config")
+}
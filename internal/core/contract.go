@@ -0,0 +1,7 @@
+package core
+
+type reloadLogger interface {
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
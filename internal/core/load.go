@@ -0,0 +1,174 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"github.com/urfave/cli/v3"
+)
+
+// ErrExitRequested возвращается, когда процесс должен завершиться сразу
+// после разбора аргументов: показана справка или конфигурация.
+var ErrExitRequested = errors.New("exit requested")
+
+const (
+	flagConfig      = "config"
+	flagPrintConfig = "print-config"
+)
+
+// overrideFlags — флаги, которые переопределяют поля конфигурации.
+var overrideFlags = map[string]string{
+	"port":      "service.port",
+	"log-level": "log.level",
+}
+
+// LoadConfig собирает конфигурацию процесса app. Источники по возрастанию
+// приоритета: значения по умолчанию, файл (--config или CONFIG_FILE),
+// переменные окружения (в том числе из .env), флаги командной строки.
+// Все ошибки разбора и проверки возвращаются одной ошибкой.
+func LoadConfig(app App) (*Config, error) {
+	_ = godotenv.Load(".env")
+
+	return load(app, os.Args, os.LookupEnv, os.Stdout)
+}
+
+func load(app App, args []string, lookupEnv func(string) (string, bool), out io.Writer) (*Config, error) {
+	var (
+		ran          bool
+		configFile   string
+		printConfig  bool
+		flagOverride = make(map[string]string)
+	)
+
+	cmd := &cli.Command{
+		Name:   string(app),
+		Usage:  "courier " + string(app),
+		Writer: out,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        flagConfig,
+				Aliases:     []string{"c"},
+				Usage:       "path to YAML config file (env CONFIG_FILE)",
+				Destination: &configFile,
+			},
+			&cli.BoolFlag{
+				Name:        flagPrintConfig,
+				Usage:       "print effective config with secrets redacted and exit",
+				Destination: &printConfig,
+			},
+			&cli.StringFlag{
+				Name:    "port",
+				Aliases: []string{"p"},
+				Usage:   "server port",
+			},
+			&cli.StringFlag{
+				Name:  "log-level",
+				Usage: "root log level",
+			},
+		},
+		Action: func(_ context.Context, cmd *cli.Command) error {
+			ran = true
+			for name := range overrideFlags {
+				if cmd.IsSet(name) {
+					flagOverride[name] = cmd.String(name)
+				}
+			}
+			return nil
+		},
+	}
+	if err := cmd.Run(context.Background(), args); err != nil {
+		return nil, err
+	}
+	if !ran {
+		return nil, ErrExitRequested
+	}
+
+	if configFile == "" {
+		configFile, _ = lookupEnv("CONFIG_FILE")
+	}
+	src := &source{app: app, file: configFile, lookupEnv: lookupEnv, flags: flagOverride}
+
+	if printConfig {
+		cfg, errs := src.read()
+		if len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+		data, err := cfg.Redacted(app)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := out.Write(data); err != nil {
+			return nil, err
+		}
+		return nil, ErrExitRequested
+	}
+
+	return src.load()
+}
+
+// source — откуда собрана конфигурация; сохраняется в Config, чтобы
+// перечитать те же источники при перезагрузке.
+type source struct {
+	app       App
+	file      string
+	lookupEnv func(string) (string, bool)
+	flags     map[string]string
+}
+
+func (s *source) read() (*Config, []error) {
+	cfg := &Config{source: s}
+	errs := applyDefaults(cfg)
+	if s.file != "" {
+		errs = append(errs, applyFile(cfg, s.file)...)
+	}
+	errs = append(errs, applyEnv(cfg, s.lookupEnv)...)
+	errs = append(errs, applyFlags(cfg, s.flags)...)
+	return cfg, errs
+}
+
+func (s *source) load() (*Config, error) {
+	cfg, errs := s.read()
+	if err := cfg.validate(s.app); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid config:\n%w", errors.Join(errs...))
+	}
+	return cfg, nil
+}
+
+func applyFile(cfg *Config, path string) []error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+	default:
+		return []error{fmt.Errorf("config file %s: unsupported format, expected .yaml or .yml", path)}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []error{fmt.Errorf("config file: %w", err)}
+	}
+	return applyYAML(cfg, data)
+}
+
+func applyFlags(cfg *Config, values map[string]string) []error {
+	byPath := make(map[string]field)
+	for _, f := range fields(reflect.ValueOf(cfg).Elem(), "") {
+		byPath[f.path] = f
+	}
+
+	var errs []error
+	for name, value := range values {
+		f := byPath[overrideFlags[name]]
+		if err := setString(f.value, value); err != nil {
+			errs = append(errs, fmt.Errorf("--%s: %v", name, err))
+		}
+	}
+	return errs
+}
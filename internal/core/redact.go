@@ -0,0 +1,49 @@
+package core
+
+import (
+	"bytes"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "******"
+
+// Redacted возвращает конфигурацию процесса app в YAML: секреты замаскированы,
+// секция другого процесса опущена.
+func (c *Config) Redacted(app App) ([]byte, error) {
+	cp := *c
+	for _, f := range fields(reflect.ValueOf(&cp).Elem(), "") {
+		if f.tag.Get("secret") == "true" && !f.value.IsZero() {
+			f.value.SetString(redacted)
+		}
+	}
+
+	var doc yaml.Node
+	if err := doc.Encode(&cp); err != nil {
+		return nil, err
+	}
+	skip := "worker"
+	if app == AppWorker {
+		skip = "service"
+	}
+	content := doc.Content[:0]
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value == skip {
+			continue
+		}
+		content = append(content, doc.Content[i], doc.Content[i+1])
+	}
+	doc.Content = content
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
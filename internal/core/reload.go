@@ -0,0 +1,181 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrNoSource — конфигурация собрана вручную, а не через LoadConfig, и
+// перечитывать нечего.
+var ErrNoSource = errors.New("config has no source to reload from")
+
+// Change — поле, значение которого изменилось при перезагрузке.
+type Change struct {
+	Path string
+	Old  string
+	New  string
+	// Reloadable — изменение применяется без перезапуска.
+	Reloadable bool
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s -> %s", c.Path, c.Old, c.New)
+}
+
+// Reload перечитывает те же источники, из которых конфигурация была
+// загружена, и проверяет результат целиком. Из новой конфигурации берутся
+// только поля с тегом reload; остальные изменения возвращаются в списке, но
+// вступят в силу только после перезапуска.
+func (c *Config) Reload() (*Config, []Change, error) {
+	if c.source == nil {
+		return nil, nil, ErrNoSource
+	}
+	next, err := c.source.load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied := *c
+	current := fields(reflect.ValueOf(c).Elem(), "")
+	target := fields(reflect.ValueOf(&applied).Elem(), "")
+	loaded := fields(reflect.ValueOf(next).Elem(), "")
+
+	var changes []Change
+	for i, f := range current {
+		if reflect.DeepEqual(f.value.Interface(), loaded[i].value.Interface()) {
+			continue
+		}
+		change := Change{
+			Path:       f.path,
+			Old:        displayValue(f),
+			New:        displayValue(loaded[i]),
+			Reloadable: f.tag.Get("reload") == "true",
+		}
+		if change.Reloadable {
+			target[i].value.Set(loaded[i].value)
+		}
+		changes = append(changes, change)
+	}
+	return &applied, changes, nil
+}
+
+func displayValue(f field) string {
+	if f.tag.Get("secret") == "true" {
+		return redacted
+	}
+	return fmt.Sprintf("%v", f.value.Interface())
+}
+
+// Reloader держит действующую конфигурацию и перезагружает ее по SIGHUP или
+// при изменении файла. Некорректная конфигурация отклоняется целиком, и
+// продолжает действовать предыдущая.
+type Reloader struct {
+	mu       sync.Mutex
+	current  *Config
+	modTime  time.Time
+	onReload []func(*Config)
+	logger   reloadLogger
+}
+
+func NewReloader(cfg *Config, logger reloadLogger) *Reloader {
+	r := &Reloader{current: cfg, logger: logger}
+	r.modTime, _ = r.fileModTime()
+	return r
+}
+
+// OnReload регистрирует функцию, которая получает конфигурацию после
+// каждой перезагрузки с изменениями. Вызывается до запуска Run.
+func (r *Reloader) OnReload(fn func(cfg *Config)) {
+	r.onReload = append(r.onReload, fn)
+}
+
+// Current возвращает действующую конфигурацию.
+func (r *Reloader) Current() *Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// Reload перечитывает конфигурацию и применяет изменения подписчиками.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, changes, err := r.current.Reload()
+	if err != nil {
+		r.logger.Errorf("Config reload rejected, keeping previous config: %v", err)
+		return err
+	}
+
+	applied := 0
+	for _, change := range changes {
+		if change.Reloadable {
+			applied++
+			r.logger.Infof("Config changed: %s", change)
+		} else {
+			r.logger.Warnf("Config change requires restart, ignored until then: %s", change)
+		}
+	}
+	if applied == 0 {
+		r.logger.Infof("Config reloaded, nothing to apply")
+		return nil
+	}
+
+	r.current = next
+	for _, fn := range r.onReload {
+		fn(next)
+	}
+	return nil
+}
+
+// Run перезагружает конфигурацию по SIGHUP, а также когда меняется время
+// модификации файла конфигурации; файл проверяется раз в pollInterval.
+func (r *Reloader) Run(ctx context.Context, pollInterval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	r.watch(ctx, hup, ticker.C)
+}
+
+func (r *Reloader) watch(ctx context.Context, hup <-chan os.Signal, poll <-chan time.Time) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			r.logger.Infof("SIGHUP received, reloading config")
+			_ = r.Reload()
+		case <-poll:
+			modTime, err := r.fileModTime()
+			if err != nil || modTime.Equal(r.modTime) {
+				continue
+			}
+			r.modTime = modTime
+			r.logger.Infof("Config file changed, reloading config")
+			_ = r.Reload()
+		}
+	}
+}
+
+func (r *Reloader) fileModTime() (time.Time, error) {
+	src := r.current.source
+	if src == nil || src.file == "" {
+		return time.Time{}, ErrNoSource
+	}
+	info, err := os.Stat(src.file)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) record(level, format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, level+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Infof(format string, args ...interface{}) {
+	l.record("info", format, args...)
+}
+
+func (l *recordingLogger) Warnf(format string, args ...interface{}) {
+	l.record("warn", format, args...)
+}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.record("error", format, args...)
+}
+
+func (l *recordingLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.lines, "\n")
+}
+
+const reloadBase = `
+service:
+  check_free_couriers_interval: 10s
+  rate_limit:
+    capacity: 100
+`
+
+func loadForReload(t *testing.T, content string) (*Config, string) {
+	t.Helper()
+	path := writeConfig(t, "config.yaml", content)
+	cfg, err := load(AppService, []string{"service", "-c", path}, envMap(baseEnv), &bytes.Buffer{})
+	require.NoError(t, err)
+	return cfg, path
+}
+
+func TestConfig_Reload(t *testing.T) {
+	cfg, path := loadForReload(t, reloadBase)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+service:
+  port: "9000"
+  check_free_couriers_interval: 30s
+  rate_limit:
+    capacity: 200
+`), 0o600))
+
+	next, changes, err := cfg.Reload()
+	require.NoError(t, err)
+
+	assert.Equal(t, []Change{
+		{Path: "service.port", Old: "8080", New: "9000"},
+		{Path: "service.check_free_couriers_interval", Old: "10s", New: "30s", Reloadable: true},
+		{Path: "service.rate_limit.capacity", Old: "100", New: "200", Reloadable: true},
+	}, changes)
+	assert.Equal(t, 30*time.Second, next.Service.CheckFreeCouriersInterval)
+	assert.Equal(t, 200, next.Service.RateLimit.Capacity)
+	// порт меняется только после перезапуска
+	assert.Equal(t, "8080", next.Service.Port)
+	// исходная конфигурация не меняется
+	assert.Equal(t, 100, cfg.Service.RateLimit.Capacity)
+}
+
+func TestConfig_ReloadWithoutSource(t *testing.T) {
+	_, _, err := (&Config{}).Reload()
+	assert.ErrorIs(t, err, ErrNoSource)
+}
+
+func TestReloader_Reload(t *testing.T) {
+	cfg, path := loadForReload(t, reloadBase)
+	log := &recordingLogger{}
+	r := NewReloader(cfg, log)
+
+	var applied []*Config
+	r.OnReload(func(cfg *Config) { applied = append(applied, cfg) })
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+service:
+  rate_limit:
+    capacity: 5
+`), 0o600))
+	require.NoError(t, r.Reload())
+	require.Len(t, applied, 1)
+	assert.Equal(t, 5, applied[0].Service.RateLimit.Capacity)
+	assert.Same(t, applied[0], r.Current())
+	assert.Contains(t, log.String(), "info Config changed: service.rate_limit.capacity: 100 -> 5")
+
+	// некорректная конфигурация отклоняется, действует предыдущая
+	require.NoError(t, os.WriteFile(path, []byte(`
+service:
+  rate_limit:
+    capacity: -1
+`), 0o600))
+	err := r.Reload()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service.rate_limit.capacity")
+	assert.Len(t, applied, 1)
+	assert.Equal(t, 5, r.Current().Service.RateLimit.Capacity)
+	assert.Contains(t, log.String(), "error Config reload rejected, keeping previous config")
+
+	// изменения, требующие перезапуска, только логируются
+	require.NoError(t, os.WriteFile(path, []byte(`
+service:
+  port: "9000"
+  rate_limit:
+    capacity: 5
+`), 0o600))
+	require.NoError(t, r.Reload())
+	assert.Len(t, applied, 1)
+	assert.Contains(t, log.String(), "warn Config change requires restart, ignored until then: service.port: 8080 -> 9000")
+}
+
+func TestReloader_Watch(t *testing.T) {
+	cfg, path := loadForReload(t, reloadBase)
+	r := NewReloader(cfg, &recordingLogger{})
+
+	reloaded := make(chan int, 2)
+	r.OnReload(func(cfg *Config) { reloaded <- cfg.Service.RateLimit.Capacity })
+
+	hup := make(chan os.Signal)
+	poll := make(chan time.Time)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.watch(ctx, hup, poll)
+		close(done)
+	}()
+
+	require.NoError(t, os.WriteFile(path, []byte("service:\n  rate_limit:\n    capacity: 7\n"), 0o600))
+	hup <- os.Interrupt
+	assert.Equal(t, 7, <-reloaded)
+
+	// файл изменился: время модификации отличается от запомненного
+	require.NoError(t, os.WriteFile(path, []byte("service:\n  rate_limit:\n    capacity: 8\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Hour)))
+	poll <- time.Now()
+	assert.Equal(t, 8, <-reloaded)
+
+	// без изменений файла опрос ничего не перезагружает
+	poll <- time.Now()
+	cancel()
+	<-done
+	assert.Empty(t, reloaded)
+}
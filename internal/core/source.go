@@ -0,0 +1,246 @@
+package core
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	yamlUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+)
+
+// field — лист конфигурации: значение и его описание из тегов.
+type field struct {
+	path  string
+	value reflect.Value
+	tag   reflect.StructTag
+}
+
+// fields обходит секции конфигурации и возвращает все листья; path — путь
+// из yaml ключей, например "order_service.retry.max_attempts".
+func fields(v reflect.Value, prefix string) []field {
+	var out []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := yamlKey(sf)
+		if key == "" {
+			continue
+		}
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		fv := v.Field(i)
+		if isSection(fv) {
+			out = append(out, fields(fv, path)...)
+			continue
+		}
+		out = append(out, field{path: path, value: fv, tag: sf.Tag})
+	}
+	return out
+}
+
+func yamlKey(sf reflect.StructField) string {
+	key, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+	if key == "-" {
+		return ""
+	}
+	return key
+}
+
+func isSection(v reflect.Value) bool {
+	return v.Kind() == reflect.Struct && !v.Addr().Type().Implements(textUnmarshalerType)
+}
+
+// envName — переменная окружения поля, для сообщений об ошибках.
+func (f field) envName() string {
+	return f.tag.Get("env")
+}
+
+func (f field) errorf(format string, args ...interface{}) error {
+	name := f.path
+	if env := f.envName(); env != "" {
+		name += " (" + env + ")"
+	}
+	return fmt.Errorf("%s: %s", name, fmt.Sprintf(format, args...))
+}
+
+// setString разбирает строковое значение из окружения, флага или тега
+// default в тип поля.
+func setString(v reflect.Value, s string) error {
+	if v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if v.Type() == durationType {
+		d, err := parseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", s)
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", s)
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", s)
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// parseDuration принимает число секунд, как в переменных *_SECONDS, или
+// строку вида "1m30s".
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if seconds, err := strconv.Atoi(s); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+func applyDefaults(cfg *Config) []error {
+	var errs []error
+	for _, f := range fields(reflect.ValueOf(cfg).Elem(), "") {
+		def, ok := f.tag.Lookup("default")
+		if !ok {
+			continue
+		}
+		if err := setString(f.value, def); err != nil {
+			errs = append(errs, f.errorf("invalid default: %v", err))
+		}
+	}
+	return errs
+}
+
+// applyEnv переопределяет поля непустыми переменными окружения.
+func applyEnv(cfg *Config, lookupEnv func(string) (string, bool)) []error {
+	var errs []error
+	for _, f := range fields(reflect.ValueOf(cfg).Elem(), "") {
+		name := f.envName()
+		if name == "" {
+			continue
+		}
+		value, ok := lookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := setString(f.value, value); err != nil {
+			errs = append(errs, f.errorf("%v", err))
+		}
+	}
+	return errs
+}
+
+// applyYAML переопределяет поля значениями из файла. Скаляры разбираются так
+// же, как переменные окружения, поэтому "30" и "30s" означают одно и то же.
+// Неизвестные ключи считаются ошибкой: опечатка не должна молча пропасть.
+func applyYAML(cfg *Config, data []byte) []error {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return []error{fmt.Errorf("config file: %w", err)}
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	return applyNode(reflect.ValueOf(cfg).Elem(), doc.Content[0], "")
+}
+
+func applyNode(v reflect.Value, node *yaml.Node, prefix string) []error {
+	if node.Kind != yaml.MappingNode {
+		return []error{fmt.Errorf("%s: expected a mapping", displayPath(prefix))}
+	}
+
+	byKey := make(map[string]reflect.Value)
+	tags := make(map[string]reflect.StructTag)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if key := yamlKey(t.Field(i)); key != "" {
+			byKey[key] = v.Field(i)
+			tags[key] = t.Field(i).Tag
+		}
+	}
+
+	var errs []error
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		fv, ok := byKey[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown key", path))
+			continue
+		}
+		if isSection(fv) {
+			errs = append(errs, applyNode(fv, value, path)...)
+			continue
+		}
+		f := field{path: path, value: fv, tag: tags[key]}
+		if err := setNode(fv, value); err != nil {
+			errs = append(errs, f.errorf("%v", err))
+		}
+	}
+	return errs
+}
+
+func setNode(v reflect.Value, node *yaml.Node) error {
+	switch {
+	case v.Addr().Type().Implements(yamlUnmarshalerType):
+		return node.Decode(v.Addr().Interface())
+	case node.Kind == yaml.ScalarNode:
+		return setString(v, node.Value)
+	case node.Kind == yaml.SequenceNode && v.Kind() == reflect.Slice,
+		node.Kind == yaml.MappingNode && v.Kind() == reflect.Map:
+		return node.Decode(v.Addr().Interface())
+	default:
+		return fmt.Errorf("unexpected value at line %d", node.Line)
+	}
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "config file"
+	}
+	return path
+}
@@ -0,0 +1,199 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	logger "courier-service/pkg/logger/zap"
+	"courier-service/pkg/tracing"
+)
+
+// validator собирает все ошибки конфигурации, чтобы показать их разом, а
+// не по одной за запуск.
+type validator struct {
+	fields map[string]field
+	errs   []error
+}
+
+func newValidator(cfg *Config) *validator {
+	v := &validator{fields: make(map[string]field)}
+	for _, f := range fields(reflect.ValueOf(cfg).Elem(), "") {
+		v.fields[f.path] = f
+	}
+	return v
+}
+
+// check добавляет ошибку поля path, если ok ложно. Сообщение содержит и
+// ключ файла, и переменную окружения, чтобы было понятно, что исправлять.
+func (v *validator) check(ok bool, path, format string, args ...interface{}) {
+	if ok {
+		return
+	}
+	f, found := v.fields[path]
+	if !found {
+		f = field{path: path}
+	}
+	v.errs = append(v.errs, f.errorf(format, args...))
+}
+
+func (v *validator) required(value, path string) {
+	v.check(value != "", path, "required")
+}
+
+func (v *validator) positive(value time.Duration, path string) {
+	v.check(value > 0, path, "must be positive, got %s", value)
+}
+
+func (c *Config) validate(app App) error {
+	v := newValidator(c)
+
+	c.validateLog(v)
+	v.required(c.Postgres.User, "postgres.user")
+	v.required(c.Postgres.Database, "postgres.database")
+	c.validatePool(v)
+	if c.Postgres.Replica.Host != "" {
+		r := c.Postgres.Replica
+		v.check(r.MaxLag >= 0, "postgres.replica.max_lag", "must not be negative")
+		v.positive(r.MaxReceiveAge, "postgres.replica.max_receive_age")
+		v.check(r.ReadYourWritesWindow >= 0, "postgres.replica.read_your_writes_window", "must not be negative")
+		v.positive(r.LagCheckInterval, "postgres.replica.lag_check_interval")
+	}
+	v.check(validIsolationLevel(c.Tx.IsolationLevel), "tx.isolation_level",
+		"unknown isolation level %q", c.Tx.IsolationLevel)
+	v.check(c.Tx.MaxRetries >= 0, "tx.max_retries", "must not be negative")
+	c.validateOrderService(v)
+	v.positive(c.Health.CheckTimeout, "health.check_timeout")
+	v.check(c.Health.CacheTTL >= 0, "health.cache_ttl", "must not be negative")
+	c.validateTracing(v)
+
+	switch app {
+	case AppService:
+		c.validateService(v)
+	case AppWorker:
+		c.validateWorker(v)
+	}
+	return errors.Join(v.errs...)
+}
+
+func (c *Config) validateLog(v *validator) {
+	v.check(validLogLevel(c.Log.Level), "log.level", "unknown level %q", c.Log.Level)
+	v.check(c.Log.Format == logger.FormatJSON || c.Log.Format == logger.FormatConsole,
+		"log.format", "unknown format %q", c.Log.Format)
+	for module, level := range c.Log.ModuleLevels {
+		v.check(validLogModule(module), "log.module_levels", "unknown module %q", module)
+		v.check(validLogLevel(level), "log.module_levels", "unknown level %q for module %q", level, module)
+	}
+	v.check(c.Log.DebugSampleFirst >= 0, "log.debug_sample_first", "must not be negative")
+	v.check(c.Log.DebugSampleThereafter >= 0, "log.debug_sample_thereafter", "must not be negative")
+}
+
+func (c *Config) validatePool(v *validator) {
+	p := c.Postgres.Pool
+	v.check(p.MaxConns >= 1, "postgres.pool.max_conns", "must be at least 1")
+	v.check(p.MinConns >= 0 && p.MinConns <= p.MaxConns, "postgres.pool.min_conns",
+		"must be between 0 and max_conns (%d)", p.MaxConns)
+	v.positive(p.MaxConnLifetime, "postgres.pool.max_conn_lifetime")
+	v.positive(p.MaxConnIdleTime, "postgres.pool.max_conn_idle_time")
+	v.positive(p.HealthCheckPeriod, "postgres.pool.health_check_period")
+	v.positive(p.ConnectTimeout, "postgres.pool.connect_timeout")
+	v.check(p.ConnectAttempts >= 1, "postgres.pool.connect_attempts", "must be at least 1")
+}
+
+func (c *Config) validateOrderService(v *validator) {
+	s := c.OrderService
+	v.required(s.Address, "order_service.address")
+	v.positive(s.CallTimeout, "order_service.call_timeout")
+	v.positive(s.StreamTimeout, "order_service.stream_timeout")
+	v.check(s.PageSize > 0, "order_service.page_size", "must be positive")
+
+	v.check(s.Retry.MaxAttempts >= 1, "order_service.retry.max_attempts", "must be at least 1")
+	v.check(s.Retry.BudgetRatio >= 0, "order_service.retry.budget_ratio", "must not be negative")
+	v.check(s.Retry.BudgetMinRetries >= 0, "order_service.retry.budget_min_retries", "must not be negative")
+	v.positive(s.Retry.BudgetWindow, "order_service.retry.budget_window")
+
+	v.check(s.Cache.TTL >= 0, "order_service.cache.ttl", "must not be negative")
+	v.check(s.Cache.MaxSize > 0, "order_service.cache.max_size", "must be positive")
+	v.positive(s.Cache.FetchTimeout, "order_service.cache.fetch_timeout")
+
+	v.positive(s.Breaker.Window, "order_service.breaker.window")
+	v.check(s.Breaker.MinRequests >= 1, "order_service.breaker.min_requests", "must be at least 1")
+	v.check(s.Breaker.FailureRatio > 0 && s.Breaker.FailureRatio <= 1,
+		"order_service.breaker.failure_ratio", "must be in (0, 1], got %v", s.Breaker.FailureRatio)
+	v.positive(s.Breaker.OpenTimeout, "order_service.breaker.open_timeout")
+	v.check(s.Breaker.HalfOpenRequests >= 1, "order_service.breaker.half_open_requests", "must be at least 1")
+}
+
+func (c *Config) validateTracing(v *validator) {
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	case tracing.ExporterFile:
+		v.required(c.Tracing.File, "tracing.file")
+	default:
+		v.check(false, "tracing.exporter", "unknown exporter %q", c.Tracing.Exporter)
+	}
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1,
+		"tracing.sample_ratio", "must be in [0, 1], got %v", c.Tracing.SampleRatio)
+}
+
+func (c *Config) validateService(v *validator) {
+	s := c.Service
+	v.required(s.Port, "service.port")
+	v.required(s.PprofAddress, "service.pprof_addr")
+	v.positive(s.CheckFreeCouriersInterval, "service.check_free_couriers_interval")
+
+	rl := s.RateLimit
+	v.check(rl.Backend == "memory" || rl.Backend == "postgres",
+		"service.rate_limit.backend", "unknown backend %q", rl.Backend)
+	v.check(rl.KeyBy == "global" || rl.KeyBy == "ip" || rl.KeyBy == "api_key",
+		"service.rate_limit.key_by", "unknown key %q", rl.KeyBy)
+	v.check(rl.Capacity > 0, "service.rate_limit.capacity", "must be positive")
+	v.check(rl.RefillRate >= 0, "service.rate_limit.refill_rate", "must not be negative")
+	for route, limit := range rl.Routes {
+		v.check(limit.Capacity > 0, "service.rate_limit.routes", "capacity for %q must be positive", route)
+		v.check(limit.RefillRate >= 0, "service.rate_limit.routes", "refill rate for %q must not be negative", route)
+	}
+	v.check(rl.MaxKeys > 0, "service.rate_limit.max_keys", "must be positive")
+	v.positive(rl.IdleTimeout, "service.rate_limit.idle_timeout")
+
+	v.positive(s.Idempotency.KeyTTL, "service.idempotency.key_ttl")
+	v.positive(s.Idempotency.LockLease, "service.idempotency.lock_lease")
+	v.check(s.Idempotency.LockLease <= s.Idempotency.KeyTTL, "service.idempotency.lock_lease", "must not exceed key_ttl")
+	v.positive(s.Idempotency.CleanupInterval, "service.idempotency.cleanup_interval")
+}
+
+func (c *Config) validateWorker(v *validator) {
+	w := c.Worker
+	v.required(w.MetricsAddress, "worker.metrics_addr")
+	v.required(w.AdminAddress, "worker.admin_addr")
+	v.check(len(w.Kafka.Brokers) > 0, "worker.kafka.brokers", "required")
+	v.required(w.Kafka.GroupID, "worker.kafka.group_id")
+	v.required(w.Kafka.Topic, "worker.kafka.topic")
+}
+
+func validIsolationLevel(level string) bool {
+	switch level {
+	case "", "read committed", "repeatable read", "serializable":
+		return true
+	default:
+		return false
+	}
+}
+
+func validLogLevel(level logger.LogLevel) bool {
+	switch level {
+	case logger.LogLevelDebug, logger.LogLevelInfo, logger.LogLevelWarn, logger.LogLevelError:
+		return true
+	default:
+		return false
+	}
+}
+
+func validLogModule(module string) bool {
+	switch module {
+	case LogModuleGateway, LogModuleRepository, LogModuleKafka:
+		return true
+	default:
+		return false
+	}
+}
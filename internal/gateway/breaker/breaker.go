@@ -0,0 +1,247 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type State int
+
+// Значения совпадают со значениями gauge circuit_breaker_state.
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+type Config struct {
+	// Window — период, за который в закрытом состоянии копится статистика.
+	Window time.Duration
+	// MinRequests — минимум вызовов в окне, после которого считается доля ошибок.
+	MinRequests int
+	// FailureRatio — доля ошибок в окне, при которой breaker открывается.
+	FailureRatio float64
+	// OpenTimeout — сколько breaker остается открытым до пробных вызовов.
+	OpenTimeout time.Duration
+	// HalfOpenMaxRequests — сколько пробных вызовов пропускается в полуоткрытом
+	// состоянии; если все успешны, breaker закрывается.
+	HalfOpenMaxRequests int
+	// IsFailure решает, считать ли ошибку отказом сервиса.
+	IsFailure func(error) bool
+}
+
+// CircuitBreaker перестает вызывать сервис, когда доля ошибок за окно
+// превышает порог, и через OpenTimeout пропускает пробные вызовы.
+// ExecuteWithContext совпадает по сигнатуре с RetryExecutor, поэтому
+// breaker можно поставить как снаружи, так и внутри ретраев.
+type CircuitBreaker struct {
+	name    string
+	config  Config
+	metrics metricsWriter
+	logger  logger
+
+	mu         sync.Mutex
+	state      State
+	generation uint64
+	expiry     time.Time
+	requests   int
+	failures   int
+	inFlight   int
+	successes  int
+
+	now func() time.Time
+}
+
+func NewCircuitBreaker(name string, config Config, metrics metricsWriter, logger logger, nowFn func() time.Time) *CircuitBreaker {
+	if config.Window <= 0 {
+		config.Window = time.Minute
+	}
+	if config.MinRequests <= 0 {
+		config.MinRequests = 10
+	}
+	if config.FailureRatio <= 0 || config.FailureRatio > 1 {
+		config.FailureRatio = 0.5
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = 30 * time.Second
+	}
+	if config.HalfOpenMaxRequests <= 0 {
+		config.HalfOpenMaxRequests = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = defaultIsFailure
+	}
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+
+	cb := &CircuitBreaker{
+		name:    name,
+		config:  config,
+		metrics: metrics,
+		logger:  logger,
+		now:     nowFn,
+	}
+	cb.toNewGeneration(cb.now())
+	cb.metrics.RecordCircuitBreakerState(cb.name, int(StateClosed))
+	return cb
+}
+
+func (cb *CircuitBreaker) ExecuteWithContext(ctx context.Context, fn func(context.Context) error) error {
+	generation, err := cb.beforeCall()
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	cb.afterCall(generation, err == nil || !cb.config.IsFailure(err))
+	return err
+}
+
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	state, _ := cb.currentState(cb.now())
+	return state
+}
+
+func (cb *CircuitBreaker) beforeCall() (uint64, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	state, generation := cb.currentState(cb.now())
+	switch state {
+	case StateOpen:
+		return generation, ErrCircuitOpen
+	case StateHalfOpen:
+		if cb.inFlight+cb.successes >= cb.config.HalfOpenMaxRequests {
+			return generation, ErrTooManyRequests
+		}
+	}
+
+	cb.requests++
+	cb.inFlight++
+	return generation, nil
+}
+
+func (cb *CircuitBreaker) afterCall(before uint64, success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	state, generation := cb.currentState(now)
+	// результат вызова, начатого до смены состояния, уже не важен
+	if generation != before {
+		return
+	}
+	cb.inFlight--
+
+	if success {
+		cb.onSuccess(state, now)
+		return
+	}
+	cb.onFailure(state, now)
+}
+
+func (cb *CircuitBreaker) onSuccess(state State, now time.Time) {
+	if state != StateHalfOpen {
+		return
+	}
+	cb.successes++
+	if cb.successes >= cb.config.HalfOpenMaxRequests {
+		cb.setState(StateClosed, now)
+	}
+}
+
+func (cb *CircuitBreaker) onFailure(state State, now time.Time) {
+	switch state {
+	case StateClosed:
+		cb.failures++
+		if cb.requests >= cb.config.MinRequests &&
+			float64(cb.failures)/float64(cb.requests) >= cb.config.FailureRatio {
+			cb.setState(StateOpen, now)
+		}
+	case StateHalfOpen:
+		cb.setState(StateOpen, now)
+	}
+}
+
+func (cb *CircuitBreaker) currentState(now time.Time) (State, uint64) {
+	switch cb.state {
+	case StateClosed:
+		if !cb.expiry.After(now) {
+			cb.toNewGeneration(now)
+		}
+	case StateOpen:
+		if !cb.expiry.After(now) {
+			cb.setState(StateHalfOpen, now)
+		}
+	}
+	return cb.state, cb.generation
+}
+
+func (cb *CircuitBreaker) setState(state State, now time.Time) {
+	if cb.state == state {
+		return
+	}
+
+	prev := cb.state
+	cb.state = state
+	cb.toNewGeneration(now)
+
+	cb.logger.Warnf("circuit breaker %s: %s -> %s", cb.name, prev, state)
+	cb.metrics.RecordCircuitBreakerState(cb.name, int(state))
+}
+
+func (cb *CircuitBreaker) toNewGeneration(now time.Time) {
+	cb.generation++
+	cb.requests, cb.failures, cb.inFlight, cb.successes = 0, 0, 0, 0
+
+	switch cb.state {
+	case StateClosed:
+		cb.expiry = now.Add(cb.config.Window)
+	case StateOpen:
+		cb.expiry = now.Add(cb.config.OpenTimeout)
+	default:
+		cb.expiry = time.Time{}
+	}
+}
+
+// defaultIsFailure не считает отказом отмену контекста вызывающей стороной.
+func defaultIsFailure(err error) bool {
+	return !errors.Is(err, context.Canceled)
+}
+
+// Executor пропускает через breaker вызов next целиком. Обернутый
+// RetryExecutor считается одним вызовом: пока breaker открыт, ретраи
+// не запускаются и сообщение сразу получает ErrCircuitOpen.
+type Executor struct {
+	breaker *CircuitBreaker
+	next    executor
+}
+
+func (cb *CircuitBreaker) Wrap(next executor) *Executor {
+	return &Executor{breaker: cb, next: next}
+}
+
+func (e *Executor) ExecuteWithContext(ctx context.Context, fn func(context.Context) error) error {
+	return e.breaker.ExecuteWithContext(ctx, func(ctx context.Context) error {
+		return e.next.ExecuteWithContext(ctx, fn)
+	})
+}
@@ -0,0 +1,176 @@
+package breaker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"courier-service/internal/gateway/breaker"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+type fixture struct {
+	cb      *breaker.CircuitBreaker
+	metrics *MockmetricsWriter
+	now     *time.Time
+}
+
+func newFixture(t *testing.T, config breaker.Config) fixture {
+	ctrl := gomock.NewController(t)
+
+	mockLogger := NewMocklogger(ctrl)
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockMetrics := NewMockmetricsWriter(ctrl)
+	mockMetrics.EXPECT().RecordCircuitBreakerState("orders", int(breaker.StateClosed))
+
+	now := time.Unix(0, 0)
+	cb := breaker.NewCircuitBreaker("orders", config, mockMetrics, mockLogger, func() time.Time { return now })
+
+	return fixture{cb: cb, metrics: mockMetrics, now: &now}
+}
+
+func call(cb *breaker.CircuitBreaker, err error) error {
+	return cb.ExecuteWithContext(context.Background(), func(context.Context) error { return err })
+}
+
+func TestCircuitBreaker_OpensOnFailureRatio(t *testing.T) {
+	f := newFixture(t, breaker.Config{
+		Window:       time.Minute,
+		MinRequests:  4,
+		FailureRatio: 0.5,
+		OpenTimeout:  10 * time.Second,
+	})
+	f.metrics.EXPECT().RecordCircuitBreakerState("orders", int(breaker.StateOpen))
+
+	require.NoError(t, call(f.cb, nil))
+	require.NoError(t, call(f.cb, nil))
+	require.ErrorIs(t, call(f.cb, errUnavailable), errUnavailable)
+	// 1 из 3 — порог еще не достигнут, да и вызовов меньше MinRequests
+	assert.Equal(t, breaker.StateClosed, f.cb.State())
+
+	require.ErrorIs(t, call(f.cb, errUnavailable), errUnavailable)
+	assert.Equal(t, breaker.StateOpen, f.cb.State())
+
+	called := false
+	err := f.cb.ExecuteWithContext(context.Background(), func(context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, breaker.ErrCircuitOpen)
+	assert.False(t, called)
+}
+
+func TestCircuitBreaker_WindowResetsCounts(t *testing.T) {
+	f := newFixture(t, breaker.Config{
+		Window:       time.Minute,
+		MinRequests:  2,
+		FailureRatio: 1,
+	})
+
+	require.Error(t, call(f.cb, errUnavailable))
+	*f.now = f.now.Add(time.Minute)
+	require.Error(t, call(f.cb, errUnavailable))
+
+	assert.Equal(t, breaker.StateClosed, f.cb.State())
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	tests := []struct {
+		name      string
+		probes    []error
+		wantState breaker.State
+	}{
+		{
+			name:      "successful probes close the breaker",
+			probes:    []error{nil, nil},
+			wantState: breaker.StateClosed,
+		},
+		{
+			name:      "failed probe opens the breaker again",
+			probes:    []error{nil, errUnavailable},
+			wantState: breaker.StateOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, breaker.Config{
+				MinRequests:         1,
+				FailureRatio:        1,
+				OpenTimeout:         10 * time.Second,
+				HalfOpenMaxRequests: 2,
+			})
+			f.metrics.EXPECT().RecordCircuitBreakerState(gomock.Any(), gomock.Any()).AnyTimes()
+
+			require.Error(t, call(f.cb, errUnavailable))
+			require.Equal(t, breaker.StateOpen, f.cb.State())
+
+			*f.now = f.now.Add(10 * time.Second)
+			require.Equal(t, breaker.StateHalfOpen, f.cb.State())
+
+			for _, probe := range tt.probes {
+				assert.ErrorIs(t, call(f.cb, probe), probe)
+			}
+			assert.Equal(t, tt.wantState, f.cb.State())
+		})
+	}
+}
+
+func TestCircuitBreaker_HalfOpenLimitsProbes(t *testing.T) {
+	f := newFixture(t, breaker.Config{
+		MinRequests:  1,
+		FailureRatio: 1,
+		OpenTimeout:  time.Second,
+	})
+	f.metrics.EXPECT().RecordCircuitBreakerState(gomock.Any(), gomock.Any()).AnyTimes()
+
+	require.Error(t, call(f.cb, errUnavailable))
+	*f.now = f.now.Add(time.Second)
+
+	// пока пробный вызов выполняется, остальные отклоняются
+	err := f.cb.ExecuteWithContext(context.Background(), func(context.Context) error {
+		assert.ErrorIs(t, call(f.cb, nil), breaker.ErrTooManyRequests)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, breaker.StateClosed, f.cb.State())
+}
+
+func TestCircuitBreaker_IgnoresNonFailures(t *testing.T) {
+	f := newFixture(t, breaker.Config{
+		MinRequests:  1,
+		FailureRatio: 1,
+		IsFailure: func(err error) bool {
+			return !errors.Is(err, errUnavailable)
+		},
+	})
+
+	require.Error(t, call(f.cb, errUnavailable))
+	require.ErrorIs(t, call(f.cb, context.Canceled), context.Canceled)
+	assert.Equal(t, breaker.StateClosed, f.cb.State())
+}
+
+func TestExecutor_SkipsRetriesWhenOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	f := newFixture(t, breaker.Config{MinRequests: 1, FailureRatio: 1})
+	f.metrics.EXPECT().RecordCircuitBreakerState("orders", int(breaker.StateOpen))
+
+	retry := NewMockexecutor(ctrl)
+	retry.EXPECT().
+		ExecuteWithContext(gomock.Any(), gomock.Any()).
+		Return(errUnavailable).
+		Times(1)
+
+	exec := f.cb.Wrap(retry)
+	fn := func(context.Context) error { return nil }
+
+	assert.ErrorIs(t, exec.ExecuteWithContext(context.Background(), fn), errUnavailable)
+	assert.ErrorIs(t, exec.ExecuteWithContext(context.Background(), fn), breaker.ErrCircuitOpen)
+}
@@ -0,0 +1,30 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
+package breaker
+
+import "context"
+
+type metricsWriter interface {
+	RecordCircuitBreakerState(name string, state int)
+}
+
+type logger interface {
+	Debug(args ...interface{})
+	Debugf(format string, args ...interface{})
+	Debugw(msg string, keysAndValues ...interface{})
+
+	Info(args ...interface{})
+	Infof(format string, args ...interface{})
+
+	Warn(args ...interface{})
+	Warnf(format string, args ...interface{})
+
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+
+	Fatal(args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
+
+type executor interface {
+	ExecuteWithContext(ctx context.Context, fn func(context.Context) error) error
+}
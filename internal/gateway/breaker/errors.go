@@ -0,0 +1,8 @@
+package breaker
+
+import "errors"
+
+var (
+	ErrCircuitOpen     = errors.New("circuit breaker is open")
+	ErrTooManyRequests = errors.New("too many requests in half-open state")
+)
@@ -2,6 +2,7 @@ package interceptor
 
 type metricsWriter interface {
 	RecordRetry(method, path string)
+	RecordGRPCRequest(method, code string, duration float64)
 }
 
 type logger interface {
@@ -2,11 +2,17 @@ package interceptor
 
 import (
 	"context"
+	"errors"
+	"io"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+
+	"courier-service/pkg/retrypolicy"
 )
 
 // LoggingMetricsInterceptor создает unary interceptor для логирования и метрик
@@ -28,6 +34,7 @@ func LoggingMetricsInterceptor(logger logger, metrics metricsWriter) grpc.UnaryC
 
 		// Измеряем длительность
 		duration := time.Since(start)
+		metrics.RecordGRPCRequest(method, status.Code(err).String(), duration.Seconds())
 
 		// Логируем результат
 		if err != nil {
@@ -109,16 +116,91 @@ func LoggingInterceptor(logger logger) grpc.UnaryClientInterceptor {
 	}
 }
 
+// RetryPushbackInterceptor читает трейлер grpc-retry-pushback-ms и передает
+// запрошенную сервером задержку политике ретраев вместе с ошибкой
+func RetryPushbackInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		var trailer metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+		return retrypolicy.WithTrailerPushback(err, trailer)
+	}
+}
+
 // isRetryableError определяет, является ли ошибка повторяемой
 func isRetryableError(code codes.Code) bool {
-	switch code {
-	case codes.Unavailable,
-		codes.DeadlineExceeded,
-		codes.ResourceExhausted,
-		codes.Aborted,
-		codes.Internal:
-		return true
+	return retrypolicy.IsRetryableCode(code)
+}
+
+// StreamLoggingMetricsInterceptor создает stream interceptor для логирования
+// и метрик. Вызов считается завершенным, когда поток вернул io.EOF или ошибку:
+// длительность включает чтение всех сообщений.
+func StreamLoggingMetricsInterceptor(logger logger, metrics metricsWriter) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		start := time.Now()
+
+		logger.Infof("gRPC stream started: method=%s", method)
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			recordStreamResult(logger, metrics, method, start, err)
+			return nil, err
+		}
+
+		return &observedStream{
+			ClientStream: stream,
+			done: func(err error) {
+				recordStreamResult(logger, metrics, method, start, err)
+			},
+		}, nil
+	}
+}
+
+func recordStreamResult(logger logger, metrics metricsWriter, method string, start time.Time, err error) {
+	duration := time.Since(start)
+	metrics.RecordGRPCRequest(method, status.Code(err).String(), duration.Seconds())
+
+	if err != nil {
+		grpcStatus, _ := status.FromError(err)
+		logger.Errorf("gRPC stream failed: method=%s, duration=%v, code=%s, error=%v",
+			method, duration, grpcStatus.Code(), err)
+		if isRetryableError(grpcStatus.Code()) {
+			metrics.RecordRetry("POST", method)
+		}
+		return
+	}
+	logger.Infof("gRPC stream finished: method=%s, duration=%v", method, duration)
+}
+
+// observedStream вызывает done один раз, когда поток завершился: RecvMsg
+// вернул io.EOF (done(nil)) или ошибку.
+type observedStream struct {
+	grpc.ClientStream
+	once sync.Once
+	done func(err error)
+}
+
+func (s *observedStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+	case errors.Is(err, io.EOF):
+		s.once.Do(func() { s.done(nil) })
 	default:
-		return false
+		s.once.Do(func() { s.done(err) })
 	}
+	return err
 }
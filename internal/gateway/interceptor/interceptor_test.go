@@ -0,0 +1,223 @@
+package interceptor_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"courier-service/internal/gateway/interceptor"
+	"courier-service/pkg/requestid"
+)
+
+const method = "/orders.OrdersService/GetOrderById"
+
+type recordedCall struct {
+	method string
+	code   string
+}
+
+type fakeMetrics struct {
+	mu      sync.Mutex
+	calls   []recordedCall
+	retries int
+}
+
+func (m *fakeMetrics) RecordRetry(string, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries++
+}
+
+func (m *fakeMetrics) RecordGRPCRequest(method, code string, _ float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, recordedCall{method: method, code: code})
+}
+
+// fakeStream отдает msgs сообщений, затем завершается err (io.EOF — штатно).
+type fakeStream struct {
+	grpc.ClientStream
+	ctx  context.Context
+	msgs int
+	err  error
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func (s *fakeStream) RecvMsg(interface{}) error {
+	if s.msgs > 0 {
+		s.msgs--
+		return nil
+	}
+	return s.err
+}
+
+func streamer(msgs int, err error, gotCtx *context.Context) grpc.Streamer {
+	return func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		if gotCtx != nil {
+			*gotCtx = ctx
+		}
+		return &fakeStream{ctx: ctx, msgs: msgs, err: err}, nil
+	}
+}
+
+func drain(t *testing.T, stream grpc.ClientStream) error {
+	t.Helper()
+	for {
+		if err := stream.RecvMsg(nil); err != nil {
+			return err
+		}
+	}
+}
+
+func TestLoggingMetricsInterceptor(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    string
+		wantRetries int
+	}{
+		{name: "success", wantCode: "OK"},
+		{name: "retryable error", err: status.Error(codes.Unavailable, "down"), wantCode: "Unavailable", wantRetries: 1},
+		{name: "permanent error", err: status.Error(codes.NotFound, "missing"), wantCode: "NotFound"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			metrics := &fakeMetrics{}
+			unary := interceptor.LoggingMetricsInterceptor(zap.NewNop().Sugar(), metrics)
+
+			err := unary(context.Background(), method, nil, nil, nil,
+				func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+					return tc.err
+				})
+
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, []recordedCall{{method: method, code: tc.wantCode}}, metrics.calls)
+			assert.Equal(t, tc.wantRetries, metrics.retries)
+		})
+	}
+}
+
+func TestStreamLoggingMetricsInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode string
+	}{
+		{name: "recorded once on EOF", err: io.EOF, wantCode: "OK"},
+		{name: "recorded with stream error code", err: status.Error(codes.DeadlineExceeded, "slow"), wantCode: "DeadlineExceeded"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			metrics := &fakeMetrics{}
+			stream, err := interceptor.StreamLoggingMetricsInterceptor(zap.NewNop().Sugar(), metrics)(
+				context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, method, streamer(2, tc.err, nil))
+			require.NoError(t, err)
+			assert.Empty(t, metrics.calls, "stream is not finished until the last message is read")
+
+			assert.Equal(t, tc.err, drain(t, stream))
+			// повторное чтение после завершения не считается новым вызовом
+			_ = stream.RecvMsg(nil)
+			assert.Equal(t, []recordedCall{{method: method, code: tc.wantCode}}, metrics.calls)
+		})
+	}
+}
+
+func TestRequestIDInterceptor(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []string
+	}{
+		{
+			name: "id from context goes to metadata",
+			ctx:  requestid.NewContext(context.Background(), "req-1"),
+			want: []string{"req-1"},
+		},
+		{
+			name: "no id leaves metadata untouched",
+			ctx:  context.Background(),
+		},
+		{
+			name: "id already in metadata is not duplicated",
+			ctx: metadata.AppendToOutgoingContext(
+				requestid.NewContext(context.Background(), "req-1"), requestid.MetadataKey, "req-1"),
+			want: []string{"req-1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			err := interceptor.RequestIDInterceptor()(tc.ctx, method, nil, nil, nil,
+				func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+					md, _ := metadata.FromOutgoingContext(ctx)
+					got = md.Get(requestid.MetadataKey)
+					return nil
+				})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+
+			var streamCtx context.Context
+			_, err = interceptor.StreamRequestIDInterceptor()(tc.ctx, &grpc.StreamDesc{}, nil, method, streamer(0, io.EOF, &streamCtx))
+			require.NoError(t, err)
+			md, _ := metadata.FromOutgoingContext(streamCtx)
+			assert.Equal(t, tc.want, md.Get(requestid.MetadataKey))
+		})
+	}
+}
+
+func TestDeadlineInterceptor(t *testing.T) {
+	deadlineOf := func(ctx context.Context) (time.Time, bool) {
+		var deadline time.Time
+		var ok bool
+		_ = interceptor.DeadlineInterceptor(time.Second)(ctx, method, nil, nil, nil,
+			func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				deadline, ok = ctx.Deadline()
+				return nil
+			})
+		return deadline, ok
+	}
+
+	t.Run("injected when caller has none", func(t *testing.T) {
+		deadline, ok := deadlineOf(context.Background())
+		require.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+	})
+
+	t.Run("caller deadline is kept", func(t *testing.T) {
+		want := time.Now().Add(time.Hour)
+		ctx, cancel := context.WithDeadline(context.Background(), want)
+		defer cancel()
+
+		deadline, ok := deadlineOf(ctx)
+		require.True(t, ok)
+		assert.Equal(t, want, deadline)
+	})
+}
+
+func TestStreamDeadlineInterceptor(t *testing.T) {
+	var streamCtx context.Context
+	stream, err := interceptor.StreamDeadlineInterceptor(time.Minute)(
+		context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, method, streamer(1, io.EOF, &streamCtx))
+	require.NoError(t, err)
+
+	_, ok := streamCtx.Deadline()
+	require.True(t, ok)
+	require.NoError(t, streamCtx.Err())
+
+	assert.Equal(t, io.EOF, drain(t, stream))
+	assert.ErrorIs(t, streamCtx.Err(), context.Canceled, "stream context is released when the stream ends")
+}
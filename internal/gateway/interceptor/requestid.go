@@ -0,0 +1,52 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"courier-service/pkg/requestid"
+)
+
+// RequestIDInterceptor передает request id из контекста (HTTP запрос или
+// сообщение Kafka) в metadata исходящего вызова
+func RequestIDInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(withRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamRequestIDInterceptor — то же для потоковых вызовов
+func StreamRequestIDInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(withRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+// withRequestID добавляет request id в исходящую metadata, если его там еще нет.
+// Ретраи идут с тем же контекстом, поэтому ключ не дублируется.
+func withRequestID(ctx context.Context) context.Context {
+	id := requestid.FromContext(ctx)
+	if id == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(requestid.MetadataKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, requestid.MetadataKey, id)
+}
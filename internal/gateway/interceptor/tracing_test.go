@@ -0,0 +1,84 @@
+package interceptor_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"courier-service/internal/gateway/interceptor"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return recorder
+}
+
+func TestTracingInterceptor(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus otelcodes.Code
+	}{
+		{name: "success", wantStatus: otelcodes.Unset},
+		{name: "error marks span", err: status.Error(codes.Unavailable, "down"), wantStatus: otelcodes.Error},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := newRecorder(t)
+
+			var traceparent []string
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+			err := interceptor.TracingInterceptor()(ctx, method, nil, nil, nil,
+				func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+					md, _ := metadata.FromOutgoingContext(ctx)
+					traceparent = md.Get("traceparent")
+					assert.Equal(t, []string{"req-1"}, md.Get("x-request-id"), "existing metadata is kept")
+					return tc.err
+				})
+			assert.Equal(t, tc.err, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "orders.OrdersService/GetOrderById", spans[0].Name())
+			assert.Equal(t, tc.wantStatus, spans[0].Status().Code)
+
+			require.Len(t, traceparent, 1)
+			assert.Contains(t, traceparent[0], spans[0].SpanContext().TraceID().String())
+		})
+	}
+}
+
+func TestStreamTracingInterceptor(t *testing.T) {
+	recorder := newRecorder(t)
+
+	stream, err := interceptor.StreamTracingInterceptor()(
+		context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, method, streamer(2, io.EOF, nil))
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Ended(), "span stays open while the stream is read")
+
+	assert.Equal(t, io.EOF, drain(t, stream))
+	require.Len(t, recorder.Ended(), 1)
+	assert.Equal(t, otelcodes.Unset, recorder.Ended()[0].Status().Code)
+}
@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"courier-service/internal/model"
+)
+
+const cacheName = "orders"
+
+// Gateway — read-through кэш заказов перед order.Gateway. Записи живут ttl,
+// при превышении maxSize вытесняется давно не использованная. Конкурентные
+// запросы одного id сводятся к одному gRPC вызову. Ошибки не кэшируются.
+type Gateway struct {
+	next    orderGateway
+	metrics metricsWriter
+	ttl     time.Duration
+	maxSize int
+	// fetchTimeout ограничивает общий запрос: отмена вызвавшего его
+	// не прерывает, ведь ответа ждут и другие вызовы
+	fetchTimeout time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	group   singleflight.Group
+	// epoch растет при каждой инвалидации: ответ, запрошенный до нее,
+	// в кэш уже не попадает
+	epoch uint64
+
+	now func() time.Time
+}
+
+type entry struct {
+	key       string
+	order     model.Order
+	expiresAt time.Time
+}
+
+func NewGateway(
+	next orderGateway,
+	ttl time.Duration,
+	maxSize int,
+	fetchTimeout time.Duration,
+	metrics metricsWriter,
+	nowFn func() time.Time,
+) *Gateway {
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	return &Gateway{
+		next:         next,
+		metrics:      metrics,
+		ttl:          ttl,
+		maxSize:      maxSize,
+		fetchTimeout: fetchTimeout,
+		entries:      make(map[string]*list.Element),
+		lru:          list.New(),
+		now:          nowFn,
+	}
+}
+
+// GetOrders не кэшируется: результат зависит от курсора from.
+func (g *Gateway) GetOrders(ctx context.Context, from time.Time) ([]model.Order, error) {
+	return g.next.GetOrders(ctx, from)
+}
+
+// StreamOrders не кэшируется по той же причине, что и GetOrders.
+func (g *Gateway) StreamOrders(
+	ctx context.Context,
+	from time.Time,
+	handle func(ctx context.Context, orders []model.Order) error,
+) error {
+	return g.next.StreamOrders(ctx, from, handle)
+}
+
+func (g *Gateway) GetOrderById(ctx context.Context, id string) (model.Order, error) {
+	if order, ok := g.get(id); ok {
+		g.metrics.RecordCacheHit(cacheName)
+		return order, nil
+	}
+	g.metrics.RecordCacheMiss(cacheName)
+
+	ch := g.group.DoChan(id, func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), g.fetchTimeout)
+		defer cancel()
+
+		epoch := g.currentEpoch()
+		order, err := g.next.GetOrderById(fetchCtx, id)
+		if err != nil {
+			return nil, err
+		}
+		g.set(id, order, epoch)
+		return order, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return model.Order{}, res.Err
+		}
+		return res.Val.(model.Order), nil
+	case <-ctx.Done():
+		return model.Order{}, ctx.Err()
+	}
+}
+
+// Invalidate удаляет заказ из кэша, например после события о смене статуса.
+func (g *Gateway) Invalidate(id string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.epoch++
+	if el, ok := g.entries[id]; ok {
+		g.remove(el)
+	}
+	// запрос, начатый до инвалидации, не должен вернуть старый статус новым вызовам
+	g.group.Forget(id)
+}
+
+// Len возвращает количество заказов в кэше.
+func (g *Gateway) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.lru.Len()
+}
+
+func (g *Gateway) get(id string) (model.Order, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	el, ok := g.entries[id]
+	if !ok {
+		return model.Order{}, false
+	}
+
+	e := el.Value.(*entry)
+	if !g.now().Before(e.expiresAt) {
+		g.remove(el)
+		return model.Order{}, false
+	}
+
+	g.lru.MoveToFront(el)
+	return e.order, true
+}
+
+func (g *Gateway) currentEpoch() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.epoch
+}
+
+func (g *Gateway) set(id string, order model.Order, epoch uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if epoch != g.epoch {
+		return
+	}
+
+	e := &entry{key: id, order: order, expiresAt: g.now().Add(g.ttl)}
+	if el, ok := g.entries[id]; ok {
+		el.Value = e
+		g.lru.MoveToFront(el)
+		return
+	}
+
+	g.entries[id] = g.lru.PushFront(e)
+	for g.maxSize > 0 && g.lru.Len() > g.maxSize {
+		g.remove(g.lru.Back())
+	}
+}
+
+func (g *Gateway) remove(el *list.Element) {
+	g.lru.Remove(el)
+	delete(g.entries, el.Value.(*entry).key)
+}
@@ -0,0 +1,229 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"courier-service/internal/gateway/order/cache"
+	"courier-service/internal/model"
+)
+
+const orderID = "550e8400-e29b-41d4-a716-446655440000"
+
+func newCache(t *testing.T, ttl time.Duration, maxSize int) (*cache.Gateway, *MockorderGateway, *MockmetricsWriter, *time.Time) {
+	ctrl := gomock.NewController(t)
+
+	next := NewMockorderGateway(ctrl)
+	metrics := NewMockmetricsWriter(ctrl)
+
+	now := time.Unix(0, 0)
+	return cache.NewGateway(next, ttl, maxSize, time.Second, metrics, func() time.Time { return now }), next, metrics, &now
+}
+
+func TestGateway_GetOrderById(t *testing.T) {
+	order := model.Order{ID: orderID, Status: model.OrderStatusCreated}
+
+	tests := []struct {
+		name    string
+		prepare func(next *MockorderGateway, metrics *MockmetricsWriter)
+		steps   func(t *testing.T, g *cache.Gateway, now *time.Time)
+	}{
+		{
+			name: "second lookup is served from cache",
+			prepare: func(next *MockorderGateway, metrics *MockmetricsWriter) {
+				next.EXPECT().GetOrderById(gomock.Any(), orderID).Return(order, nil).Times(1)
+				metrics.EXPECT().RecordCacheMiss("orders").Times(1)
+				metrics.EXPECT().RecordCacheHit("orders").Times(1)
+			},
+			steps: func(t *testing.T, g *cache.Gateway, now *time.Time) {
+				for i := 0; i < 2; i++ {
+					got, err := g.GetOrderById(context.Background(), orderID)
+					require.NoError(t, err)
+					assert.Equal(t, order, got)
+				}
+			},
+		},
+		{
+			name: "expired entry is fetched again",
+			prepare: func(next *MockorderGateway, metrics *MockmetricsWriter) {
+				next.EXPECT().GetOrderById(gomock.Any(), orderID).Return(order, nil).Times(2)
+				metrics.EXPECT().RecordCacheMiss("orders").Times(2)
+			},
+			steps: func(t *testing.T, g *cache.Gateway, now *time.Time) {
+				_, err := g.GetOrderById(context.Background(), orderID)
+				require.NoError(t, err)
+
+				*now = now.Add(time.Minute)
+				_, err = g.GetOrderById(context.Background(), orderID)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "invalidated entry is fetched again",
+			prepare: func(next *MockorderGateway, metrics *MockmetricsWriter) {
+				next.EXPECT().GetOrderById(gomock.Any(), orderID).Return(order, nil).Times(2)
+				metrics.EXPECT().RecordCacheMiss("orders").Times(2)
+			},
+			steps: func(t *testing.T, g *cache.Gateway, now *time.Time) {
+				_, err := g.GetOrderById(context.Background(), orderID)
+				require.NoError(t, err)
+
+				g.Invalidate(orderID)
+				_, err = g.GetOrderById(context.Background(), orderID)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "errors are not cached",
+			prepare: func(next *MockorderGateway, metrics *MockmetricsWriter) {
+				gomock.InOrder(
+					next.EXPECT().GetOrderById(gomock.Any(), orderID).Return(model.Order{}, errors.New("unavailable")),
+					next.EXPECT().GetOrderById(gomock.Any(), orderID).Return(order, nil),
+				)
+				metrics.EXPECT().RecordCacheMiss("orders").Times(2)
+			},
+			steps: func(t *testing.T, g *cache.Gateway, now *time.Time) {
+				_, err := g.GetOrderById(context.Background(), orderID)
+				require.EqualError(t, err, "unavailable")
+
+				got, err := g.GetOrderById(context.Background(), orderID)
+				require.NoError(t, err)
+				assert.Equal(t, order, got)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, next, metrics, now := newCache(t, 30*time.Second, 10)
+			tt.prepare(next, metrics)
+			tt.steps(t, g, now)
+		})
+	}
+}
+
+func TestGateway_SizeBound(t *testing.T) {
+	g, next, metrics, _ := newCache(t, time.Minute, 2)
+	metrics.EXPECT().RecordCacheMiss("orders").AnyTimes()
+	metrics.EXPECT().RecordCacheHit("orders").AnyTimes()
+	next.EXPECT().
+		GetOrderById(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id string) (model.Order, error) {
+			return model.Order{ID: id}, nil
+		}).
+		Times(4)
+
+	ctx := context.Background()
+	for _, id := range []string{"a", "b", "a", "c"} {
+		_, err := g.GetOrderById(ctx, id)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, g.Len())
+
+	// "b" вытеснен как давно не использованный, "a" остался
+	_, err := g.GetOrderById(ctx, "a")
+	require.NoError(t, err)
+	_, err = g.GetOrderById(ctx, "b")
+	require.NoError(t, err)
+}
+
+func TestGateway_SingleflightDeduplicatesLookups(t *testing.T) {
+	g, next, metrics, _ := newCache(t, time.Minute, 10)
+	metrics.EXPECT().RecordCacheMiss("orders").AnyTimes()
+	metrics.EXPECT().RecordCacheHit("orders").AnyTimes()
+
+	release := make(chan struct{})
+	next.EXPECT().
+		GetOrderById(gomock.Any(), orderID).
+		DoAndReturn(func(context.Context, string) (model.Order, error) {
+			<-release
+			return model.Order{ID: orderID}, nil
+		}).
+		Times(1)
+
+	const callers = 10
+	var (
+		wg      sync.WaitGroup
+		started sync.WaitGroup
+	)
+	started.Add(callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			started.Done()
+			got, err := g.GetOrderById(context.Background(), orderID)
+			assert.NoError(t, err)
+			assert.Equal(t, orderID, got.ID)
+		}()
+	}
+	started.Wait()
+	// даем горутинам дойти до singleflight
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func TestGateway_CallerCancellation(t *testing.T) {
+	g, next, metrics, _ := newCache(t, time.Minute, 10)
+	metrics.EXPECT().RecordCacheMiss("orders")
+
+	release := make(chan struct{})
+	defer close(release)
+	next.EXPECT().
+		GetOrderById(gomock.Any(), orderID).
+		DoAndReturn(func(context.Context, string) (model.Order, error) {
+			<-release
+			return model.Order{ID: orderID}, nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := g.GetOrderById(ctx, orderID)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGateway_FirstCallerCancellationDoesNotFailSharedLookup(t *testing.T) {
+	g, next, metrics, _ := newCache(t, time.Minute, 10)
+	metrics.EXPECT().RecordCacheMiss("orders").Times(2)
+
+	release := make(chan struct{})
+	next.EXPECT().
+		GetOrderById(gomock.Any(), orderID).
+		DoAndReturn(func(ctx context.Context, _ string) (model.Order, error) {
+			<-release
+			return model.Order{ID: orderID}, ctx.Err()
+		}).
+		Times(1)
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := g.GetOrderById(firstCtx, orderID)
+		firstErr <- err
+	}()
+	// даем первому вызову начать общий запрос
+	time.Sleep(20 * time.Millisecond)
+
+	second := make(chan model.Order, 1)
+	go func() {
+		got, err := g.GetOrderById(context.Background(), orderID)
+		assert.NoError(t, err)
+		second <- got
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancelFirst()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	assert.Equal(t, orderID, (<-second).ID)
+}
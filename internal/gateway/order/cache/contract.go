@@ -0,0 +1,20 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
+package cache
+
+import (
+	"context"
+	"time"
+
+	"courier-service/internal/model"
+)
+
+type orderGateway interface {
+	GetOrders(ctx context.Context, from time.Time) ([]model.Order, error)
+	GetOrderById(ctx context.Context, id string) (model.Order, error)
+	StreamOrders(ctx context.Context, from time.Time, handle func(ctx context.Context, orders []model.Order) error) error
+}
+
+type metricsWriter interface {
+	RecordCacheHit(cache string)
+	RecordCacheMiss(cache string)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package cache_test is a generated GoMock package.
+package cache_test
+
+import (
+	context "context"
+	model "courier-service/internal/model"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockorderGateway is a mock of orderGateway interface.
+type MockorderGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderGatewayMockRecorder
+}
+
+// MockorderGatewayMockRecorder is the mock recorder for MockorderGateway.
+type MockorderGatewayMockRecorder struct {
+	mock *MockorderGateway
+}
+
+// NewMockorderGateway creates a new mock instance.
+func NewMockorderGateway(ctrl *gomock.Controller) *MockorderGateway {
+	mock := &MockorderGateway{ctrl: ctrl}
+	mock.recorder = &MockorderGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderGateway) EXPECT() *MockorderGatewayMockRecorder {
+	return m.recorder
+}
+
+// GetOrderById mocks base method.
+func (m *MockorderGateway) GetOrderById(ctx context.Context, id string) (model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderById", ctx, id)
+	ret0, _ := ret[0].(model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderById indicates an expected call of GetOrderById.
+func (mr *MockorderGatewayMockRecorder) GetOrderById(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderById", reflect.TypeOf((*MockorderGateway)(nil).GetOrderById), ctx, id)
+}
+
+// GetOrders mocks base method.
+func (m *MockorderGateway) GetOrders(ctx context.Context, from time.Time) ([]model.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, from)
+	ret0, _ := ret[0].([]model.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockorderGatewayMockRecorder) GetOrders(ctx, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockorderGateway)(nil).GetOrders), ctx, from)
+}
+
+// StreamOrders mocks base method.
+func (m *MockorderGateway) StreamOrders(ctx context.Context, from time.Time, handle func(context.Context, []model.Order) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamOrders", ctx, from, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamOrders indicates an expected call of StreamOrders.
+func (mr *MockorderGatewayMockRecorder) StreamOrders(ctx, from, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamOrders", reflect.TypeOf((*MockorderGateway)(nil).StreamOrders), ctx, from, handle)
+}
+
+// MockmetricsWriter is a mock of metricsWriter interface.
+type MockmetricsWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockmetricsWriterMockRecorder
+}
+
+// MockmetricsWriterMockRecorder is the mock recorder for MockmetricsWriter.
+type MockmetricsWriterMockRecorder struct {
+	mock *MockmetricsWriter
+}
+
+// NewMockmetricsWriter creates a new mock instance.
+func NewMockmetricsWriter(ctrl *gomock.Controller) *MockmetricsWriter {
+	mock := &MockmetricsWriter{ctrl: ctrl}
+	mock.recorder = &MockmetricsWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmetricsWriter) EXPECT() *MockmetricsWriterMockRecorder {
+	return m.recorder
+}
+
+// RecordCacheHit mocks base method.
+func (m *MockmetricsWriter) RecordCacheHit(cache string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordCacheHit", cache)
+}
+
+// RecordCacheHit indicates an expected call of RecordCacheHit.
+func (mr *MockmetricsWriterMockRecorder) RecordCacheHit(cache interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordCacheHit", reflect.TypeOf((*MockmetricsWriter)(nil).RecordCacheHit), cache)
+}
+
+// RecordCacheMiss mocks base method.
+func (m *MockmetricsWriter) RecordCacheMiss(cache string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordCacheMiss", cache)
+}
+
+// RecordCacheMiss indicates an expected call of RecordCacheMiss.
+func (mr *MockmetricsWriterMockRecorder) RecordCacheMiss(cache interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordCacheMiss", reflect.TypeOf((*MockmetricsWriter)(nil).RecordCacheMiss), cache)
+}
@@ -2,7 +2,6 @@ package order
 
 import (
 	"context"
-	"time"
 
 	"google.golang.org/grpc"
 
@@ -12,15 +11,12 @@ import (
 type client interface {
 	GetOrders(ctx context.Context, in *pb.GetOrdersRequest, opts ...grpc.CallOption) (*pb.GetOrdersResponse, error)
 	GetOrderById(ctx context.Context, in *pb.GetOrderByIdRequest, opts ...grpc.CallOption) (*pb.GetOrderByIdResponse, error)
+	StreamOrders(ctx context.Context, in *pb.GetOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.GetOrdersResponse], error)
 }
 
+// retryexec — RetryExecutor или он же, обернутый в circuit breaker.
 type retryexec interface {
-	Execute(fn func() error) error
 	ExecuteWithContext(ctx context.Context, fn func(context.Context) error) error
-	ExecuteWithCallback(
-		fn func() error,
-		onRetry func(attempt int, err error, delay time.Duration),
-	) error
 }
 
 type logger interface {
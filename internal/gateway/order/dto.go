@@ -1,14 +1,49 @@
 package order
 
 import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"courier-service/internal/model"
 	pb "courier-service/proto/order"
 )
 
 func orderModelFromProto(o *pb.Order) model.Order {
+	items := make([]model.OrderItem, 0, len(o.GetItems()))
+	for _, item := range o.GetItems() {
+		items = append(items, model.OrderItem{
+			Name:     item.GetName(),
+			Price:    item.GetPrice(),
+			Quantity: item.GetQuantity(),
+		})
+	}
+
+	address := o.GetAddress()
 	return model.Order{
-		ID:        o.Id,
-		Status:    model.OrderStatus(o.Status),
-		CreatedAt: o.CreatedAt.AsTime(),
+		ID:           o.GetId(),
+		OrderNumber:  o.GetOrderNumber(),
+		RestaurantID: o.GetRestaurantId(),
+		Items:        items,
+		TotalPrice:   o.GetTotalPrice(),
+		Address: model.OrderAddress{
+			Street:    address.GetStreet(),
+			House:     address.GetHouse(),
+			Apartment: address.GetApartment(),
+			Floor:     address.GetFloor(),
+			Comment:   address.GetComment(),
+		},
+		Status:            model.OrderStatus(o.GetStatus()),
+		CreatedAt:         timeFromProto(o.GetCreatedAt()),
+		UpdatedAt:         timeFromProto(o.GetUpdatedAt()),
+		EstimatedDelivery: timeFromProto(o.GetEstimatedDelivery()),
+	}
+}
+
+// timeFromProto возвращает нулевое время для незаполненного поля вместо 1970-01-01
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
 	}
+	return ts.AsTime()
 }
@@ -4,4 +4,6 @@ import "errors"
 
 var (
 	ErrRetryLimitExceeded = errors.New("retry limit exceeded")
+	ErrServiceUnavailable = errors.New("order service unavailable")
+	ErrOrderNotFound      = errors.New("order not found")
 )
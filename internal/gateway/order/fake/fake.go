@@ -0,0 +1,257 @@
+package fake
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "courier-service/proto/order"
+)
+
+const (
+	defaultPageSize = 100
+	bufSize         = 1024 * 1024
+)
+
+// Server — in-memory реализация OrdersServiceServer для тестов. Заказы отдаются
+// в порядке created_at, id; page_token — закодированное смещение в этом порядке.
+// Поведение отдельных вызовов задается через SetLatency и Enqueue.
+type Server struct {
+	pb.UnimplementedOrdersServiceServer
+
+	mu      sync.Mutex
+	orders  map[string]*pb.Order
+	latency map[string]time.Duration
+	faults  map[string][]Fault
+	calls   map[string]int
+}
+
+// Fault — поведение одного очередного вызова метода: задержка перед ответом
+// и, если Code не OK, ошибка вместо ответа.
+type Fault struct {
+	Delay   time.Duration
+	Code    codes.Code
+	Message string
+}
+
+func NewServer(orders ...*pb.Order) *Server {
+	s := &Server{
+		orders:  make(map[string]*pb.Order, len(orders)),
+		latency: make(map[string]time.Duration),
+		faults:  make(map[string][]Fault),
+		calls:   make(map[string]int),
+	}
+	s.AddOrders(orders...)
+	return s
+}
+
+// AddOrders добавляет заказы или заменяет существующие с тем же id.
+func (s *Server) AddOrders(orders ...*pb.Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, o := range orders {
+		s.orders[o.GetId()] = o
+	}
+}
+
+// SetStatus меняет статус сохраненного заказа, как это сделал бы сервис заказов.
+func (s *Server) SetStatus(id string, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if o, ok := s.orders[id]; ok {
+		updated := proto.Clone(o).(*pb.Order)
+		updated.Status = status
+		updated.UpdatedAt = timestamppb.Now()
+		s.orders[id] = updated
+	}
+}
+
+// SetLatency задает задержку каждого вызова method (полное имя gRPC метода).
+func (s *Server) SetLatency(method string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency[method] = d
+}
+
+// Enqueue добавляет сценарий для следующих вызовов method: каждый вызов
+// забирает один Fault, после исчерпания очереди метод отвечает штатно.
+func (s *Server) Enqueue(method string, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[method] = append(s.faults[method], faults...)
+}
+
+// Calls возвращает число вызовов method, включая завершившиеся ошибкой.
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[method]
+}
+
+// intercept учитывает вызов и применяет задержку и сценарий ошибки.
+func (s *Server) intercept(ctx context.Context, method string) error {
+	s.mu.Lock()
+	s.calls[method]++
+	delay := s.latency[method]
+	var fault Fault
+	if queue := s.faults[method]; len(queue) > 0 {
+		fault, s.faults[method] = queue[0], queue[1:]
+	}
+	s.mu.Unlock()
+
+	if d := delay + fault.Delay; d > 0 {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	if fault.Code != codes.OK {
+		return status.Error(fault.Code, fault.Message)
+	}
+	return nil
+}
+
+func (s *Server) GetOrders(ctx context.Context, req *pb.GetOrdersRequest) (*pb.GetOrdersResponse, error) {
+	if err := s.intercept(ctx, pb.OrdersService_GetOrders_FullMethodName); err != nil {
+		return nil, err
+	}
+	return s.page(req.GetFrom().AsTime().UnixNano(), req.GetPageSize(), req.GetPageToken())
+}
+
+func (s *Server) GetOrderById(ctx context.Context, req *pb.GetOrderByIdRequest) (*pb.GetOrderByIdResponse, error) {
+	if err := s.intercept(ctx, pb.OrdersService_GetOrderById_FullMethodName); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders[req.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "order %s not found", req.GetId())
+	}
+	return &pb.GetOrderByIdResponse{Order: o}, nil
+}
+
+func (s *Server) StreamOrders(req *pb.GetOrdersRequest, stream grpc.ServerStreamingServer[pb.GetOrdersResponse]) error {
+	if err := s.intercept(stream.Context(), pb.OrdersService_StreamOrders_FullMethodName); err != nil {
+		return err
+	}
+
+	from := req.GetFrom().AsTime().UnixNano()
+	token := req.GetPageToken()
+	for {
+		resp, err := s.page(from, req.GetPageSize(), token)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+		if resp.NextPageToken == "" {
+			return nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+func (s *Server) page(from int64, pageSize int32, token string) (*pb.GetOrdersResponse, error) {
+	offset, err := decodePageToken(token)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token %q", token)
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	orders := s.ordersFrom(from)
+	if offset > len(orders) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token %q", token)
+	}
+
+	end := min(offset+int(pageSize), len(orders))
+	resp := &pb.GetOrdersResponse{Orders: orders[offset:end]}
+	if end < len(orders) {
+		resp.NextPageToken = encodePageToken(end)
+	}
+	return resp, nil
+}
+
+func (s *Server) ordersFrom(from int64) []*pb.Order {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders := make([]*pb.Order, 0, len(s.orders))
+	for _, o := range s.orders {
+		if o.GetCreatedAt().AsTime().UnixNano() >= from {
+			orders = append(orders, o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		ti, tj := orders[i].GetCreatedAt().AsTime(), orders[j].GetCreatedAt().AsTime()
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return orders[i].GetId() < orders[j].GetId()
+	})
+	return orders
+}
+
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(string(raw))
+}
+
+// Serve поднимает srv на bufconn и возвращает подключенного к нему клиента.
+// stop закрывает соединение и останавливает сервер.
+func Serve(srv pb.OrdersServiceServer, opts ...grpc.DialOption) (conn *grpc.ClientConn, stop func(), err error) {
+	lis := bufconn.Listen(bufSize)
+	server := grpc.NewServer()
+	pb.RegisterOrdersServiceServer(server, srv)
+	go func() { _ = server.Serve(lis) }()
+
+	opts = append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, opts...)
+	conn, err = grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		server.Stop()
+		return nil, nil, err
+	}
+
+	return conn, func() {
+		_ = conn.Close()
+		server.Stop()
+	}, nil
+}
@@ -0,0 +1,68 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"courier-service/internal/gateway/order/fake"
+	pb "courier-service/proto/order"
+)
+
+func newClient(t *testing.T, srv *fake.Server) pb.OrdersServiceClient {
+	t.Helper()
+
+	conn, stop, err := fake.Serve(srv)
+	require.NoError(t, err)
+	t.Cleanup(stop)
+	return pb.NewOrdersServiceClient(conn)
+}
+
+func TestServer_EnqueuedFaults(t *testing.T) {
+	srv := fake.NewServer(&pb.Order{Id: "order-1", Status: "created"})
+	srv.Enqueue(pb.OrdersService_GetOrderById_FullMethodName,
+		fake.Fault{Code: codes.Unavailable},
+		fake.Fault{Code: codes.ResourceExhausted, Message: "slow down"},
+	)
+	client := newClient(t, srv)
+	req := &pb.GetOrderByIdRequest{Id: "order-1"}
+
+	_, err := client.GetOrderById(context.Background(), req)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = client.GetOrderById(context.Background(), req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	resp, err := client.GetOrderById(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "created", resp.GetOrder().GetStatus())
+	assert.Equal(t, 3, srv.Calls(pb.OrdersService_GetOrderById_FullMethodName))
+}
+
+func TestServer_Latency(t *testing.T) {
+	srv := fake.NewServer()
+	srv.SetLatency(pb.OrdersService_GetOrders_FullMethodName, time.Second)
+	client := newClient(t, srv)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetOrders(ctx, &pb.GetOrdersRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestServer_SetStatus(t *testing.T) {
+	srv := fake.NewServer(&pb.Order{Id: "order-1", Status: "created"})
+	client := newClient(t, srv)
+
+	srv.SetStatus("order-1", "cancelled")
+
+	resp, err := client.GetOrderById(context.Background(), &pb.GetOrderByIdRequest{Id: "order-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "cancelled", resp.GetOrder().GetStatus())
+}
@@ -4,61 +4,145 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"courier-service/internal/gateway/breaker"
 	re "courier-service/internal/gateway/retry"
 	"courier-service/internal/model"
+	"courier-service/pkg/retrypolicy"
 	pb "courier-service/proto/order"
 )
 
+// DefaultPageSize — размер страницы заказов, если в NewGateway передан 0.
+const DefaultPageSize = 500
+
 type Gateway struct {
 	client    client
 	retryexec retryexec
 	logger    logger
+	pageSize  int32
 }
 
-func NewGateway(client client, rexec retryexec, logger logger) *Gateway {
+func NewGateway(client client, rexec retryexec, logger logger, pageSize int) *Gateway {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
 	return &Gateway{
 		client:    client,
 		retryexec: rexec,
 		logger:    logger,
+		pageSize:  int32(pageSize),
 	}
 }
 
+// GetOrders собирает все заказы начиная с from, запрашивая их постранично:
+// каждая страница повторяется отдельно и укладывается в лимит размера сообщения.
 func (g *Gateway) GetOrders(ctx context.Context, from time.Time) ([]model.Order, error) {
-	var orders *pb.GetOrdersResponse
+	var (
+		ordersList []model.Order
+		pageToken  string
+	)
+
+	ctx = retrypolicy.WithMethod(ctx, pb.OrdersService_GetOrders_FullMethodName)
+	for {
+		var page *pb.GetOrdersResponse
+		err := g.retryexec.ExecuteWithContext(ctx, func(ctx context.Context) error {
+			resp, err := g.client.GetOrders(ctx, &pb.GetOrdersRequest{
+				From:      timestamppb.New(from),
+				PageSize:  g.pageSize,
+				PageToken: pageToken,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get orders: %w", err)
+			}
+			if resp == nil {
+				return errors.New("no orders found")
+			}
+			page = resp
+			return nil
+		})
+		if err != nil {
+			return nil, mapExecError(err)
+		}
+
+		for _, order := range page.Orders {
+			ordersList = append(ordersList, orderModelFromProto(order))
+		}
+		if page.NextPageToken == "" {
+			return ordersList, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// StreamOrders читает заказы начиная с from потоком страниц и передает каждую
+// в handle. Следующая страница читается только после возврата из handle, так что
+// медленный обработчик тормозит сервер через flow control gRPC. При обрыве поток
+// переоткрывается с последней обработанной страницы. Ошибка handle прерывает
+// чтение без повторов.
+func (g *Gateway) StreamOrders(
+	ctx context.Context,
+	from time.Time,
+	handle func(ctx context.Context, orders []model.Order) error,
+) error {
+	var (
+		pageToken string
+		handleErr error
+	)
 
+	ctx = retrypolicy.WithMethod(ctx, pb.OrdersService_StreamOrders_FullMethodName)
 	err := g.retryexec.ExecuteWithContext(ctx, func(ctx context.Context) error {
-		resp, err := g.client.GetOrders(ctx, &pb.GetOrdersRequest{
-			From: timestamppb.New(from),
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := g.client.StreamOrders(streamCtx, &pb.GetOrdersRequest{
+			From:      timestamppb.New(from),
+			PageSize:  g.pageSize,
+			PageToken: pageToken,
 		})
 		if err != nil {
-			return fmt.Errorf("failed to get orders: %w", err)
+			return fmt.Errorf("failed to open orders stream: %w", err)
 		}
-		if resp == nil {
-			return errors.New("no orders found")
+
+		for {
+			page, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to receive orders page: %w", err)
+			}
+
+			orders := make([]model.Order, 0, len(page.Orders))
+			for _, order := range page.Orders {
+				orders = append(orders, orderModelFromProto(order))
+			}
+			if err := handle(ctx, orders); err != nil {
+				handleErr = err
+				return nil
+			}
+			if page.NextPageToken == "" {
+				return nil
+			}
+			pageToken = page.NextPageToken
 		}
-		orders = resp
-		return nil
 	})
-
 	if err != nil {
-		return nil, err
+		return mapExecError(err)
 	}
-
-	ordersList := make([]model.Order, 0, len(orders.Orders))
-	for _, order := range orders.Orders {
-		ordersList = append(ordersList, orderModelFromProto(order))
-	}
-	return ordersList, nil
+	return handleErr
 }
 
 func (g *Gateway) GetOrderById(ctx context.Context, id string) (model.Order, error) {
 	var order *pb.GetOrderByIdResponse
 
 	g.logger.Infof("sending grpc request for order id %s", id)
+	ctx = retrypolicy.WithMethod(ctx, pb.OrdersService_GetOrderById_FullMethodName)
 	err := g.retryexec.ExecuteWithContext(ctx, func(ctx context.Context) error {
 		resp, err := g.client.GetOrderById(ctx, &pb.GetOrderByIdRequest{
 			Id: id,
@@ -74,11 +158,21 @@ func (g *Gateway) GetOrderById(ctx context.Context, id string) (model.Order, err
 	})
 
 	if err != nil {
-		if errors.Is(err, re.ErrMaxAttemptsExceeded) {
-			return model.Order{}, ErrRetryLimitExceeded
-		}
-		return model.Order{}, err
+		return model.Order{}, mapExecError(err)
 	}
 
 	return orderModelFromProto(order.Order), nil
 }
+
+func mapExecError(err error) error {
+	switch {
+	case errors.Is(err, re.ErrMaxAttemptsExceeded):
+		return ErrRetryLimitExceeded
+	case errors.Is(err, breaker.ErrCircuitOpen), errors.Is(err, breaker.ErrTooManyRequests):
+		return fmt.Errorf("%w: %v", ErrServiceUnavailable, err)
+	case status.Code(err) == codes.NotFound:
+		return ErrOrderNotFound
+	default:
+		return err
+	}
+}
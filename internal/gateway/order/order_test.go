@@ -0,0 +1,175 @@
+package order_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ordergw "courier-service/internal/gateway/order"
+	"courier-service/internal/gateway/order/fake"
+	retryexec "courier-service/internal/gateway/retry"
+	"courier-service/internal/model"
+	delay "courier-service/pkg/delay/fulljitter"
+	"courier-service/pkg/retrypolicy"
+	pb "courier-service/proto/order"
+)
+
+const pageSize = 2
+
+// flakyStreamServer обрывает первый поток после первой страницы.
+type flakyStreamServer struct {
+	*fake.Server
+	calls  atomic.Int32
+	tokens []string
+}
+
+func (s *flakyStreamServer) StreamOrders(req *pb.GetOrdersRequest, stream grpc.ServerStreamingServer[pb.GetOrdersResponse]) error {
+	s.tokens = append(s.tokens, req.GetPageToken())
+	if s.calls.Add(1) > 1 {
+		return s.Server.StreamOrders(req, stream)
+	}
+
+	page, err := s.Server.GetOrders(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(page); err != nil {
+		return err
+	}
+	return status.Error(codes.Unavailable, "connection reset")
+}
+
+func newGateway(t *testing.T, srv pb.OrdersServiceServer) *ordergw.Gateway {
+	t.Helper()
+
+	conn, stop, err := fake.Serve(srv)
+	require.NoError(t, err)
+	t.Cleanup(stop)
+
+	logger := zap.NewNop().Sugar()
+	retry := retryexec.NewRetryExecutor(retryexec.RetryConfig{
+		MaxAttempts: 3,
+		Strategy:    delay.NewFullJitter(time.Millisecond, 5*time.Millisecond, 2.0, nil),
+		ShouldRetry: retrypolicy.IsRetryable,
+	}, logger)
+	return ordergw.NewGateway(pb.NewOrdersServiceClient(conn), retry, logger, pageSize)
+}
+
+func testOrders(n int) []*pb.Order {
+	base := time.Now().Add(-time.Minute)
+	orders := make([]*pb.Order, 0, n)
+	for i := 0; i < n; i++ {
+		orders = append(orders, &pb.Order{
+			Id:         fmt.Sprintf("order-%d", i),
+			Status:     string(model.OrderStatusCreated),
+			TotalPrice: int64(100 * (i + 1)),
+			CreatedAt:  timestamppb.New(base.Add(time.Duration(i) * time.Second)),
+		})
+	}
+	return orders
+}
+
+func orderIDs(orders []model.Order) []string {
+	ids := make([]string, 0, len(orders))
+	for _, o := range orders {
+		ids = append(ids, o.ID)
+	}
+	return ids
+}
+
+func TestGateway_GetOrders_Pages(t *testing.T) {
+	g := newGateway(t, fake.NewServer(testOrders(5)...))
+
+	orders, err := g.GetOrders(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"order-0", "order-1", "order-2", "order-3", "order-4"}, orderIDs(orders))
+	assert.Equal(t, int64(500), orders[4].TotalPrice)
+}
+
+func TestGateway_StreamOrders(t *testing.T) {
+	tests := []struct {
+		name         string
+		orders       int
+		handleErr    error
+		wantPages    [][]string
+		expectations func(t *testing.T, err error)
+	}{
+		{
+			name:      "pages are delivered in order",
+			orders:    5,
+			wantPages: [][]string{{"order-0", "order-1"}, {"order-2", "order-3"}, {"order-4"}},
+			expectations: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:      "empty result is a single empty page",
+			orders:    0,
+			wantPages: [][]string{{}},
+			expectations: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:      "handler error stops the stream without retry",
+			orders:    5,
+			handleErr: errors.New("stop"),
+			wantPages: [][]string{{"order-0", "order-1"}},
+			expectations: func(t *testing.T, err error) {
+				assert.EqualError(t, err, "stop")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGateway(t, fake.NewServer(testOrders(tc.orders)...))
+
+			var pages [][]string
+			err := g.StreamOrders(context.Background(), time.Now().Add(-time.Hour),
+				func(ctx context.Context, orders []model.Order) error {
+					pages = append(pages, orderIDs(orders))
+					return tc.handleErr
+				})
+
+			assert.Equal(t, tc.wantPages, pages)
+			tc.expectations(t, err)
+		})
+	}
+}
+
+func TestGateway_StreamOrders_ResumesAfterDisconnect(t *testing.T) {
+	srv := &flakyStreamServer{Server: fake.NewServer(testOrders(5)...)}
+	g := newGateway(t, srv)
+
+	var got []string
+	err := g.StreamOrders(context.Background(), time.Now().Add(-time.Hour),
+		func(ctx context.Context, orders []model.Order) error {
+			got = append(got, orderIDs(orders)...)
+			return nil
+		})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"order-0", "order-1", "order-2", "order-3", "order-4"}, got)
+	require.Len(t, srv.tokens, 2)
+	assert.Empty(t, srv.tokens[0])
+	assert.NotEmpty(t, srv.tokens[1], "second stream must continue from the last received page")
+}
+
+func TestGateway_GetOrderById_NotFound(t *testing.T) {
+	g := newGateway(t, fake.NewServer())
+
+	_, err := g.GetOrderById(context.Background(), "missing")
+	assert.ErrorIs(t, err, ordergw.ErrOrderNotFound)
+}
@@ -1,6 +1,10 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
 package retry
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type logger interface {
 	Debug(args ...interface{})
@@ -23,3 +27,14 @@ type logger interface {
 type strategy interface {
 	NextDelay(attempt int) time.Duration
 }
+
+// policy решает, повторять ли вызов, и может вернуть задержку,
+// запрошенную сервером (0 — использовать strategy).
+type policy interface {
+	ShouldRetry(ctx context.Context, attempt int, err error) (bool, time.Duration)
+}
+
+type budget interface {
+	RecordRequest()
+	TryRetry() bool
+}
@@ -0,0 +1,342 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package retry_test is a generated GoMock package.
+package retry_test
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// Debug mocks base method.
+func (m *Mocklogger) Debug(args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Debug", varargs...)
+}
+
+// Debug indicates an expected call of Debug.
+func (mr *MockloggerMockRecorder) Debug(args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debug", reflect.TypeOf((*Mocklogger)(nil).Debug), args...)
+}
+
+// Debugf mocks base method.
+func (m *Mocklogger) Debugf(format string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{format}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Debugf", varargs...)
+}
+
+// Debugf indicates an expected call of Debugf.
+func (mr *MockloggerMockRecorder) Debugf(format interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{format}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debugf", reflect.TypeOf((*Mocklogger)(nil).Debugf), varargs...)
+}
+
+// Debugw mocks base method.
+func (m *Mocklogger) Debugw(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Debugw", varargs...)
+}
+
+// Debugw indicates an expected call of Debugw.
+func (mr *MockloggerMockRecorder) Debugw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Debugw", reflect.TypeOf((*Mocklogger)(nil).Debugw), varargs...)
+}
+
+// Error mocks base method.
+func (m *Mocklogger) Error(args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Error", varargs...)
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockloggerMockRecorder) Error(args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*Mocklogger)(nil).Error), args...)
+}
+
+// Errorf mocks base method.
+func (m *Mocklogger) Errorf(format string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{format}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Errorf", varargs...)
+}
+
+// Errorf indicates an expected call of Errorf.
+func (mr *MockloggerMockRecorder) Errorf(format interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{format}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorf", reflect.TypeOf((*Mocklogger)(nil).Errorf), varargs...)
+}
+
+// Fatal mocks base method.
+func (m *Mocklogger) Fatal(args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Fatal", varargs...)
+}
+
+// Fatal indicates an expected call of Fatal.
+func (mr *MockloggerMockRecorder) Fatal(args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatal", reflect.TypeOf((*Mocklogger)(nil).Fatal), args...)
+}
+
+// Fatalf mocks base method.
+func (m *Mocklogger) Fatalf(format string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{format}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Fatalf", varargs...)
+}
+
+// Fatalf indicates an expected call of Fatalf.
+func (mr *MockloggerMockRecorder) Fatalf(format interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{format}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fatalf", reflect.TypeOf((*Mocklogger)(nil).Fatalf), varargs...)
+}
+
+// Info mocks base method.
+func (m *Mocklogger) Info(args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Info", varargs...)
+}
+
+// Info indicates an expected call of Info.
+func (mr *MockloggerMockRecorder) Info(args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*Mocklogger)(nil).Info), args...)
+}
+
+// Infof mocks base method.
+func (m *Mocklogger) Infof(format string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{format}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Infof", varargs...)
+}
+
+// Infof indicates an expected call of Infof.
+func (mr *MockloggerMockRecorder) Infof(format interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{format}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Infof", reflect.TypeOf((*Mocklogger)(nil).Infof), varargs...)
+}
+
+// Warn mocks base method.
+func (m *Mocklogger) Warn(args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warn", varargs...)
+}
+
+// Warn indicates an expected call of Warn.
+func (mr *MockloggerMockRecorder) Warn(args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warn", reflect.TypeOf((*Mocklogger)(nil).Warn), args...)
+}
+
+// Warnf mocks base method.
+func (m *Mocklogger) Warnf(format string, args ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{format}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Warnf", varargs...)
+}
+
+// Warnf indicates an expected call of Warnf.
+func (mr *MockloggerMockRecorder) Warnf(format interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{format}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnf", reflect.TypeOf((*Mocklogger)(nil).Warnf), varargs...)
+}
+
+// Mockstrategy is a mock of strategy interface.
+type Mockstrategy struct {
+	ctrl     *gomock.Controller
+	recorder *MockstrategyMockRecorder
+}
+
+// MockstrategyMockRecorder is the mock recorder for Mockstrategy.
+type MockstrategyMockRecorder struct {
+	mock *Mockstrategy
+}
+
+// NewMockstrategy creates a new mock instance.
+func NewMockstrategy(ctrl *gomock.Controller) *Mockstrategy {
+	mock := &Mockstrategy{ctrl: ctrl}
+	mock.recorder = &MockstrategyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstrategy) EXPECT() *MockstrategyMockRecorder {
+	return m.recorder
+}
+
+// NextDelay mocks base method.
+func (m *Mockstrategy) NextDelay(attempt int) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextDelay", attempt)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// NextDelay indicates an expected call of NextDelay.
+func (mr *MockstrategyMockRecorder) NextDelay(attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextDelay", reflect.TypeOf((*Mockstrategy)(nil).NextDelay), attempt)
+}
+
+// Mockpolicy is a mock of policy interface.
+type Mockpolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockpolicyMockRecorder
+}
+
+// MockpolicyMockRecorder is the mock recorder for Mockpolicy.
+type MockpolicyMockRecorder struct {
+	mock *Mockpolicy
+}
+
+// NewMockpolicy creates a new mock instance.
+func NewMockpolicy(ctrl *gomock.Controller) *Mockpolicy {
+	mock := &Mockpolicy{ctrl: ctrl}
+	mock.recorder = &MockpolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpolicy) EXPECT() *MockpolicyMockRecorder {
+	return m.recorder
+}
+
+// ShouldRetry mocks base method.
+func (m *Mockpolicy) ShouldRetry(ctx context.Context, attempt int, err error) (bool, time.Duration) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldRetry", ctx, attempt, err)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(time.Duration)
+	return ret0, ret1
+}
+
+// ShouldRetry indicates an expected call of ShouldRetry.
+func (mr *MockpolicyMockRecorder) ShouldRetry(ctx, attempt, err interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldRetry", reflect.TypeOf((*Mockpolicy)(nil).ShouldRetry), ctx, attempt, err)
+}
+
+// Mockbudget is a mock of budget interface.
+type Mockbudget struct {
+	ctrl     *gomock.Controller
+	recorder *MockbudgetMockRecorder
+}
+
+// MockbudgetMockRecorder is the mock recorder for Mockbudget.
+type MockbudgetMockRecorder struct {
+	mock *Mockbudget
+}
+
+// NewMockbudget creates a new mock instance.
+func NewMockbudget(ctrl *gomock.Controller) *Mockbudget {
+	mock := &Mockbudget{ctrl: ctrl}
+	mock.recorder = &MockbudgetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockbudget) EXPECT() *MockbudgetMockRecorder {
+	return m.recorder
+}
+
+// RecordRequest mocks base method.
+func (m *Mockbudget) RecordRequest() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordRequest")
+}
+
+// RecordRequest indicates an expected call of RecordRequest.
+func (mr *MockbudgetMockRecorder) RecordRequest() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRequest", reflect.TypeOf((*Mockbudget)(nil).RecordRequest))
+}
+
+// TryRetry mocks base method.
+func (m *Mockbudget) TryRetry() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryRetry")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TryRetry indicates an expected call of TryRetry.
+func (mr *MockbudgetMockRecorder) TryRetry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryRetry", reflect.TypeOf((*Mockbudget)(nil).TryRetry))
+}
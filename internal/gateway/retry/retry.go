@@ -4,20 +4,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 )
 
-var ErrMaxAttemptsExceeded = errors.New("max retry attempts exceeded")
+var (
+	ErrMaxAttemptsExceeded  = errors.New("max retry attempts exceeded")
+	ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+)
 
 type RetryConfig struct {
 	MaxAttempts int
 	Strategy    strategy
 	ShouldRetry func(error) bool
+	// Policy, если задана, используется в ExecuteWithContext вместо ShouldRetry.
+	// MaxAttempts остается верхней границей числа попыток.
+	Policy policy
+	// Budget ограничивает долю повторов в ExecuteWithContext.
+	Budget budget
 }
 
 type RetryExecutor struct {
 	config RetryConfig
-	logger logger
+	// maxAttempts — действующее значение config.MaxAttempts, меняется
+	// через SetMaxAttempts без пересоздания executor'а.
+	maxAttempts atomic.Int64
+	logger      logger
 }
 
 func NewRetryExecutor(config RetryConfig, logger logger) *RetryExecutor {
@@ -27,13 +39,30 @@ func NewRetryExecutor(config RetryConfig, logger logger) *RetryExecutor {
 	if config.ShouldRetry == nil {
 		config.ShouldRetry = func(err error) bool { return err != nil }
 	}
-	return &RetryExecutor{config: config, logger: logger}
+	r := &RetryExecutor{config: config, logger: logger}
+	r.maxAttempts.Store(int64(config.MaxAttempts))
+	return r
+}
+
+// SetMaxAttempts меняет число попыток для последующих вызовов; уже
+// начатые вызовы дорабатывают со старым значением.
+func (r *RetryExecutor) SetMaxAttempts(maxAttempts int) {
+	if maxAttempts <= 0 {
+		return
+	}
+	r.maxAttempts.Store(int64(maxAttempts))
+}
+
+// MaxAttempts возвращает действующее число попыток.
+func (r *RetryExecutor) MaxAttempts() int {
+	return int(r.maxAttempts.Load())
 }
 
 func (r *RetryExecutor) Execute(fn func() error) error {
 	var lastErr error
+	maxAttempts := r.MaxAttempts()
 
-	for attempt := 1; attempt <= r.config.MaxAttempts; attempt++ {
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		err := fn()
 		if err == nil {
 			return nil
@@ -45,7 +74,7 @@ func (r *RetryExecutor) Execute(fn func() error) error {
 			return err
 		}
 
-		if attempt == r.config.MaxAttempts {
+		if attempt == maxAttempts {
 			break
 		}
 
@@ -58,8 +87,13 @@ func (r *RetryExecutor) Execute(fn func() error) error {
 
 func (r *RetryExecutor) ExecuteWithContext(ctx context.Context, fn func(context.Context) error) error {
 	var lastErr error
+	maxAttempts := r.MaxAttempts()
+
+	if r.config.Budget != nil {
+		r.config.Budget.RecordRequest()
+	}
 
-	for attempt := 1; attempt <= r.config.MaxAttempts; attempt++ {
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
 
 		if err := ctx.Err(); err != nil {
 			return err
@@ -72,18 +106,26 @@ func (r *RetryExecutor) ExecuteWithContext(ctx context.Context, fn func(context.
 
 		lastErr = err
 
-		if !r.config.ShouldRetry(err) {
+		retry, pushback := r.shouldRetry(ctx, attempt, err)
+		if !retry {
 			return err
 		}
 
-		if attempt == r.config.MaxAttempts {
+		if attempt == maxAttempts {
 			r.logger.Warnf("Attempt %d failed (last), retrying is stopped", attempt)
 			break
-		} else {
-			r.logger.Warnf("Attempt %d failed, retrying...", attempt)
 		}
 
-		delay := r.config.Strategy.NextDelay(attempt)
+		if r.config.Budget != nil && !r.config.Budget.TryRetry() {
+			r.logger.Warnf("Attempt %d failed, retry budget exhausted", attempt)
+			return fmt.Errorf("%w: %w", ErrRetryBudgetExhausted, err)
+		}
+		r.logger.Warnf("Attempt %d failed, retrying...", attempt)
+
+		delay := pushback
+		if delay <= 0 {
+			delay = r.config.Strategy.NextDelay(attempt)
+		}
 
 		select {
 		case <-time.After(delay):
@@ -92,7 +134,14 @@ func (r *RetryExecutor) ExecuteWithContext(ctx context.Context, fn func(context.
 		}
 	}
 
-	return fmt.Errorf("%w: %v", ErrMaxAttemptsExceeded, lastErr)
+	return fmt.Errorf("%w: %w", ErrMaxAttemptsExceeded, lastErr)
+}
+
+func (r *RetryExecutor) shouldRetry(ctx context.Context, attempt int, err error) (bool, time.Duration) {
+	if r.config.Policy != nil {
+		return r.config.Policy.ShouldRetry(ctx, attempt, err)
+	}
+	return r.config.ShouldRetry(err), 0
 }
 
 func (r *RetryExecutor) ExecuteWithCallback(
@@ -100,8 +149,9 @@ func (r *RetryExecutor) ExecuteWithCallback(
 	onRetry func(attempt int, err error, delay time.Duration),
 ) error {
 	var lastErr error
+	maxAttempts := r.MaxAttempts()
 
-	for attempt := 1; attempt <= r.config.MaxAttempts; attempt++ {
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		err := fn()
 		if err == nil {
 			return nil
@@ -113,7 +163,7 @@ func (r *RetryExecutor) ExecuteWithCallback(
 			return err
 		}
 
-		if attempt == r.config.MaxAttempts {
+		if attempt == maxAttempts {
 			break
 		}
 
@@ -0,0 +1,137 @@
+package retry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"courier-service/internal/gateway/retry"
+)
+
+var errTemporary = errors.New("temporary")
+
+func TestRetryExecutor_ExecuteWithContext(t *testing.T) {
+	tests := []struct {
+		name      string
+		prepare   func(policy *Mockpolicy, budget *Mockbudget, strategy *Mockstrategy)
+		results   []error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name: "policy stops on non-retryable error",
+			prepare: func(policy *Mockpolicy, budget *Mockbudget, strategy *Mockstrategy) {
+				budget.EXPECT().RecordRequest()
+				policy.EXPECT().ShouldRetry(gomock.Any(), 1, errTemporary).Return(false, time.Duration(0))
+			},
+			results:   []error{errTemporary},
+			wantCalls: 1,
+			wantErr:   errTemporary,
+		},
+		{
+			name: "server pushback replaces strategy delay",
+			prepare: func(policy *Mockpolicy, budget *Mockbudget, strategy *Mockstrategy) {
+				budget.EXPECT().RecordRequest()
+				policy.EXPECT().ShouldRetry(gomock.Any(), 1, errTemporary).Return(true, time.Millisecond)
+				budget.EXPECT().TryRetry().Return(true)
+				strategy.EXPECT().NextDelay(gomock.Any()).Times(0)
+			},
+			results:   []error{errTemporary, nil},
+			wantCalls: 2,
+		},
+		{
+			name: "exhausted budget stops retries",
+			prepare: func(policy *Mockpolicy, budget *Mockbudget, strategy *Mockstrategy) {
+				budget.EXPECT().RecordRequest()
+				policy.EXPECT().ShouldRetry(gomock.Any(), 1, errTemporary).Return(true, time.Duration(0))
+				budget.EXPECT().TryRetry().Return(false)
+			},
+			results:   []error{errTemporary},
+			wantCalls: 1,
+			wantErr:   retry.ErrRetryBudgetExhausted,
+		},
+		{
+			name: "max attempts keeps last error",
+			prepare: func(policy *Mockpolicy, budget *Mockbudget, strategy *Mockstrategy) {
+				budget.EXPECT().RecordRequest()
+				policy.EXPECT().ShouldRetry(gomock.Any(), gomock.Any(), errTemporary).Return(true, time.Duration(0)).Times(2)
+				budget.EXPECT().TryRetry().Return(true)
+				strategy.EXPECT().NextDelay(1).Return(time.Millisecond)
+			},
+			results:   []error{errTemporary, errTemporary},
+			wantCalls: 2,
+			wantErr:   errTemporary,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockPolicy := NewMockpolicy(ctrl)
+			mockBudget := NewMockbudget(ctrl)
+			mockStrategy := NewMockstrategy(ctrl)
+			tt.prepare(mockPolicy, mockBudget, mockStrategy)
+
+			mockLogger := NewMocklogger(ctrl)
+			mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			exec := retry.NewRetryExecutor(retry.RetryConfig{
+				MaxAttempts: 2,
+				Strategy:    mockStrategy,
+				Policy:      mockPolicy,
+				Budget:      mockBudget,
+			}, mockLogger)
+
+			calls := 0
+			err := exec.ExecuteWithContext(context.Background(), func(context.Context) error {
+				err := tt.results[calls]
+				calls++
+				return err
+			})
+
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryExecutor_SetMaxAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStrategy := NewMockstrategy(ctrl)
+	mockStrategy.EXPECT().NextDelay(gomock.Any()).Return(time.Duration(0)).AnyTimes()
+	mockLogger := NewMocklogger(ctrl)
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	exec := retry.NewRetryExecutor(retry.RetryConfig{MaxAttempts: 2, Strategy: mockStrategy}, mockLogger)
+
+	count := func() int {
+		calls := 0
+		_ = exec.ExecuteWithContext(context.Background(), func(context.Context) error {
+			calls++
+			return errTemporary
+		})
+		return calls
+	}
+
+	assert.Equal(t, 2, count())
+
+	exec.SetMaxAttempts(4)
+	assert.Equal(t, 4, exec.MaxAttempts())
+	assert.Equal(t, 4, count())
+
+	// некорректное значение игнорируется
+	exec.SetMaxAttempts(0)
+	assert.Equal(t, 4, exec.MaxAttempts())
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
+package admin
+
+import (
+	l "courier-service/pkg/logger/zap"
+)
+
+type logLevels interface {
+	Level() l.LogLevel
+	SetLevel(level l.LogLevel) error
+	ModuleLevels() map[string]l.LogLevel
+	SetModuleLevel(module string, level l.LogLevel) error
+	ResetModuleLevel(module string) error
+}
@@ -0,0 +1,15 @@
+package admin
+
+import l "courier-service/pkg/logger/zap"
+
+// LogLevelRequestDTO меняет общий уровень, если Module пуст, иначе уровень
+// модуля. Пустой Level для модуля снимает переопределение.
+type LogLevelRequestDTO struct {
+	Module string `json:"module"`
+	Level  string `json:"level"`
+}
+
+type LogLevelResponseDTO struct {
+	Level   l.LogLevel            `json:"level"`
+	Modules map[string]l.LogLevel `json:"modules"`
+}
@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"courier-service/internal/handlers/utils"
+	l "courier-service/pkg/logger/zap"
+)
+
+const (
+	ErrUnknownLevel  = "Unknown log level"
+	ErrUnknownModule = "Unknown log module"
+)
+
+type LogLevelController struct {
+	levels logLevels
+}
+
+func NewLogLevelController(levels logLevels) *LogLevelController {
+	return &LogLevelController{levels: levels}
+}
+
+func (c *LogLevelController) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	utils.RespondWithJSON(w, http.StatusOK, c.current())
+}
+
+func (c *LogLevelController) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req LogLevelRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var err error
+	switch {
+	case req.Module == "":
+		err = c.levels.SetLevel(l.LogLevel(req.Level))
+	case req.Level == "":
+		err = c.levels.ResetModuleLevel(req.Module)
+	default:
+		err = c.levels.SetModuleLevel(req.Module, l.LogLevel(req.Level))
+	}
+
+	switch {
+	case errors.Is(err, l.ErrUnknownLevel):
+		utils.RespondWithError(w, http.StatusBadRequest, ErrUnknownLevel)
+	case errors.Is(err, l.ErrUnknownModule):
+		utils.RespondWithError(w, http.StatusNotFound, ErrUnknownModule)
+	default:
+		utils.RespondWithJSON(w, http.StatusOK, c.current())
+	}
+}
+
+func (c *LogLevelController) current() LogLevelResponseDTO {
+	return LogLevelResponseDTO{
+		Level:   c.levels.Level(),
+		Modules: c.levels.ModuleLevels(),
+	}
+}
@@ -0,0 +1,108 @@
+package admin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"courier-service/internal/handlers/admin"
+	l "courier-service/pkg/logger/zap"
+)
+
+func TestLogLevelHandler_GetLogLevel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	levels := NewMocklogLevels(ctrl)
+	levels.EXPECT().Level().Return(l.LogLevelInfo)
+	levels.EXPECT().ModuleLevels().Return(map[string]l.LogLevel{"gateway": l.LogLevelDebug})
+
+	rr := httptest.NewRecorder()
+	admin.NewLogLevelController(levels).GetLogLevel(rr, httptest.NewRequest(http.MethodGet, "/admin/log-level", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	var resp admin.LogLevelResponseDTO
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	assert.Equal(t, l.LogLevelInfo, resp.Level)
+	assert.Equal(t, map[string]l.LogLevel{"gateway": l.LogLevelDebug}, resp.Modules)
+}
+
+func TestLogLevelHandler_SetLogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		body           string
+		prepare        func(levels *MocklogLevels)
+		wantStatusCode int
+	}{
+		{
+			name: "success: root level",
+			body: `{"level":"debug"}`,
+			prepare: func(levels *MocklogLevels) {
+				levels.EXPECT().SetLevel(l.LogLevelDebug).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "success: module level",
+			body: `{"module":"gateway","level":"warn"}`,
+			prepare: func(levels *MocklogLevels) {
+				levels.EXPECT().SetModuleLevel("gateway", l.LogLevelWarn).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "success: reset module level",
+			body: `{"module":"gateway"}`,
+			prepare: func(levels *MocklogLevels) {
+				levels.EXPECT().ResetModuleLevel("gateway").Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "error: unknown level",
+			body: `{"level":"verbose"}`,
+			prepare: func(levels *MocklogLevels) {
+				levels.EXPECT().SetLevel(l.LogLevel("verbose")).Return(l.ErrUnknownLevel)
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "error: unknown module",
+			body: `{"module":"billing","level":"debug"}`,
+			prepare: func(levels *MocklogLevels) {
+				levels.EXPECT().SetModuleLevel("billing", l.LogLevelDebug).Return(l.ErrUnknownModule)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "error: invalid body",
+			body:           `{`,
+			prepare:        func(levels *MocklogLevels) {},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			levels := NewMocklogLevels(ctrl)
+			tt.prepare(levels)
+			levels.EXPECT().Level().Return(l.LogLevelInfo).AnyTimes()
+			levels.EXPECT().ModuleLevels().Return(map[string]l.LogLevel{}).AnyTimes()
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/log-level", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+			admin.NewLogLevelController(levels).SetLogLevel(rr, req)
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package admin_test is a generated GoMock package.
+package admin_test
+
+import (
+	logger "courier-service/pkg/logger/zap"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocklogLevels is a mock of logLevels interface.
+type MocklogLevels struct {
+	ctrl     *gomock.Controller
+	recorder *MocklogLevelsMockRecorder
+}
+
+// MocklogLevelsMockRecorder is the mock recorder for MocklogLevels.
+type MocklogLevelsMockRecorder struct {
+	mock *MocklogLevels
+}
+
+// NewMocklogLevels creates a new mock instance.
+func NewMocklogLevels(ctrl *gomock.Controller) *MocklogLevels {
+	mock := &MocklogLevels{ctrl: ctrl}
+	mock.recorder = &MocklogLevelsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocklogLevels) EXPECT() *MocklogLevelsMockRecorder {
+	return m.recorder
+}
+
+// Level mocks base method.
+func (m *MocklogLevels) Level() logger.LogLevel {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Level")
+	ret0, _ := ret[0].(logger.LogLevel)
+	return ret0
+}
+
+// Level indicates an expected call of Level.
+func (mr *MocklogLevelsMockRecorder) Level() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Level", reflect.TypeOf((*MocklogLevels)(nil).Level))
+}
+
+// ModuleLevels mocks base method.
+func (m *MocklogLevels) ModuleLevels() map[string]logger.LogLevel {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModuleLevels")
+	ret0, _ := ret[0].(map[string]logger.LogLevel)
+	return ret0
+}
+
+// ModuleLevels indicates an expected call of ModuleLevels.
+func (mr *MocklogLevelsMockRecorder) ModuleLevels() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModuleLevels", reflect.TypeOf((*MocklogLevels)(nil).ModuleLevels))
+}
+
+// ResetModuleLevel mocks base method.
+func (m *MocklogLevels) ResetModuleLevel(module string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetModuleLevel", module)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetModuleLevel indicates an expected call of ResetModuleLevel.
+func (mr *MocklogLevelsMockRecorder) ResetModuleLevel(module interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetModuleLevel", reflect.TypeOf((*MocklogLevels)(nil).ResetModuleLevel), module)
+}
+
+// SetLevel mocks base method.
+func (m *MocklogLevels) SetLevel(level logger.LogLevel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLevel", level)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLevel indicates an expected call of SetLevel.
+func (mr *MocklogLevelsMockRecorder) SetLevel(level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLevel", reflect.TypeOf((*MocklogLevels)(nil).SetLevel), level)
+}
+
+// SetModuleLevel mocks base method.
+func (m *MocklogLevels) SetModuleLevel(module string, level logger.LogLevel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetModuleLevel", module, level)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetModuleLevel indicates an expected call of SetModuleLevel.
+func (mr *MocklogLevelsMockRecorder) SetModuleLevel(module, level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetModuleLevel", reflect.TypeOf((*MocklogLevels)(nil).SetModuleLevel), module, level)
+}
@@ -0,0 +1,65 @@
+package audit
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"courier-service/internal/handlers/utils"
+	"courier-service/internal/model"
+)
+
+type AuditController struct {
+	useCase auditUseCase
+	logger  logger
+}
+
+func NewAuditController(useCase auditUseCase, logger logger) *AuditController {
+	return &AuditController{useCase: useCase, logger: logger}
+}
+
+// ListAudit отдает журнал аудита; фильтры передаются в query: entity_type,
+// entity_id, from и to в RFC3339, limit.
+func (c *AuditController) ListAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := parseFilter(r.URL.Query())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, err := c.useCase.List(ctx, filter)
+	if err != nil {
+		handleListAuditError(w, c.logger.With(ctx), err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, ToAuditEntriesResponse(entries))
+}
+
+func parseFilter(query url.Values) (model.AuditFilter, error) {
+	filter := model.AuditFilter{
+		EntityType: model.AuditEntity(query.Get("entity_type")),
+		EntityID:   query.Get("entity_id"),
+	}
+	var err error
+	if filter.From, err = parseTime(query.Get("from")); err != nil {
+		return model.AuditFilter{}, errInvalidFrom
+	}
+	if filter.To, err = parseTime(query.Get("to")); err != nil {
+		return model.AuditFilter{}, errInvalidTo
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit <= 0 {
+			return model.AuditFilter{}, errInvalidLimit
+		}
+	}
+	return filter, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,134 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	audithandler "courier-service/internal/handlers/audit"
+	"courier-service/internal/model"
+	auditusecase "courier-service/internal/usecase/audit"
+	l "courier-service/pkg/logger/zap"
+)
+
+func TestAuditHandler_ListAudit(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	entry := model.AuditEntry{
+		ID:         7,
+		OccurredAt: from.Add(time.Hour),
+		Actor:      "admin",
+		Action:     model.AuditActionCourierUpdate,
+		EntityType: model.AuditEntityCourier,
+		EntityID:   "1",
+		Changes: map[string]model.AuditChange{
+			"status": {Before: "available", After: "inactive"},
+		},
+		RequestID: "req-1",
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		prepare        func(uc *MockauditUseCase)
+		wantStatusCode int
+		expectations   func(t *testing.T, rr *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "success: filters are passed to use case",
+			query: "?entity_type=courier&entity_id=1&from=2026-10-01T00:00:00Z&to=2026-10-18T00:00:00Z&limit=10",
+			prepare: func(uc *MockauditUseCase) {
+				uc.EXPECT().
+					List(gomock.Any(), model.AuditFilter{
+						EntityType: model.AuditEntityCourier,
+						EntityID:   "1",
+						From:       from,
+						To:         to,
+						Limit:      10,
+					}).
+					Return([]model.AuditEntry{entry}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var result []audithandler.AuditEntryResponseDTO
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+				require.Len(t, result, 1)
+				assert.Equal(t, "courier.update", result[0].Action)
+				assert.Equal(t, "admin", result[0].Actor)
+				assert.Equal(t, "inactive", result[0].Changes["status"].After)
+			},
+		},
+		{
+			name:  "success: empty journal",
+			query: "",
+			prepare: func(uc *MockauditUseCase) {
+				uc.EXPECT().List(gomock.Any(), model.AuditFilter{}).Return([]model.AuditEntry{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.JSONEq(t, "[]", rr.Body.String())
+			},
+		},
+		{
+			name:           "invalid from",
+			query:          "?from=yesterday",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid limit",
+			query:          "?limit=-1",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "unknown entity",
+			query: "?entity_type=order",
+			prepare: func(uc *MockauditUseCase) {
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, auditusecase.ErrUnknownEntity)
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "inverted time range",
+			query: "?from=2026-10-18T00:00:00Z&to=2026-10-01T00:00:00Z",
+			prepare: func(uc *MockauditUseCase) {
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, auditusecase.ErrInvalidTimeRange)
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "internal error",
+			query: "",
+			prepare: func(uc *MockauditUseCase) {
+				uc.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockUseCase := NewMockauditUseCase(ctrl)
+			if tc.prepare != nil {
+				tc.prepare(mockUseCase)
+			}
+
+			controller := audithandler.NewAuditController(mockUseCase, l.NewNop())
+			req := httptest.NewRequest(http.MethodGet, "/audit"+tc.query, nil)
+			rr := httptest.NewRecorder()
+
+			controller.ListAudit(rr, req)
+
+			assert.Equal(t, tc.wantStatusCode, rr.Code)
+			if tc.expectations != nil {
+				tc.expectations(t, rr)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
+package audit
+
+import (
+	"context"
+
+	"courier-service/internal/model"
+	l "courier-service/pkg/logger/zap"
+)
+
+type auditUseCase interface {
+	List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEntry, error)
+}
+
+type logger interface {
+	With(ctx context.Context) *l.Logger
+}
+
+type errorLogger interface {
+	Errorw(msg string, keysAndValues ...interface{})
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"time"
+
+	"courier-service/internal/model"
+)
+
+type AuditEntryResponseDTO struct {
+	ID         int64                        `json:"id"`
+	OccurredAt time.Time                    `json:"occurred_at"`
+	Actor      string                       `json:"actor"`
+	Action     string                       `json:"action"`
+	EntityType string                       `json:"entity_type"`
+	EntityID   string                       `json:"entity_id"`
+	Changes    map[string]model.AuditChange `json:"changes"`
+	RequestID  string                       `json:"request_id,omitempty"`
+}
+
+func ToAuditEntriesResponse(entries []model.AuditEntry) []AuditEntryResponseDTO {
+	response := make([]AuditEntryResponseDTO, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, AuditEntryResponseDTO{
+			ID:         entry.ID,
+			OccurredAt: entry.OccurredAt,
+			Actor:      entry.Actor,
+			Action:     string(entry.Action),
+			EntityType: string(entry.EntityType),
+			EntityID:   entry.EntityID,
+			Changes:    entry.Changes,
+			RequestID:  entry.RequestID,
+		})
+	}
+	return response
+}
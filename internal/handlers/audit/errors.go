@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"errors"
+	"net/http"
+
+	"courier-service/internal/handlers/utils"
+	auditusecase "courier-service/internal/usecase/audit"
+)
+
+const (
+	ErrUnknownEntity    = "Unknown entity type"
+	ErrEntityRequired   = "entity_type is required with entity_id"
+	ErrInvalidTimeRange = "from must not be after to"
+)
+
+var (
+	errInvalidFrom  = errors.New("from must be an RFC3339 timestamp")
+	errInvalidTo    = errors.New("to must be an RFC3339 timestamp")
+	errInvalidLimit = errors.New("limit must be a positive integer")
+)
+
+func handleListAuditError(w http.ResponseWriter, logger errorLogger, err error) {
+	switch err {
+	case auditusecase.ErrUnknownEntity:
+		utils.RespondWithError(w, http.StatusBadRequest, ErrUnknownEntity)
+	case auditusecase.ErrEntityRequired:
+		utils.RespondWithError(w, http.StatusBadRequest, ErrEntityRequired)
+	case auditusecase.ErrInvalidTimeRange:
+		utils.RespondWithError(w, http.StatusBadRequest, ErrInvalidTimeRange)
+	default:
+		utils.RespondUnhandledError(w, logger, err)
+	}
+}
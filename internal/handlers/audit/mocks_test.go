@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package audit_test is a generated GoMock package.
+package audit_test
+
+import (
+	context "context"
+	model "courier-service/internal/model"
+	logger "courier-service/pkg/logger/zap"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockauditUseCase is a mock of auditUseCase interface.
+type MockauditUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditUseCaseMockRecorder
+}
+
+// MockauditUseCaseMockRecorder is the mock recorder for MockauditUseCase.
+type MockauditUseCaseMockRecorder struct {
+	mock *MockauditUseCase
+}
+
+// NewMockauditUseCase creates a new mock instance.
+func NewMockauditUseCase(ctrl *gomock.Controller) *MockauditUseCase {
+	mock := &MockauditUseCase{ctrl: ctrl}
+	mock.recorder = &MockauditUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditUseCase) EXPECT() *MockauditUseCaseMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockauditUseCase) List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]model.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockauditUseCaseMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockauditUseCase)(nil).List), ctx, filter)
+}
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// With mocks base method.
+func (m *Mocklogger) With(ctx context.Context) *logger.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "With", ctx)
+	ret0, _ := ret[0].(*logger.Logger)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockloggerMockRecorder) With(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*Mocklogger)(nil).With), ctx)
+}
+
+// MockerrorLogger is a mock of errorLogger interface.
+type MockerrorLogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrorLoggerMockRecorder
+}
+
+// MockerrorLoggerMockRecorder is the mock recorder for MockerrorLogger.
+type MockerrorLoggerMockRecorder struct {
+	mock *MockerrorLogger
+}
+
+// NewMockerrorLogger creates a new mock instance.
+func NewMockerrorLogger(ctrl *gomock.Controller) *MockerrorLogger {
+	mock := &MockerrorLogger{ctrl: ctrl}
+	mock.recorder = &MockerrorLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrorLogger) EXPECT() *MockerrorLoggerMockRecorder {
+	return m.recorder
+}
+
+// Errorw mocks base method.
+func (m *MockerrorLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Errorw", varargs...)
+}
+
+// Errorw indicates an expected call of Errorw.
+func (mr *MockerrorLoggerMockRecorder) Errorw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorw", reflect.TypeOf((*MockerrorLogger)(nil).Errorw), varargs...)
+}
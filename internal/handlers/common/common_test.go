@@ -1,13 +1,17 @@
 package common_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"courier-service/internal/handlers/common"
+	"courier-service/pkg/health"
 )
 
 func TestPing(t *testing.T) {
@@ -32,3 +36,61 @@ func TestHealthcheck(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, response.Code)
 	assert.Empty(t, response.Body.String(), "Healthcheck should not return any body")
 }
+
+func TestLivez(t *testing.T) {
+	t.Parallel()
+	response := httptest.NewRecorder()
+
+	common.NewProbesController(nil).Livez(response, httptest.NewRequest("GET", "/livez", nil))
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, response.Body.String())
+}
+
+func TestReadyz(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		report         health.Report
+		wantStatusCode int
+	}{
+		{
+			name: "all dependencies available",
+			report: health.Report{
+				Status: health.StatusOK,
+				Checks: map[string]health.CheckResult{"postgres": {Status: health.StatusOK}},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "dependency unavailable",
+			report: health.Report{
+				Status: health.StatusFail,
+				Checks: map[string]health.CheckResult{
+					"postgres":      {Status: health.StatusOK},
+					"order_service": {Status: health.StatusFail, Error: "grpc connection is TRANSIENT_FAILURE"},
+				},
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			checker := NewMockreadinessChecker(ctrl)
+			checker.EXPECT().Check(gomock.Any()).Return(tt.report)
+
+			response := httptest.NewRecorder()
+			common.NewProbesController(checker).Readyz(response, httptest.NewRequest("GET", "/readyz", nil))
+
+			assert.Equal(t, tt.wantStatusCode, response.Code)
+			var report health.Report
+			require.NoError(t, json.Unmarshal(response.Body.Bytes(), &report))
+			assert.Equal(t, tt.report.Checks, report.Checks)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
+package common
+
+import (
+	"context"
+
+	"courier-service/pkg/health"
+)
+
+type readinessChecker interface {
+	Check(ctx context.Context) health.Report
+}
@@ -0,0 +1,31 @@
+package common
+
+import (
+	"net/http"
+
+	"courier-service/internal/handlers/utils"
+	"courier-service/pkg/health"
+)
+
+// ProbesController отвечает на пробы оркестратора: livez — процесс жив,
+// readyz — доступны зависимости и можно принимать трафик.
+type ProbesController struct {
+	checker readinessChecker
+}
+
+func NewProbesController(checker readinessChecker) *ProbesController {
+	return &ProbesController{checker: checker}
+}
+
+func (c *ProbesController) Livez(w http.ResponseWriter, _ *http.Request) {
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{"status": health.StatusOK})
+}
+
+func (c *ProbesController) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := c.checker.Check(r.Context())
+	if !report.Healthy() {
+		utils.RespondWithJSON(w, http.StatusServiceUnavailable, report)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"courier-service/internal/model"
+	l "courier-service/pkg/logger/zap"
 )
 
 type courierUseCase interface {
@@ -12,4 +13,16 @@ type courierUseCase interface {
 	GetAllCouriers(ctx context.Context) ([]model.Courier, error)
 	CreateCourier(ctx context.Context, courier model.Courier) (int64, error)
 	UpdateCourier(ctx context.Context, courier model.Courier) error
+	DeleteCourier(ctx context.Context, id int64) error
+	DeactivateCourier(ctx context.Context, id int64) error
+	ReactivateCourier(ctx context.Context, id int64) error
+	EraseCourier(ctx context.Context, id int64) error
+}
+
+type logger interface {
+	With(ctx context.Context) *l.Logger
+}
+
+type errorLogger interface {
+	Errorw(msg string, keysAndValues ...interface{})
 }
@@ -1,6 +1,7 @@
 package courier
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -11,23 +12,25 @@ import (
 	"courier-service/internal/handlers/utils"
 	"courier-service/internal/model"
 	usecase "courier-service/internal/usecase/courier"
+	l "courier-service/pkg/logger/zap"
 )
 
 type CourierController struct {
 	useCase courierUseCase
+	logger  logger
 }
 
-func NewCourierController(useCase courierUseCase) *CourierController {
-	return &CourierController{useCase: useCase}
+func NewCourierController(useCase courierUseCase, logger logger) *CourierController {
+	return &CourierController{useCase: useCase, logger: logger}
 }
 
 func (c *CourierController) GetCourierById(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
 	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
 	if err != nil {
 		utils.RespondWithError(w, http.StatusBadRequest, ErrInvalidID)
 		return
 	}
+	ctx := l.WithCourierID(r.Context(), id)
 
 	courier, err := c.useCase.GetCourierById(ctx, id)
 	if err != nil {
@@ -35,7 +38,7 @@ func (c *CourierController) GetCourierById(w http.ResponseWriter, r *http.Reques
 			utils.RespondWithError(w, http.StatusNotFound, ErrCourierNotFound)
 			return
 		}
-		utils.RespondInternalServerError(w, err)
+		utils.RespondInternalServerError(w, c.logger.With(ctx), err)
 		return
 	}
 	utils.RespondWithJSON(w, http.StatusOK, courier)
@@ -45,7 +48,7 @@ func (c *CourierController) GetAllCouriers(w http.ResponseWriter, r *http.Reques
 	ctx := r.Context()
 	couriers, err := c.useCase.GetAllCouriers(ctx)
 	if err != nil {
-		utils.RespondInternalServerError(w, err)
+		utils.RespondInternalServerError(w, c.logger.With(ctx), err)
 		return
 	}
 	utils.RespondWithJSON(w, http.StatusOK, couriers)
@@ -67,7 +70,7 @@ func (c *CourierController) CreateCourier(w http.ResponseWriter, r *http.Request
 	})
 
 	if err != nil {
-		handleCreateError(w, err)
+		handleCreateError(w, c.logger.With(ctx), err)
 		return
 	}
 
@@ -90,10 +93,11 @@ func (c *CourierController) UpdateCourier(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	ctx = l.WithCourierID(ctx, req.ID)
 	courier := req.ToModel()
 	err := c.useCase.UpdateCourier(ctx, courier)
 	if err != nil {
-		handleUpdateError(w, err)
+		handleUpdateError(w, c.logger.With(ctx), err)
 		return
 	}
 
@@ -101,3 +105,41 @@ func (c *CourierController) UpdateCourier(w http.ResponseWriter, r *http.Request
 		"message": "Courier updated successfully",
 	})
 }
+
+// DeleteCourier мягко удаляет курьера; история его доставок сохраняется.
+func (c *CourierController) DeleteCourier(w http.ResponseWriter, r *http.Request) {
+	c.changeCourier(w, r, c.useCase.DeleteCourier, "Courier deleted successfully")
+}
+
+func (c *CourierController) DeactivateCourier(w http.ResponseWriter, r *http.Request) {
+	c.changeCourier(w, r, c.useCase.DeactivateCourier, "Courier deactivated successfully")
+}
+
+func (c *CourierController) ReactivateCourier(w http.ResponseWriter, r *http.Request) {
+	c.changeCourier(w, r, c.useCase.ReactivateCourier, "Courier reactivated successfully")
+}
+
+// EraseCourier обезличивает имя и телефон курьера по запросу на удаление
+// персональных данных.
+func (c *CourierController) EraseCourier(w http.ResponseWriter, r *http.Request) {
+	c.changeCourier(w, r, c.useCase.EraseCourier, "Courier personal data erased successfully")
+}
+
+// changeCourier выполняет над курьером из пути операцию без тела запроса.
+func (c *CourierController) changeCourier(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, id int64) error, message string) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, ErrInvalidID)
+		return
+	}
+	ctx := l.WithCourierID(r.Context(), id)
+
+	if err := change(ctx, id); err != nil {
+		handleLifecycleError(w, c.logger.With(ctx), err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, map[string]string{
+		"message": message,
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"courier-service/internal/handlers/courier"
 	"courier-service/internal/model"
 	usecase "courier-service/internal/usecase/courier"
+	l "courier-service/pkg/logger/zap"
 )
 
 func TestCourierHandler_GetById(t *testing.T) {
@@ -102,7 +103,7 @@ func TestCourierHandler_GetById(t *testing.T) {
 				tc.prepare(mockUseCase)
 			}
 
-			controller := courier.NewCourierController(mockUseCase)
+			controller := courier.NewCourierController(mockUseCase, l.NewNop())
 
 			req := httptest.NewRequest(http.MethodGet, "/courier/"+tc.courierID, nil)
 
@@ -212,7 +213,7 @@ func TestCourierHandler_UpdateCourier(t *testing.T) {
 				tc.prepare(mockUseCase)
 			}
 
-			controller := courier.NewCourierController(mockUseCase)
+			controller := courier.NewCourierController(mockUseCase, l.NewNop())
 
 			body := strings.NewReader(tc.requestBody)
 			req := httptest.NewRequest(http.MethodPut, "/courier/"+tc.courierID, body)
@@ -231,3 +232,105 @@ func TestCourierHandler_UpdateCourier(t *testing.T) {
 		})
 	}
 }
+
+func TestCourierHandler_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	type handlerFn func(c *courier.CourierController) http.HandlerFunc
+
+	tests := []struct {
+		name           string
+		handler        handlerFn
+		courierID      string
+		prepare        func(courierUC *MockcourierUseCase)
+		wantStatusCode int
+		wantMessage    string
+	}{
+		{
+			name:      "delete: success",
+			handler:   func(c *courier.CourierController) http.HandlerFunc { return c.DeleteCourier },
+			courierID: "1",
+			prepare: func(courierUC *MockcourierUseCase) {
+				courierUC.EXPECT().DeleteCourier(gomock.Any(), int64(1)).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:      "delete: courier on delivery",
+			handler:   func(c *courier.CourierController) http.HandlerFunc { return c.DeleteCourier },
+			courierID: "1",
+			prepare: func(courierUC *MockcourierUseCase) {
+				courierUC.EXPECT().DeleteCourier(gomock.Any(), int64(1)).Return(usecase.ErrCourierOnDelivery)
+			},
+			wantStatusCode: http.StatusConflict,
+			wantMessage:    courier.ErrCourierOnDelivery,
+		},
+		{
+			name:           "delete: invalid id",
+			handler:        func(c *courier.CourierController) http.HandlerFunc { return c.DeleteCourier },
+			courierID:      "abc",
+			wantStatusCode: http.StatusBadRequest,
+			wantMessage:    courier.ErrInvalidID,
+		},
+		{
+			name:      "deactivate: not found",
+			handler:   func(c *courier.CourierController) http.HandlerFunc { return c.DeactivateCourier },
+			courierID: "999",
+			prepare: func(courierUC *MockcourierUseCase) {
+				courierUC.EXPECT().DeactivateCourier(gomock.Any(), int64(999)).Return(usecase.ErrCourierNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantMessage:    courier.ErrCourierNotFound,
+		},
+		{
+			name:      "reactivate: success",
+			handler:   func(c *courier.CourierController) http.HandlerFunc { return c.ReactivateCourier },
+			courierID: "1",
+			prepare: func(courierUC *MockcourierUseCase) {
+				courierUC.EXPECT().ReactivateCourier(gomock.Any(), int64(1)).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:      "erase: internal error",
+			handler:   func(c *courier.CourierController) http.HandlerFunc { return c.EraseCourier },
+			courierID: "1",
+			prepare: func(courierUC *MockcourierUseCase) {
+				courierUC.EXPECT().EraseCourier(gomock.Any(), int64(1)).Return(errors.New("database connection failed"))
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUseCase := NewMockcourierUseCase(ctrl)
+			if tc.prepare != nil {
+				tc.prepare(mockUseCase)
+			}
+
+			controller := courier.NewCourierController(mockUseCase, l.NewNop())
+
+			req := httptest.NewRequest(http.MethodPost, "/courier/"+tc.courierID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", tc.courierID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			tc.handler(controller)(rr, req)
+
+			assert.Equal(t, tc.wantStatusCode, rr.Code)
+			if tc.wantMessage != "" {
+				var result map[string]string
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+				assert.Equal(t, tc.wantMessage, result["error"])
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package courier
 
 import (
+	"errors"
 	"net/http"
 
 	"courier-service/internal/handlers/utils"
@@ -16,36 +17,51 @@ const (
 	ErrPhoneAlreadyExists    = "Phone number already exists"
 	ErrInternalServer        = "Internal server error"
 	ErrIDRequired            = "Id is required"
+	ErrCourierOnDelivery     = "Courier has an active delivery"
+	ErrInactiveStatusChange  = "Use /courier/{id}/deactivate or /courier/{id}/reactivate to change inactive status"
 )
 
-func handleCreateError(w http.ResponseWriter, err error) {
-	switch err {
-	case courier.ErrInvalidCreate:
+func handleCreateError(w http.ResponseWriter, logger errorLogger, err error) {
+	switch {
+	case errors.Is(err, courier.ErrInvalidCreate):
 		utils.RespondWithError(w, http.StatusBadRequest, ErrMissingRequiredFields)
-	case courier.ErrInvalidPhoneNumber:
+	case errors.Is(err, courier.ErrInvalidPhoneNumber):
 		utils.RespondWithError(w, http.StatusBadRequest, ErrInvalidPhoneNumber)
-	case courier.ErrUnknownTransportType:
+	case errors.Is(err, courier.ErrUnknownTransportType):
 		utils.RespondWithError(w, http.StatusBadRequest, ErrUnknownTransportType)
-	case courier.ErrPhoneNumberExists:
+	case errors.Is(err, courier.ErrPhoneNumberExists):
 		utils.RespondWithError(w, http.StatusConflict, ErrPhoneAlreadyExists)
 	default:
-		utils.RespondInternalServerError(w, err)
+		utils.RespondUnhandledError(w, logger, err)
 	}
 }
 
-func handleUpdateError(w http.ResponseWriter, err error) {
-	switch err {
-	case courier.ErrInvalidUpdate:
+func handleUpdateError(w http.ResponseWriter, logger errorLogger, err error) {
+	switch {
+	case errors.Is(err, courier.ErrInvalidUpdate):
 		utils.RespondWithError(w, http.StatusBadRequest, ErrMissingRequiredFields)
-	case courier.ErrInvalidPhoneNumber:
+	case errors.Is(err, courier.ErrInvalidPhoneNumber):
 		utils.RespondWithError(w, http.StatusBadRequest, ErrInvalidPhoneNumber)
-	case courier.ErrUnknownTransportType:
+	case errors.Is(err, courier.ErrUnknownTransportType):
 		utils.RespondWithError(w, http.StatusBadRequest, ErrUnknownTransportType)
-	case courier.ErrPhoneNumberExists:
+	case errors.Is(err, courier.ErrPhoneNumberExists):
 		utils.RespondWithError(w, http.StatusConflict, ErrPhoneAlreadyExists)
-	case courier.ErrCourierNotFound:
+	case errors.Is(err, courier.ErrCourierNotFound):
 		utils.RespondWithError(w, http.StatusNotFound, ErrCourierNotFound)
+	case errors.Is(err, courier.ErrInactiveStatusChange):
+		utils.RespondWithError(w, http.StatusConflict, ErrInactiveStatusChange)
 	default:
-		utils.RespondInternalServerError(w, err)
+		utils.RespondUnhandledError(w, logger, err)
+	}
+}
+
+func handleLifecycleError(w http.ResponseWriter, logger errorLogger, err error) {
+	switch {
+	case errors.Is(err, courier.ErrCourierNotFound):
+		utils.RespondWithError(w, http.StatusNotFound, ErrCourierNotFound)
+	case errors.Is(err, courier.ErrCourierOnDelivery):
+		utils.RespondWithError(w, http.StatusConflict, ErrCourierOnDelivery)
+	default:
+		utils.RespondUnhandledError(w, logger, err)
 	}
 }
@@ -7,6 +7,7 @@ package courier_test
 import (
 	context "context"
 	model "courier-service/internal/model"
+	logger "courier-service/pkg/logger/zap"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -50,6 +51,48 @@ func (mr *MockcourierUseCaseMockRecorder) CreateCourier(ctx, courier interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourier", reflect.TypeOf((*MockcourierUseCase)(nil).CreateCourier), ctx, courier)
 }
 
+// DeactivateCourier mocks base method.
+func (m *MockcourierUseCase) DeactivateCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateCourier indicates an expected call of DeactivateCourier.
+func (mr *MockcourierUseCaseMockRecorder) DeactivateCourier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateCourier", reflect.TypeOf((*MockcourierUseCase)(nil).DeactivateCourier), ctx, id)
+}
+
+// DeleteCourier mocks base method.
+func (m *MockcourierUseCase) DeleteCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourier indicates an expected call of DeleteCourier.
+func (mr *MockcourierUseCaseMockRecorder) DeleteCourier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourier", reflect.TypeOf((*MockcourierUseCase)(nil).DeleteCourier), ctx, id)
+}
+
+// EraseCourier mocks base method.
+func (m *MockcourierUseCase) EraseCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseCourier indicates an expected call of EraseCourier.
+func (mr *MockcourierUseCaseMockRecorder) EraseCourier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseCourier", reflect.TypeOf((*MockcourierUseCase)(nil).EraseCourier), ctx, id)
+}
+
 // GetAllCouriers mocks base method.
 func (m *MockcourierUseCase) GetAllCouriers(ctx context.Context) ([]model.Courier, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +123,20 @@ func (mr *MockcourierUseCaseMockRecorder) GetCourierById(ctx, id interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierById", reflect.TypeOf((*MockcourierUseCase)(nil).GetCourierById), ctx, id)
 }
 
+// ReactivateCourier mocks base method.
+func (m *MockcourierUseCase) ReactivateCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactivateCourier indicates an expected call of ReactivateCourier.
+func (mr *MockcourierUseCaseMockRecorder) ReactivateCourier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateCourier", reflect.TypeOf((*MockcourierUseCase)(nil).ReactivateCourier), ctx, id)
+}
+
 // UpdateCourier mocks base method.
 func (m *MockcourierUseCase) UpdateCourier(ctx context.Context, courier model.Courier) error {
 	m.ctrl.T.Helper()
@@ -93,3 +150,80 @@ func (mr *MockcourierUseCaseMockRecorder) UpdateCourier(ctx, courier interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourier", reflect.TypeOf((*MockcourierUseCase)(nil).UpdateCourier), ctx, courier)
 }
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// With mocks base method.
+func (m *Mocklogger) With(ctx context.Context) *logger.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "With", ctx)
+	ret0, _ := ret[0].(*logger.Logger)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockloggerMockRecorder) With(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*Mocklogger)(nil).With), ctx)
+}
+
+// MockerrorLogger is a mock of errorLogger interface.
+type MockerrorLogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrorLoggerMockRecorder
+}
+
+// MockerrorLoggerMockRecorder is the mock recorder for MockerrorLogger.
+type MockerrorLoggerMockRecorder struct {
+	mock *MockerrorLogger
+}
+
+// NewMockerrorLogger creates a new mock instance.
+func NewMockerrorLogger(ctrl *gomock.Controller) *MockerrorLogger {
+	mock := &MockerrorLogger{ctrl: ctrl}
+	mock.recorder = &MockerrorLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrorLogger) EXPECT() *MockerrorLoggerMockRecorder {
+	return m.recorder
+}
+
+// Errorw mocks base method.
+func (m *MockerrorLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Errorw", varargs...)
+}
+
+// Errorw indicates an expected call of Errorw.
+func (mr *MockerrorLoggerMockRecorder) Errorw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorw", reflect.TypeOf((*MockerrorLogger)(nil).Errorw), varargs...)
+}
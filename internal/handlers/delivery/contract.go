@@ -4,7 +4,9 @@ package delivery
 import (
 	"context"
 
+	"courier-service/internal/model"
 	assign "courier-service/internal/usecase/delivery/assign"
+	l "courier-service/pkg/logger/zap"
 )
 
 type assignUsecase interface {
@@ -14,3 +16,15 @@ type assignUsecase interface {
 type unassignUsecase interface {
 	Unassign(context.Context, string) (int64, error)
 }
+
+type getUsecase interface {
+	Get(context.Context, string) (model.Delivery, error)
+}
+
+type logger interface {
+	With(ctx context.Context) *l.Logger
+}
+
+type errorLogger interface {
+	Errorw(msg string, keysAndValues ...interface{})
+}
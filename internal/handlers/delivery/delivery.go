@@ -4,16 +4,38 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+
 	"courier-service/internal/handlers/utils"
+	l "courier-service/pkg/logger/zap"
 )
 
 type DeliveryController struct {
 	assign   assignUsecase
 	unassign unassignUsecase
+	get      getUsecase
+	logger   logger
+}
+
+func NewDeliveryController(
+	assign assignUsecase,
+	unassign unassignUsecase,
+	get getUsecase,
+	logger logger,
+) *DeliveryController {
+	return &DeliveryController{assign: assign, unassign: unassign, get: get, logger: logger}
 }
 
-func NewDeliveryController(assign assignUsecase, unassign unassignUsecase) *DeliveryController {
-	return &DeliveryController{assign: assign, unassign: unassign}
+func (c *DeliveryController) GetDelivery(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "order_id")
+	ctx := l.WithOrderID(r.Context(), orderID)
+
+	delivery, err := c.get.Get(ctx, orderID)
+	if err != nil {
+		handleGetDeliveryError(w, c.logger.With(ctx), err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, ToDeliveryResponse(delivery))
 }
 
 func (c *DeliveryController) AssignDelivery(w http.ResponseWriter, r *http.Request) {
@@ -24,11 +46,12 @@ func (c *DeliveryController) AssignDelivery(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	ctx = l.WithOrderID(ctx, req.OrderID)
 	assignment, err := c.assign.Assign(ctx, req.OrderID)
 	response := ToAssignCourierResponse(assignment)
 
 	if err != nil {
-		handleAssignDeliveryError(w, err)
+		handleAssignDeliveryError(w, c.logger.With(ctx), err)
 		return
 	}
 	utils.RespondWithJSON(w, http.StatusOK, response)
@@ -42,11 +65,12 @@ func (c *DeliveryController) UnassignDelivery(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	ctx = l.WithOrderID(ctx, req.OrderID)
 	courierID, err := c.unassign.Unassign(ctx, req.OrderID)
 	response := ToUnassignCourierResponse(courierID, req.OrderID)
 
 	if err != nil {
-		handleUnassignDeliveryError(w, err)
+		handleUnassignDeliveryError(w, c.logger.With(ctx), err)
 		return
 	}
 	utils.RespondWithJSON(w, http.StatusOK, response)
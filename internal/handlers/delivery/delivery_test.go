@@ -2,18 +2,26 @@ package delivery_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	deliveryhandler "courier-service/internal/handlers/delivery"
+	"courier-service/internal/model"
 	assignusecase "courier-service/internal/usecase/delivery/assign"
+	getusecase "courier-service/internal/usecase/delivery/get"
 	unassignusecase "courier-service/internal/usecase/delivery/unassign"
+	"courier-service/pkg/database/pgerrors"
+	l "courier-service/pkg/logger/zap"
 )
 
 func TestDeliveryHandler_AssignDelivery(t *testing.T) {
@@ -129,6 +137,42 @@ func TestDeliveryHandler_AssignDelivery(t *testing.T) {
 			},
 			wantStatusCode: http.StatusInternalServerError,
 		},
+		{
+			name: "transaction conflict after retries",
+			requestBody: func() []byte {
+				reqBody := deliveryhandler.DeliveryAssignRequestDTO{
+					OrderID: "550e8400-e29b-41d4-a716-446655440000",
+				}
+				b, _ := json.Marshal(reqBody)
+				return b
+			}(),
+			prepare: func(uc *MockassignUsecase) {
+				uc.EXPECT().
+					Assign(gomock.Any(), gomock.Any()).
+					Return(assignusecase.DeliveryAssignResponse{}, fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40001"}))
+			},
+			wantStatusCode: http.StatusServiceUnavailable,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+			},
+		},
+		{
+			name: "foreign key violation",
+			requestBody: func() []byte {
+				reqBody := deliveryhandler.DeliveryAssignRequestDTO{
+					OrderID: "550e8400-e29b-41d4-a716-446655440000",
+				}
+				b, _ := json.Marshal(reqBody)
+				return b
+			}(),
+			prepare: func(uc *MockassignUsecase) {
+				err := pgerrors.Translate(&pgconn.PgError{Code: "23503", ConstraintName: "delivery_courier_id_fkey"}, nil)
+				uc.EXPECT().
+					Assign(gomock.Any(), gomock.Any()).
+					Return(assignusecase.DeliveryAssignResponse{}, err)
+			},
+			wantStatusCode: http.StatusConflict,
+		},
 	}
 
 	for _, tt := range tests {
@@ -141,7 +185,7 @@ func TestDeliveryHandler_AssignDelivery(t *testing.T) {
 				tt.prepare(mockAssignUsecase)
 			}
 
-			controller := deliveryhandler.NewDeliveryController(mockAssignUsecase, nil)
+			controller := deliveryhandler.NewDeliveryController(mockAssignUsecase, nil, nil, l.NewNop())
 
 			req := httptest.NewRequest(http.MethodPost, "/delivery/assign", bytes.NewReader(tt.requestBody))
 			rr := httptest.NewRecorder()
@@ -263,7 +307,7 @@ func TestDeliveryHandler_UnassignDelivery(t *testing.T) {
 				tt.prepare(mockUnassignUsecase)
 			}
 
-			controller := deliveryhandler.NewDeliveryController(nil, mockUnassignUsecase)
+			controller := deliveryhandler.NewDeliveryController(nil, mockUnassignUsecase, nil, l.NewNop())
 
 			req := httptest.NewRequest(http.MethodPost, "/delivery/unassign", bytes.NewReader(tt.requestBody))
 			rr := httptest.NewRecorder()
@@ -278,3 +322,118 @@ func TestDeliveryHandler_UnassignDelivery(t *testing.T) {
 		})
 	}
 }
+
+func TestDeliveryHandler_GetDelivery(t *testing.T) {
+	type expectationsFn func(t *testing.T, rr *httptest.ResponseRecorder)
+
+	tests := []struct {
+		name           string
+		orderID        string
+		prepare        func(uc *MockgetUsecase)
+		wantStatusCode int
+		expectations   expectationsFn
+	}{
+		{
+			name:    "success: delivery with order snapshot",
+			orderID: "550e8400-e29b-41d4-a716-446655440000",
+			prepare: func(uc *MockgetUsecase) {
+				uc.EXPECT().
+					Get(gomock.Any(), "550e8400-e29b-41d4-a716-446655440000").
+					Return(model.Delivery{
+						ID:        1,
+						CourierID: 2,
+						OrderID:   "550e8400-e29b-41d4-a716-446655440000",
+						Order: &model.Order{
+							ID:         "550e8400-e29b-41d4-a716-446655440000",
+							Items:      []model.OrderItem{{Name: "pizza", Price: 700, Quantity: 2}},
+							TotalPrice: 1400,
+							Address:    model.OrderAddress{Street: "Tverskaya", House: "1"},
+						},
+					}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				var result deliveryhandler.DeliveryResponseDTO
+				err := json.Unmarshal(rr.Body.Bytes(), &result)
+				require.NoError(t, err)
+
+				assert.Equal(t, int64(2), result.CourierID)
+				require.NotNil(t, result.Order)
+				assert.Equal(t, int64(1400), result.Order.TotalPrice)
+				assert.Equal(t, "Tverskaya", result.Order.Address.Street)
+				assert.Len(t, result.Order.Items, 1)
+			},
+		},
+		{
+			name:    "success: delivery without snapshot",
+			orderID: "550e8400-e29b-41d4-a716-446655440001",
+			prepare: func(uc *MockgetUsecase) {
+				uc.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(model.Delivery{ID: 1, CourierID: 2, OrderID: "550e8400-e29b-41d4-a716-446655440001"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				assert.NotContains(t, rr.Body.String(), `"order"`)
+			},
+		},
+		{
+			name:    "order id not found",
+			orderID: "550e8400-e29b-41d4-a716-446655440000",
+			prepare: func(uc *MockgetUsecase) {
+				uc.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(model.Delivery{}, getusecase.ErrOrderIDNotFound)
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:    "wrapped order id not found",
+			orderID: "550e8400-e29b-41d4-a716-446655440000",
+			prepare: func(uc *MockgetUsecase) {
+				uc.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(model.Delivery{}, fmt.Errorf("get delivery: %w", getusecase.ErrOrderIDNotFound))
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:    "internal error",
+			orderID: "550e8400-e29b-41d4-a716-446655440000",
+			prepare: func(uc *MockgetUsecase) {
+				uc.EXPECT().
+					Get(gomock.Any(), gomock.Any()).
+					Return(model.Delivery{}, assert.AnError)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockGetUsecase := NewMockgetUsecase(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(mockGetUsecase)
+			}
+
+			controller := deliveryhandler.NewDeliveryController(nil, nil, mockGetUsecase, l.NewNop())
+
+			req := httptest.NewRequest(http.MethodGet, "/delivery/"+tt.orderID, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("order_id", tt.orderID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rr := httptest.NewRecorder()
+
+			controller.GetDelivery(rr, req)
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+
+			if tt.expectations != nil {
+				tt.expectations(t, rr)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package delivery
 import (
 	"time"
 
+	"courier-service/internal/model"
 	assign "courier-service/internal/usecase/delivery/assign"
 )
 
@@ -23,6 +24,42 @@ type DeliveryAssignResponseDTO struct {
 	OrderID       string    `json:"order_id"`
 	TransportType string    `json:"transport_type"`
 	Deadline      time.Time `json:"delivery_deadline"`
+	Order         *OrderDTO `json:"order,omitempty"`
+}
+
+type DeliveryResponseDTO struct {
+	ID         int64     `json:"id"`
+	CourierID  int64     `json:"courier_id"`
+	OrderID    string    `json:"order_id"`
+	AssignedAt time.Time `json:"assigned_at"`
+	Deadline   time.Time `json:"delivery_deadline"`
+	Order      *OrderDTO `json:"order,omitempty"`
+}
+
+type OrderDTO struct {
+	ID                string          `json:"id"`
+	OrderNumber       string          `json:"order_number"`
+	RestaurantID      string          `json:"restaurant_id"`
+	Items             []OrderItemDTO  `json:"items"`
+	TotalPrice        int64           `json:"total_price"`
+	Address           OrderAddressDTO `json:"address"`
+	Status            string          `json:"status"`
+	CreatedAt         time.Time       `json:"created_at"`
+	EstimatedDelivery time.Time       `json:"estimated_delivery"`
+}
+
+type OrderItemDTO struct {
+	Name     string `json:"name"`
+	Price    int64  `json:"price"`
+	Quantity int64  `json:"quantity"`
+}
+
+type OrderAddressDTO struct {
+	Street    string `json:"street"`
+	House     string `json:"house"`
+	Apartment string `json:"apartment"`
+	Floor     string `json:"floor"`
+	Comment   string `json:"comment"`
 }
 
 type DeliveryUnassignResponseDTO struct {
@@ -45,5 +82,40 @@ func ToAssignCourierResponse(delivery assign.DeliveryAssignResponse) DeliveryAss
 		OrderID:       delivery.OrderID,
 		TransportType: delivery.TransportType,
 		Deadline:      delivery.Deadline,
+		Order:         toOrderDTO(delivery.Order),
+	}
+}
+
+func ToDeliveryResponse(delivery model.Delivery) DeliveryResponseDTO {
+	return DeliveryResponseDTO{
+		ID:         delivery.ID,
+		CourierID:  delivery.CourierID,
+		OrderID:    delivery.OrderID,
+		AssignedAt: delivery.AssignedAt,
+		Deadline:   delivery.Deadline,
+		Order:      toOrderDTO(delivery.Order),
+	}
+}
+
+func toOrderDTO(order *model.Order) *OrderDTO {
+	if order == nil {
+		return nil
+	}
+
+	items := make([]OrderItemDTO, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, OrderItemDTO(item))
+	}
+
+	return &OrderDTO{
+		ID:                order.ID,
+		OrderNumber:       order.OrderNumber,
+		RestaurantID:      order.RestaurantID,
+		Items:             items,
+		TotalPrice:        order.TotalPrice,
+		Address:           OrderAddressDTO(order.Address),
+		Status:            string(order.Status),
+		CreatedAt:         order.CreatedAt,
+		EstimatedDelivery: order.EstimatedDelivery,
 	}
 }
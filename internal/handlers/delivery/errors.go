@@ -1,10 +1,12 @@
 package delivery
 
 import (
+	"errors"
 	"net/http"
 
 	"courier-service/internal/handlers/utils"
 	assign "courier-service/internal/usecase/delivery/assign"
+	get "courier-service/internal/usecase/delivery/get"
 	unassign "courier-service/internal/usecase/delivery/unassign"
 )
 
@@ -23,26 +25,37 @@ const (
 	ErrOrderIDNotFound       = "Order id not found"
 )
 
-func handleAssignDeliveryError(w http.ResponseWriter, err error) {
-	switch err {
-	case assign.ErrCouriersBusy:
+func handleAssignDeliveryError(w http.ResponseWriter, logger errorLogger, err error) {
+	switch {
+	case errors.Is(err, assign.ErrCouriersBusy):
 		utils.RespondWithError(w, http.StatusConflict, ErrCouriersBusy)
-	case assign.ErrNoOrderID:
+	case errors.Is(err, assign.ErrNoOrderID):
 		utils.RespondWithError(w, http.StatusBadRequest, ErrMissingRequiredFields)
-	case assign.ErrOrderIDExists:
+	case errors.Is(err, assign.ErrOrderIDExists):
 		utils.RespondWithError(w, http.StatusConflict, ErrOrderIDExists)
 	default:
-		utils.RespondInternalServerError(w, err)
+		utils.RespondUnhandledError(w, logger, err)
 	}
 }
 
-func handleUnassignDeliveryError(w http.ResponseWriter, err error) {
-	switch err {
-	case unassign.ErrNoOrderID:
+func handleUnassignDeliveryError(w http.ResponseWriter, logger errorLogger, err error) {
+	switch {
+	case errors.Is(err, unassign.ErrNoOrderID):
 		utils.RespondWithError(w, http.StatusBadRequest, ErrMissingRequiredFields)
-	case unassign.ErrOrderIDNotFound:
+	case errors.Is(err, unassign.ErrOrderIDNotFound):
 		utils.RespondWithError(w, http.StatusNotFound, ErrOrderIDNotFound)
 	default:
-		utils.RespondInternalServerError(w, err)
+		utils.RespondUnhandledError(w, logger, err)
+	}
+}
+
+func handleGetDeliveryError(w http.ResponseWriter, logger errorLogger, err error) {
+	switch {
+	case errors.Is(err, get.ErrNoOrderID):
+		utils.RespondWithError(w, http.StatusBadRequest, ErrMissingRequiredFields)
+	case errors.Is(err, get.ErrOrderIDNotFound):
+		utils.RespondWithError(w, http.StatusNotFound, ErrOrderIDNotFound)
+	default:
+		utils.RespondUnhandledError(w, logger, err)
 	}
 }
@@ -6,7 +6,9 @@ package delivery_test
 
 import (
 	context "context"
+	model "courier-service/internal/model"
 	assign "courier-service/internal/usecase/delivery/assign"
+	logger "courier-service/pkg/logger/zap"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -87,3 +89,118 @@ func (mr *MockunassignUsecaseMockRecorder) Unassign(arg0, arg1 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unassign", reflect.TypeOf((*MockunassignUsecase)(nil).Unassign), arg0, arg1)
 }
+
+// MockgetUsecase is a mock of getUsecase interface.
+type MockgetUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetUsecaseMockRecorder
+}
+
+// MockgetUsecaseMockRecorder is the mock recorder for MockgetUsecase.
+type MockgetUsecaseMockRecorder struct {
+	mock *MockgetUsecase
+}
+
+// NewMockgetUsecase creates a new mock instance.
+func NewMockgetUsecase(ctrl *gomock.Controller) *MockgetUsecase {
+	mock := &MockgetUsecase{ctrl: ctrl}
+	mock.recorder = &MockgetUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetUsecase) EXPECT() *MockgetUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockgetUsecase) Get(arg0 context.Context, arg1 string) (model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockgetUsecaseMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockgetUsecase)(nil).Get), arg0, arg1)
+}
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// With mocks base method.
+func (m *Mocklogger) With(ctx context.Context) *logger.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "With", ctx)
+	ret0, _ := ret[0].(*logger.Logger)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockloggerMockRecorder) With(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*Mocklogger)(nil).With), ctx)
+}
+
+// MockerrorLogger is a mock of errorLogger interface.
+type MockerrorLogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrorLoggerMockRecorder
+}
+
+// MockerrorLoggerMockRecorder is the mock recorder for MockerrorLogger.
+type MockerrorLoggerMockRecorder struct {
+	mock *MockerrorLogger
+}
+
+// NewMockerrorLogger creates a new mock instance.
+func NewMockerrorLogger(ctrl *gomock.Controller) *MockerrorLogger {
+	mock := &MockerrorLogger{ctrl: ctrl}
+	mock.recorder = &MockerrorLoggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrorLogger) EXPECT() *MockerrorLoggerMockRecorder {
+	return m.recorder
+}
+
+// Errorw mocks base method.
+func (m *MockerrorLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{msg}
+	for _, a := range keysAndValues {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "Errorw", varargs...)
+}
+
+// Errorw indicates an expected call of Errorw.
+func (mr *MockerrorLoggerMockRecorder) Errorw(msg interface{}, keysAndValues ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{msg}, keysAndValues...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errorw", reflect.TypeOf((*MockerrorLogger)(nil).Errorw), varargs...)
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"courier-service/pkg/actor"
+)
+
+// ActorMiddleware кладет в контекст пользователя из заголовка X-Auth-User
+// для журнала аудита; запросы без заголовка записываются от Anonymous.
+func ActorMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := r.Header.Get(actor.Header)
+			if !actor.Valid(user) {
+				user = actor.Anonymous
+			}
+			next.ServeHTTP(w, r.WithContext(actor.NewContext(r.Context(), user)))
+		})
+	}
+}
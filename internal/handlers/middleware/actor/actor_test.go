@@ -0,0 +1,43 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	actormiddleware "courier-service/internal/handlers/middleware/actor"
+	"courier-service/pkg/actor"
+)
+
+func TestActorMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "user from header", header: "dispatcher@example.com", want: "dispatcher@example.com"},
+		{name: "missing header", header: "", want: actor.Anonymous},
+		{name: "too long header", header: strings.Repeat("a", 300), want: actor.Anonymous},
+		{name: "header with control characters", header: "evil\x01user", want: actor.Anonymous},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got string
+			handler := actormiddleware.ActorMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = actor.FromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/courier", nil)
+			if tc.header != "" {
+				req.Header.Set(actor.Header, tc.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"courier-service/internal/model"
+	l "courier-service/pkg/logger/zap"
+)
+
+type idempotencyStore interface {
+	ReserveKey(ctx context.Context, key model.IdempotencyKey, lease time.Duration) error
+	GetKey(ctx context.Context, key string) (model.IdempotencyKey, error)
+	CompleteKey(ctx context.Context, key model.IdempotencyKey) error
+	DeleteKey(ctx context.Context, key string) error
+}
+
+type logger interface {
+	Debug(args ...interface{})
+	Debugf(format string, args ...interface{})
+	Debugw(msg string, keysAndValues ...interface{})
+
+	Info(args ...interface{})
+	Infof(format string, args ...interface{})
+
+	Warn(args ...interface{})
+	Warnf(format string, args ...interface{})
+
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+
+	Fatal(args ...interface{})
+	Fatalf(format string, args ...interface{})
+
+	With(ctx context.Context) *l.Logger
+}
@@ -0,0 +1,9 @@
+package middleware
+
+const (
+	ErrKeyTooLong          = "Idempotency key is too long"
+	ErrRequestTooLarge     = "Request body is too large"
+	ErrKeyReused           = "Idempotency key was already used with a different request"
+	ErrRequestInProgress   = "Request with this idempotency key is still in progress"
+	ErrFailedToReadRequest = "Failed to read request body"
+)
@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"courier-service/internal/handlers/utils"
+	"courier-service/internal/model"
+	idempotencyrepo "courier-service/internal/repository/idempotency"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxKeyLength       = 255
+	maxRequestBodySize = 1 << 20
+)
+
+// IdempotencyMiddleware сохраняет ответ на мутирующий запрос с заголовком
+// Idempotency-Key и отдает его повторно на ретраи клиента с тем же ключом.
+// Ответы 5xx не сохраняются, чтобы клиент мог повторить запрос. Пока запрос
+// выполняется, ключ занят на lease: если процесс упадет, не освободив ключ,
+// после lease ретрай займет его заново. Поэтому lease должен быть больше
+// времени выполнения запроса.
+func IdempotencyMiddleware(
+	store idempotencyStore,
+	ttl time.Duration,
+	lease time.Duration,
+	logger logger,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderIdempotencyKey)
+			if key == "" || !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxKeyLength {
+				utils.RespondWithError(w, http.StatusBadRequest, ErrKeyTooLong)
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodySize+1))
+			if err != nil {
+				utils.RespondWithError(w, http.StatusBadRequest, ErrFailedToReadRequest)
+				return
+			}
+			if len(body) > maxRequestBodySize {
+				utils.RespondWithError(w, http.StatusRequestEntityTooLarge, ErrRequestTooLarge)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := r.Context()
+			hash := requestHash(r.Method, r.URL.Path, body)
+
+			err = store.ReserveKey(ctx, model.IdempotencyKey{
+				Key:         key,
+				RequestHash: hash,
+				ExpiresAt:   time.Now().Add(ttl),
+			}, lease)
+			if errors.Is(err, idempotencyrepo.ErrKeyAlreadyExists) {
+				replay(ctx, w, store, key, hash, logger)
+				return
+			}
+			if err != nil {
+				utils.RespondInternalServerError(w, logger.With(ctx), fmt.Errorf("reserve idempotency key %s: %w", key, err))
+				return
+			}
+
+			// клиент мог отвалиться, но результат запроса все равно нужно сохранить
+			storeCtx := context.WithoutCancel(ctx)
+			defer func() {
+				if p := recover(); p != nil {
+					releaseKey(storeCtx, store, key, logger)
+					panic(p)
+				}
+			}()
+
+			rec := &bodyRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError {
+				releaseKey(storeCtx, store, key, logger)
+				return
+			}
+
+			err = store.CompleteKey(storeCtx, model.IdempotencyKey{
+				Key:          key,
+				StatusCode:   rec.status,
+				ContentType:  rec.Header().Get("Content-Type"),
+				ResponseBody: rec.body.Bytes(),
+			})
+			if err != nil {
+				logger.Errorf("Failed to save response for idempotency key %s: %v", key, err)
+			}
+		})
+	}
+}
+
+// releaseKey освобождает ключ, чтобы клиент мог повторить запрос сразу,
+// не дожидаясь конца аренды.
+func releaseKey(ctx context.Context, store idempotencyStore, key string, logger logger) {
+	if err := store.DeleteKey(ctx, key); err != nil {
+		logger.Errorf("Failed to release idempotency key %s: %v", key, err)
+	}
+}
+
+func replay(
+	ctx context.Context,
+	w http.ResponseWriter,
+	store idempotencyStore,
+	key string,
+	hash string,
+	logger logger,
+) {
+	stored, err := store.GetKey(ctx, key)
+	if errors.Is(err, idempotencyrepo.ErrKeyNotFound) {
+		// ключ освободили между резервированием и чтением — пусть клиент повторит
+		utils.RespondWithError(w, http.StatusConflict, ErrRequestInProgress)
+		return
+	}
+	if err != nil {
+		utils.RespondInternalServerError(w, logger.With(ctx), fmt.Errorf("get idempotency key %s: %w", key, err))
+		return
+	}
+
+	if stored.RequestHash != hash {
+		utils.RespondWithError(w, http.StatusUnprocessableEntity, ErrKeyReused)
+		return
+	}
+	if !stored.Completed() {
+		utils.RespondWithError(w, http.StatusConflict, ErrRequestInProgress)
+		return
+	}
+
+	logger.Debugf("Replaying stored response for idempotency key %s", key)
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	w.Header().Set(HeaderIdempotentReplayed, "true")
+	w.WriteHeader(stored.StatusCode)
+	if _, err := w.Write(stored.ResponseBody); err != nil {
+		logger.Warnf("Failed to write replayed response for idempotency key %s: %v", key, err)
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func requestHash(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type bodyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *bodyRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
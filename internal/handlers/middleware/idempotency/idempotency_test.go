@@ -0,0 +1,258 @@
+package middleware_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	middleware "courier-service/internal/handlers/middleware/idempotency"
+	"courier-service/internal/model"
+	idempotencyrepo "courier-service/internal/repository/idempotency"
+	l "courier-service/pkg/logger/zap"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	type expectationsFn func(t *testing.T, rr *httptest.ResponseRecorder, handlerCalls int)
+
+	const key = "c6f1e0b2-4a5d-4e0b-9a57-3f8f3b1f2a10"
+	body := []byte(`{"order_id":"550e8400-e29b-41d4-a716-446655440000"}`)
+
+	// первый прогон сохраняет хэш запроса, чтобы остальные кейсы могли его переиспользовать
+	var storedHash string
+
+	tests := []struct {
+		name           string
+		method         string
+		key            string
+		body           []byte
+		handlerStatus  int
+		prepare        func(store *MockidempotencyStore)
+		wantStatusCode int
+		expectations   expectationsFn
+	}{
+		{
+			name:          "no key passes through",
+			method:        http.MethodPost,
+			body:          body,
+			handlerStatus: http.StatusOK,
+			prepare:       nil, // хранилище не вызывается
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder, handlerCalls int) {
+				assert.Equal(t, 1, handlerCalls)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:          "safe method passes through",
+			method:        http.MethodGet,
+			key:           key,
+			handlerStatus: http.StatusOK,
+			prepare:       nil,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder, handlerCalls int) {
+				assert.Equal(t, 1, handlerCalls)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:          "first request is executed and stored",
+			method:        http.MethodPost,
+			key:           key,
+			body:          body,
+			handlerStatus: http.StatusCreated,
+			prepare: func(store *MockidempotencyStore) {
+				store.EXPECT().
+					ReserveKey(gomock.Any(), gomock.Any(), 30*time.Second).
+					DoAndReturn(func(_ interface{}, k model.IdempotencyKey, _ time.Duration) error {
+						storedHash = k.RequestHash
+						return nil
+					})
+				store.EXPECT().
+					CompleteKey(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, k model.IdempotencyKey) error {
+						assert.Equal(t, http.StatusCreated, k.StatusCode)
+						assert.Equal(t, "application/json", k.ContentType)
+						assert.JSONEq(t, `{"id":"1"}`, string(k.ResponseBody))
+						return nil
+					})
+			},
+			wantStatusCode: http.StatusCreated,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder, handlerCalls int) {
+				assert.Equal(t, 1, handlerCalls)
+				assert.NotEmpty(t, storedHash)
+			},
+		},
+		{
+			name:          "repeated request is replayed",
+			method:        http.MethodPost,
+			key:           key,
+			body:          body,
+			handlerStatus: http.StatusCreated,
+			prepare: func(store *MockidempotencyStore) {
+				store.EXPECT().
+					ReserveKey(gomock.Any(), gomock.Any(), 30*time.Second).
+					Return(idempotencyrepo.ErrKeyAlreadyExists)
+				store.EXPECT().
+					GetKey(gomock.Any(), key).
+					Return(model.IdempotencyKey{
+						Key:          key,
+						RequestHash:  storedHash,
+						StatusCode:   http.StatusCreated,
+						ContentType:  "application/json",
+						ResponseBody: []byte(`{"id":"1"}`),
+					}, nil)
+			},
+			wantStatusCode: http.StatusCreated,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder, handlerCalls int) {
+				assert.Equal(t, 0, handlerCalls)
+				assert.Equal(t, "true", rr.Header().Get(middleware.HeaderIdempotentReplayed))
+				assert.JSONEq(t, `{"id":"1"}`, rr.Body.String())
+			},
+		},
+		{
+			name:          "key reused with different body",
+			method:        http.MethodPost,
+			key:           key,
+			body:          []byte(`{"order_id":"another"}`),
+			handlerStatus: http.StatusCreated,
+			prepare: func(store *MockidempotencyStore) {
+				store.EXPECT().
+					ReserveKey(gomock.Any(), gomock.Any(), 30*time.Second).
+					Return(idempotencyrepo.ErrKeyAlreadyExists)
+				store.EXPECT().
+					GetKey(gomock.Any(), key).
+					Return(model.IdempotencyKey{Key: key, RequestHash: storedHash, StatusCode: http.StatusCreated}, nil)
+			},
+			wantStatusCode: http.StatusUnprocessableEntity,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder, handlerCalls int) {
+				assert.Equal(t, 0, handlerCalls)
+			},
+		},
+		{
+			name:          "request still in progress",
+			method:        http.MethodPost,
+			key:           key,
+			body:          body,
+			handlerStatus: http.StatusCreated,
+			prepare: func(store *MockidempotencyStore) {
+				store.EXPECT().
+					ReserveKey(gomock.Any(), gomock.Any(), 30*time.Second).
+					Return(idempotencyrepo.ErrKeyAlreadyExists)
+				store.EXPECT().
+					GetKey(gomock.Any(), key).
+					Return(model.IdempotencyKey{Key: key, RequestHash: storedHash}, nil)
+			},
+			wantStatusCode: http.StatusConflict,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder, handlerCalls int) {
+				assert.Equal(t, 0, handlerCalls)
+			},
+		},
+		{
+			name:          "server error releases key",
+			method:        http.MethodPost,
+			key:           key,
+			body:          body,
+			handlerStatus: http.StatusInternalServerError,
+			prepare: func(store *MockidempotencyStore) {
+				store.EXPECT().
+					ReserveKey(gomock.Any(), gomock.Any(), 30*time.Second).
+					Return(nil)
+				store.EXPECT().
+					DeleteKey(gomock.Any(), key).
+					Return(nil)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder, handlerCalls int) {
+				assert.Equal(t, 1, handlerCalls)
+			},
+		},
+		{
+			name:          "reserve fails",
+			method:        http.MethodPost,
+			key:           key,
+			body:          body,
+			handlerStatus: http.StatusCreated,
+			prepare: func(store *MockidempotencyStore) {
+				store.EXPECT().
+					ReserveKey(gomock.Any(), gomock.Any(), 30*time.Second).
+					Return(assert.AnError)
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			expectations: func(t *testing.T, rr *httptest.ResponseRecorder, handlerCalls int) {
+				assert.Equal(t, 0, handlerCalls)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := NewMockidempotencyStore(ctrl)
+			if tt.prepare != nil {
+				tt.prepare(mockStore)
+			}
+
+			mockLogger := NewMocklogger(ctrl)
+			mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().With(gomock.Any()).Return(l.NewNop()).AnyTimes()
+
+			handlerCalls := 0
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerCalls++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.handlerStatus)
+				_, _ = w.Write([]byte(`{"id":"1"}`))
+			})
+
+			handler := middleware.IdempotencyMiddleware(mockStore, time.Hour, 30*time.Second, mockLogger)(next)
+
+			req := httptest.NewRequest(tt.method, "/delivery/assign", bytes.NewReader(tt.body))
+			if tt.key != "" {
+				req.Header.Set(middleware.HeaderIdempotencyKey, tt.key)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatusCode, rr.Code)
+
+			if tt.expectations != nil {
+				tt.expectations(t, rr, handlerCalls)
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddleware_PanicReleasesKey(t *testing.T) {
+	const key = "c6f1e0b2-4a5d-4e0b-9a57-3f8f3b1f2a11"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := NewMockidempotencyStore(ctrl)
+	gomock.InOrder(
+		mockStore.EXPECT().ReserveKey(gomock.Any(), gomock.Any(), 30*time.Second).Return(nil),
+		mockStore.EXPECT().DeleteKey(gomock.Any(), key).Return(nil),
+	)
+
+	mockLogger := NewMocklogger(ctrl)
+
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("handler failed")
+	})
+	handler := middleware.IdempotencyMiddleware(mockStore, time.Hour, 30*time.Second, mockLogger)(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/delivery/assign", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set(middleware.HeaderIdempotencyKey, key)
+
+	assert.PanicsWithValue(t, "handler failed", func() {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
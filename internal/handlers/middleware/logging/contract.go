@@ -1,6 +1,11 @@
 package middleware
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+
+	l "courier-service/pkg/logger/zap"
+)
 
 type pathNormalizer interface {
 	Normalize(r *http.Request) string
@@ -18,6 +23,7 @@ type logger interface {
 
 	Info(args ...interface{})
 	Infof(format string, args ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
 
 	Warn(args ...interface{})
 	Warnf(format string, args ...interface{})
@@ -27,4 +33,6 @@ type logger interface {
 
 	Fatal(args ...interface{})
 	Fatalf(format string, args ...interface{})
+
+	With(ctx context.Context) *l.Logger
 }
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"strconv"
 	"time"
+
+	l "courier-service/pkg/logger/zap"
 )
 
 func LoggingMiddleware(
@@ -15,6 +17,10 @@ func LoggingMiddleware(
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
+			// маршрут нужен в контексте до обработчика, чтобы попасть в его логи
+			ctx := l.WithRoute(r.Context(), normalizer.Normalize(r))
+			r = r.WithContext(ctx)
+
 			rr := &responseRecorder{ResponseWriter: w, status: 200}
 			next.ServeHTTP(rr, r)
 			path := normalizer.Normalize(r)
@@ -33,12 +39,11 @@ func LoggingMiddleware(
 			metricsWriter.RecordRequest(r.Method, path, status)
 			metricsWriter.RecordDuration(r.Method, path, status, duration)
 
-			logger.Infof(PrettyRequestLogFormat,
-				time.Now().Format("2006/01/02 15:04:05"),
-				r.Method,
-				path,
-				rr.status,
-				duration*1000,
+			logger.With(ctx).Infow("http request",
+				"method", r.Method,
+				"path", path,
+				"status", rr.status,
+				"duration_ms", duration*1000,
 			)
 		})
 	}
@@ -53,21 +58,3 @@ func (r *responseRecorder) WriteHeader(code int) {
 	r.status = code
 	r.ResponseWriter.WriteHeader(code)
 }
-
-type Color string
-
-const (
-	ColorLightBlue   Color = "\x1b[94m"
-	ColorLightYellow Color = "\x1b[93m"
-	ColorLightRed    Color = "\x1b[91m"
-	ColorLightGreen  Color = "\x1b[92m"
-	ColorPurple      Color = "\x1b[95m"
-	ColorCyan        Color = "\x1b[96m"
-	ColorLightPink   Color = "\x1b[95m"
-
-	PrettyRequestLogFormat string = string(ColorPurple) + "time=%s " +
-		string(ColorLightBlue) + "method=%s " +
-		string(ColorLightGreen) + "path=%s " +
-		string(ColorLightYellow) + "status=%d " +
-		string(ColorCyan) + "duration=%fms" + "\x1b[0m"
-)
@@ -1,6 +1,11 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+
+	"courier-service/pkg/ratelimiter"
+)
 
 type pathNormalizer interface {
 	Normalize(r *http.Request) string
@@ -11,7 +16,7 @@ type metricsWriter interface {
 }
 
 type rateLimiter interface {
-	Allow() bool
+	Allow(key string, limit ratelimiter.Limit) ratelimiter.Status
 }
 
 type logger interface {
@@ -1,23 +1,82 @@
 package middleware
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"courier-service/pkg/ratelimiter"
+)
+
+const HeaderAPIKey = "X-API-Key"
+
+// KeyBy определяет, кого считать отдельным клиентом лимитера.
+type KeyBy string
+
+const (
+	KeyByGlobal KeyBy = "global"
+	KeyByIP     KeyBy = "ip"
+	KeyByAPIKey KeyBy = "api_key"
 )
 
+// Policy описывает лимиты. Default действует на клиента по всем маршрутам
+// без собственного лимита, Routes задает отдельные бакеты для маршрутов.
+// Ключ Routes — шаблон маршрута ("/courier/{id}") или метод с шаблоном ("POST /courier").
+type Policy struct {
+	KeyBy      KeyBy
+	Default    ratelimiter.Limit
+	Routes     map[string]ratelimiter.Limit
+	TrustProxy bool
+}
+
+// PolicyStore хранит действующую политику. Store подменяет ее без
+// перезапуска, например после перезагрузки конфигурации; запросы, уже
+// прошедшие проверку, не затрагиваются.
+type PolicyStore struct {
+	policy atomic.Pointer[Policy]
+}
+
+func NewPolicyStore(policy Policy) *PolicyStore {
+	s := &PolicyStore{}
+	s.Store(policy)
+	return s
+}
+
+func (s *PolicyStore) Load() Policy {
+	return *s.policy.Load()
+}
+
+func (s *PolicyStore) Store(policy Policy) {
+	s.policy.Store(&policy)
+}
+
 func RateLimitMiddleware(
 	limiter rateLimiter,
+	policies *PolicyStore,
 	logger logger,
 	metricsWriter metricsWriter,
 	normalizer pathNormalizer,
 ) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if !limiter.Allow() {
-				path := normalizer.Normalize(r)
+			policy := policies.Load()
+			path := normalizer.Normalize(r)
+			route, limit := policy.route(r.Method, path)
+			key := limiterKey(route, policy.identity(r))
+
+			status := limiter.Allow(key, limit)
+			setRateLimitHeaders(w.Header(), status)
+
+			if !status.Allowed {
 				logger.Warnf("Rate limit exceeded for %s", path)
 				metricsWriter.RecordRateLimitExceeded(r.Method, path)
-				w.Header().Set("X-RateLimit-Limit", "10")
-				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(status.RetryAfter, 1)))
 				w.WriteHeader(http.StatusTooManyRequests)
 				if _, err := w.Write([]byte("Rate limit exceeded")); err != nil {
 					logger.Warnf("Failed to write rate limit response for %s: %v", path, err)
@@ -25,8 +84,72 @@ func RateLimitMiddleware(
 				return
 			}
 
-			logger.Debugf("Request allowed: %s", r.URL.Path)
+			// постоянное сообщение: семплирование debug логов группирует записи по нему
+			logger.Debugw("Request allowed", "path", r.URL.Path)
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// route возвращает ключ маршрута с собственным лимитом или пустую строку,
+// если действует лимит по умолчанию.
+func (p Policy) route(method, path string) (string, ratelimiter.Limit) {
+	if limit, ok := p.Routes[method+" "+path]; ok {
+		return method + " " + path, limit
+	}
+	if limit, ok := p.Routes[path]; ok {
+		return path, limit
+	}
+	return "", p.Default
+}
+
+func (p Policy) identity(r *http.Request) string {
+	switch p.KeyBy {
+	case KeyByAPIKey:
+		if apiKey := r.Header.Get(HeaderAPIKey); apiKey != "" {
+			// сам ключ в памяти и логах не держим
+			sum := sha256.Sum256([]byte(apiKey))
+			return "key:" + hex.EncodeToString(sum[:16])
+		}
+		return "ip:" + clientIP(r, p.TrustProxy)
+	case KeyByIP:
+		return "ip:" + clientIP(r, p.TrustProxy)
+	default:
+		return ""
+	}
+}
+
+func limiterKey(route, identity string) string {
+	return route + "|" + identity
+}
+
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+			first, _, _ := strings.Cut(xff, ",")
+			return strings.TrimSpace(first)
+		}
+		if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
+			return realIP
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func setRateLimitHeaders(h http.Header, status ratelimiter.Status) {
+	h.Set("X-RateLimit-Limit", strconv.Itoa(status.Limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(status.Remaining))
+	h.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(status.ResetAfter, 0)))
+}
+
+func ceilSeconds(d time.Duration, min int) int {
+	seconds := int(math.Min(math.Ceil(d.Seconds()), math.MaxInt32))
+	if seconds < min {
+		return min
+	}
+	return seconds
+}
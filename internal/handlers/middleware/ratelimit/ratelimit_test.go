@@ -0,0 +1,215 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	middleware "courier-service/internal/handlers/middleware/ratelimit"
+	"courier-service/pkg/ratelimiter"
+	"courier-service/pkg/ratelimiter/keyed"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	type request struct {
+		method     string
+		route      string
+		remoteAddr string
+		apiKey     string
+		wantStatus int
+	}
+
+	tests := []struct {
+		name     string
+		policy   middleware.Policy
+		requests []request
+	}{
+		{
+			name: "global bucket shared by everyone",
+			policy: middleware.Policy{
+				KeyBy:   middleware.KeyByGlobal,
+				Default: ratelimiter.Limit{Capacity: 1, RefillRate: 1},
+			},
+			requests: []request{
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.1:1000", wantStatus: http.StatusOK},
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.2:1000", wantStatus: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "separate buckets per ip",
+			policy: middleware.Policy{
+				KeyBy:   middleware.KeyByIP,
+				Default: ratelimiter.Limit{Capacity: 1, RefillRate: 1},
+			},
+			requests: []request{
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.1:1000", wantStatus: http.StatusOK},
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.2:1000", wantStatus: http.StatusOK},
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.1:2000", wantStatus: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "api key takes precedence over ip",
+			policy: middleware.Policy{
+				KeyBy:   middleware.KeyByAPIKey,
+				Default: ratelimiter.Limit{Capacity: 1, RefillRate: 1},
+			},
+			requests: []request{
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.1:1000", apiKey: "a", wantStatus: http.StatusOK},
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.1:1000", apiKey: "b", wantStatus: http.StatusOK},
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.1:1000", wantStatus: http.StatusOK},
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.2:1000", apiKey: "a", wantStatus: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "route limit uses its own bucket",
+			policy: middleware.Policy{
+				KeyBy:   middleware.KeyByIP,
+				Default: ratelimiter.Limit{Capacity: 1, RefillRate: 1},
+				Routes: map[string]ratelimiter.Limit{
+					"POST /delivery/assign": {Capacity: 2, RefillRate: 1},
+				},
+			},
+			requests: []request{
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.1:1000", wantStatus: http.StatusOK},
+				{method: http.MethodPost, route: "/delivery/assign", remoteAddr: "10.0.0.1:1000", wantStatus: http.StatusOK},
+				{method: http.MethodPost, route: "/delivery/assign", remoteAddr: "10.0.0.1:1000", wantStatus: http.StatusOK},
+				{method: http.MethodPost, route: "/delivery/assign", remoteAddr: "10.0.0.1:1000", wantStatus: http.StatusTooManyRequests},
+				{method: http.MethodGet, route: "/couriers", remoteAddr: "10.0.0.1:1000", wantStatus: http.StatusTooManyRequests},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			fake := time.Unix(0, 0)
+			limiter := keyed.NewLimiter(100, time.Minute, func() time.Time { return fake })
+
+			mockLogger := NewMocklogger(ctrl)
+			mockLogger.EXPECT().Debugw(gomock.Any(), gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			mockMetrics := NewMockmetricsWriter(ctrl)
+			mockMetrics.EXPECT().RecordRateLimitExceeded(gomock.Any(), gomock.Any()).AnyTimes()
+
+			for i, req := range tt.requests {
+				mockNormalizer := NewMockpathNormalizer(ctrl)
+				mockNormalizer.EXPECT().Normalize(gomock.Any()).Return(req.route)
+
+				next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})
+				handler := middleware.RateLimitMiddleware(limiter, middleware.NewPolicyStore(tt.policy), mockLogger, mockMetrics, mockNormalizer)(next)
+
+				r := httptest.NewRequest(req.method, req.route, nil)
+				r.RemoteAddr = req.remoteAddr
+				if req.apiKey != "" {
+					r.Header.Set(middleware.HeaderAPIKey, req.apiKey)
+				}
+				rr := httptest.NewRecorder()
+
+				handler.ServeHTTP(rr, r)
+
+				assert.Equal(t, req.wantStatus, rr.Code, "request %d", i)
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddleware_Headers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fake := time.Unix(0, 0)
+	limiter := keyed.NewLimiter(100, time.Minute, func() time.Time { return fake })
+	policy := middleware.Policy{
+		KeyBy:   middleware.KeyByIP,
+		Default: ratelimiter.Limit{Capacity: 2, RefillRate: 1},
+	}
+
+	mockLogger := NewMocklogger(ctrl)
+	mockLogger.EXPECT().Debugw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockMetrics := NewMockmetricsWriter(ctrl)
+	mockMetrics.EXPECT().RecordRateLimitExceeded(http.MethodGet, "/couriers").Times(1)
+
+	mockNormalizer := NewMockpathNormalizer(ctrl)
+	mockNormalizer.EXPECT().Normalize(gomock.Any()).Return("/couriers").AnyTimes()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.RateLimitMiddleware(limiter, middleware.NewPolicyStore(policy), mockLogger, mockMetrics, mockNormalizer)(next)
+
+	do := func() *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/couriers", nil))
+		return rr
+	}
+
+	rr := do()
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rr.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1", rr.Header().Get("X-RateLimit-Reset"))
+	assert.Empty(t, rr.Header().Get("Retry-After"))
+
+	do()
+	rr = do()
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "0", rr.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "2", rr.Header().Get("X-RateLimit-Reset"))
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+}
+
+func TestRateLimitMiddleware_PolicyReload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fake := time.Unix(0, 0)
+	limiter := keyed.NewLimiter(100, time.Minute, func() time.Time { return fake })
+	policies := middleware.NewPolicyStore(middleware.Policy{
+		KeyBy:   middleware.KeyByGlobal,
+		Default: ratelimiter.Limit{Capacity: 1, RefillRate: 1},
+	})
+
+	mockLogger := NewMocklogger(ctrl)
+	mockLogger.EXPECT().Debugw(gomock.Any(), gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockMetrics := NewMockmetricsWriter(ctrl)
+	mockMetrics.EXPECT().RecordRateLimitExceeded(gomock.Any(), gomock.Any()).AnyTimes()
+
+	mockNormalizer := NewMockpathNormalizer(ctrl)
+	mockNormalizer.EXPECT().Normalize(gomock.Any()).Return("/couriers").AnyTimes()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := middleware.RateLimitMiddleware(limiter, policies, mockLogger, mockMetrics, mockNormalizer)(next)
+
+	do := func() int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/couriers", nil))
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do())
+	assert.Equal(t, http.StatusTooManyRequests, do())
+
+	// новая емкость применяется к уже созданному бакету без перезапуска
+	policies.Store(middleware.Policy{
+		KeyBy:   middleware.KeyByGlobal,
+		Default: ratelimiter.Limit{Capacity: 3, RefillRate: 1},
+	})
+	assert.Equal(t, http.StatusOK, do())
+	assert.Equal(t, http.StatusOK, do())
+	assert.Equal(t, http.StatusTooManyRequests, do())
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"courier-service/pkg/requestid"
+)
+
+// RequestIDMiddleware берет id запроса из X-Request-ID или выдает новый,
+// кладет его в контекст для исходящих gRPC вызовов и возвращает клиенту.
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(requestid.Header)
+			if !requestid.Valid(id) {
+				id = requestid.New()
+			}
+
+			w.Header().Set(requestid.Header, id)
+			next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+		})
+	}
+}
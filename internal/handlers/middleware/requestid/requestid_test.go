@@ -0,0 +1,50 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	requestidmiddleware "courier-service/internal/handlers/middleware/requestid"
+	"courier-service/pkg/requestid"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		headerID string
+		wantSame bool
+	}{
+		{name: "client id is kept", headerID: "req-123", wantSame: true},
+		{name: "missing id is generated", headerID: ""},
+		{name: "too long id is replaced", headerID: strings.Repeat("a", 200)},
+		{name: "id with spaces is replaced", headerID: "bad id"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var ctxID string
+			handler := requestidmiddleware.RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxID = requestid.FromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			if tc.headerID != "" {
+				req.Header.Set(requestid.Header, tc.headerID)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.NotEmpty(t, ctxID)
+			assert.Equal(t, ctxID, rr.Header().Get(requestid.Header))
+			if tc.wantSame {
+				assert.Equal(t, tc.headerID, ctxID)
+			} else {
+				assert.NotEqual(t, tc.headerID, ctxID)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package middleware
+
+import "net/http"
+
+type pathNormalizer interface {
+	Normalize(r *http.Request) string
+}
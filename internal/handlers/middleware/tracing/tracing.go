@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"courier-service/pkg/requestid"
+	"courier-service/pkg/tracing"
+)
+
+// TracingMiddleware открывает серверный спан на запрос, продолжая трассу из
+// traceparent. Имя спана — метод и шаблон маршрута chi, чтобы запросы к
+// /courier/1 и /courier/2 попадали в одну операцию.
+func TracingMiddleware(normalizer pathNormalizer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracing.Tracer().Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLPath(r.URL.Path),
+				),
+			)
+			defer span.End()
+
+			if id := requestid.FromContext(ctx); id != "" {
+				span.SetAttributes(attribute.String("request.id", id))
+			}
+
+			rr := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rr, r.WithContext(ctx))
+
+			route := normalizer.Normalize(r)
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(
+				semconv.HTTPRoute(route),
+				semconv.HTTPResponseStatusCode(rr.status),
+			)
+			if rr.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(rr.status))
+			}
+		})
+	}
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
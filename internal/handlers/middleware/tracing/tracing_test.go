@@ -0,0 +1,76 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	middleware "courier-service/internal/handlers/middleware/tracing"
+)
+
+const (
+	remoteTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceparent   = "00-" + remoteTraceID + "-00f067aa0ba902b7-01"
+)
+
+type routePattern struct{}
+
+func (routePattern) Normalize(r *http.Request) string {
+	return chi.RouteContext(r.Context()).RoutePattern()
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		header     string
+		wantStatus codes.Code
+	}{
+		{name: "remote trace is continued", status: http.StatusOK, header: traceparent, wantStatus: codes.Unset},
+		{name: "server error marks span", status: http.StatusInternalServerError, wantStatus: codes.Error},
+		{name: "client error is not a span error", status: http.StatusNotFound, wantStatus: codes.Unset},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+			otel.SetTextMapPropagator(propagation.TraceContext{})
+			t.Cleanup(func() {
+				otel.SetTracerProvider(prevProvider)
+				otel.SetTextMapPropagator(prevPropagator)
+			})
+
+			r := chi.NewRouter()
+			r.Use(middleware.TracingMiddleware(routePattern{}))
+			r.Get("/courier/{id}", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/courier/42", nil)
+			if tc.header != "" {
+				req.Header.Set("traceparent", tc.header)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "GET /courier/{id}", spans[0].Name())
+			assert.Equal(t, tc.wantStatus, spans[0].Status().Code)
+			if tc.header != "" {
+				assert.Equal(t, remoteTraceID, spans[0].SpanContext().TraceID().String())
+				assert.True(t, spans[0].Parent().IsRemote())
+			}
+		})
+	}
+}
@@ -4,12 +4,18 @@ import (
 	"context"
 
 	"courier-service/internal/model"
+	l "courier-service/pkg/logger/zap"
 )
 
 type orderChangedUseCase interface {
 	HandleOrderStatusChanged(ctx context.Context, status model.OrderStatus, orderID string) error
 }
 
+type metricsWriter interface {
+	SetConsumerLag(topic string, partition int32, lag int64)
+	RecordMessageProcessing(status string, duration float64)
+}
+
 type logger interface {
 	Debug(args ...interface{})
 	Debugf(format string, args ...interface{})
@@ -26,4 +32,6 @@ type logger interface {
 
 	Fatal(args ...interface{})
 	Fatalf(format string, args ...interface{})
+
+	With(ctx context.Context) *l.Logger
 }
@@ -1,22 +1,37 @@
 package order
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"courier-service/internal/model"
 	changed "courier-service/internal/usecase/order/changed"
+	l "courier-service/pkg/logger/zap"
+	"courier-service/pkg/requestid"
+)
+
+// invalidMessageStatus — метка длительности обработки для сообщений,
+// которые не удалось разобрать; unknownMessageStatus — для неизвестных
+// статусов заказа, чтобы содержимое сообщений не плодило метки.
+const (
+	invalidMessageStatus = "invalid"
+	unknownMessageStatus = "unknown"
 )
 
 type OrderStatusChangedHandler struct {
 	useCase orderChangedUseCase
+	metrics metricsWriter
 	logger  logger
 }
 
-func NewOrderStatusChangedHandler(useCase orderChangedUseCase, logger logger) *OrderStatusChangedHandler {
-	return &OrderStatusChangedHandler{useCase: useCase, logger: logger}
+func NewOrderStatusChangedHandler(useCase orderChangedUseCase, metrics metricsWriter, logger logger) *OrderStatusChangedHandler {
+	return &OrderStatusChangedHandler{useCase: useCase, metrics: metrics, logger: logger}
 }
 
 func (h *OrderStatusChangedHandler) Setup(sarama.ConsumerGroupSession) error {
@@ -29,27 +44,71 @@ func (h *OrderStatusChangedHandler) Cleanup(sarama.ConsumerGroupSession) error {
 
 func (h *OrderStatusChangedHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	for message := range claim.Messages() {
-		ctx := session.Context()
+		start := time.Now()
+		status := h.handleMessage(session, message)
+		h.metrics.RecordMessageProcessing(status, time.Since(start).Seconds())
 
-		var msg orderChangedDto
-		if err := json.Unmarshal(message.Value, &msg); err != nil {
-			session.MarkMessage(message, "")
-			continue
-		}
+		session.MarkMessage(message, "")
+		h.metrics.SetConsumerLag(message.Topic, message.Partition, consumerLag(claim, message))
+	}
+	return nil
+}
 
-		status := model.OrderStatus(msg.Status)
+// consumerLag — число сообщений партиции после обработанного.
+func consumerLag(claim sarama.ConsumerGroupClaim, message *sarama.ConsumerMessage) int64 {
+	return max(claim.HighWaterMarkOffset()-message.Offset-1, 0)
+}
 
-		h.logger.Infof("fetched order with id %s and status %s", msg.OrderID, status)
+// handleMessage обрабатывает одно сообщение в спане, продолжающем трассу
+// из заголовков, и возвращает метку статуса заказа из него. Ошибки только
+// логируются: сообщение подтверждается в любом случае.
+func (h *OrderStatusChangedHandler) handleMessage(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) string {
+	ctx, span := startMessageSpan(messageContext(session.Context(), message), message)
+	defer span.End()
+
+	var msg orderChangedDto
+	if err := json.Unmarshal(message.Value, &msg); err != nil {
+		h.logger.With(ctx).Warnw("order.changed handler: invalid message", "offset", message.Offset, "error", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid message")
+		return invalidMessageStatus
+	}
 
-		if err := h.useCase.HandleOrderStatusChanged(ctx, status, msg.OrderID); err != nil {
-			if errors.Is(err, changed.ErrOrderStatusMismatch) {
-				session.MarkMessage(message, "")
-				continue
-			}
-			h.logger.Errorf("order.changed handler: failed to process order: %v", err)
+	status := model.OrderStatus(msg.Status)
+	span.SetAttributes(
+		attribute.String("order.id", msg.OrderID),
+		attribute.String("order.status", string(status)),
+	)
+
+	ctx = l.WithOrderID(ctx, msg.OrderID)
+	h.logger.With(ctx).Infow("order status changed", "status", status)
+
+	if err := h.useCase.HandleOrderStatusChanged(ctx, status, msg.OrderID); err != nil {
+		if errors.Is(err, changed.ErrOrderStatusMismatch) {
+			return statusLabel(status)
 		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to process order")
+		h.logger.With(ctx).Errorw("order.changed handler: failed to process order", "error", err)
+	}
+	return statusLabel(status)
+}
 
-		session.MarkMessage(message, "")
+func statusLabel(status model.OrderStatus) string {
+	switch status {
+	case model.OrderStatusCreated, model.OrderStatusCompleted, model.OrderStatusCancelled:
+		return string(status)
+	default:
+		return unknownMessageStatus
 	}
-	return nil
+}
+
+// messageContext кладет в контекст request id из заголовка сообщения, чтобы он
+// дошел до исходящих gRPC вызовов. Без заголовка генерируется новый.
+func messageContext(ctx context.Context, message *sarama.ConsumerMessage) context.Context {
+	id := headersCarrier(message.Headers).Get(requestid.Header)
+	if !requestid.Valid(id) {
+		id = requestid.New()
+	}
+	return requestid.NewContext(ctx, id)
 }
@@ -0,0 +1,55 @@
+package order
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"courier-service/pkg/tracing"
+)
+
+// startMessageSpan открывает consumer спан обработки сообщения. Контекст
+// трассы продюсера берется из заголовков (traceparent), если он их передал.
+func startMessageSpan(ctx context.Context, message *sarama.ConsumerMessage) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headersCarrier(message.Headers))
+	return tracing.Tracer().Start(ctx, message.Topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypeDeliver,
+			semconv.MessagingDestinationName(message.Topic),
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(int(message.Partition))),
+			semconv.MessagingKafkaMessageOffset(int(message.Offset)),
+		),
+	)
+}
+
+// headersCarrier — заголовки сообщения Kafka как propagation.TextMapCarrier.
+// Используется только для чтения, имена сравниваются без учета регистра.
+type headersCarrier []*sarama.RecordHeader
+
+func (c headersCarrier) Get(key string) string {
+	for _, h := range c {
+		if h != nil && strings.EqualFold(string(h.Key), key) {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c headersCarrier) Set(string, string) {}
+
+func (c headersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for _, h := range c {
+		if h != nil {
+			keys = append(keys, string(h.Key))
+		}
+	}
+	return keys
+}
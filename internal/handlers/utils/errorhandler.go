@@ -1,19 +1,47 @@
 package utils
 
 import (
-	"log"
 	"net/http"
+
+	"courier-service/pkg/database/pgerrors"
 )
 
 const (
-	ErrInternalServer = "Internal server error"
+	ErrInternalServer   = "Internal server error"
+	ErrConflict         = "Request conflicts with existing data"
+	ErrConcurrentUpdate = "Concurrent update, retry the request"
+
+	// conflictRetryAfter — через сколько секунд повторять запрос после
+	// конфликта транзакций.
+	conflictRetryAfter = "1"
 )
 
+type errorLogger interface {
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
 func RespondWithError(w http.ResponseWriter, httpStatus int, message string) {
 	RespondWithJSON(w, httpStatus, map[string]string{"error": message})
 }
 
-func RespondInternalServerError(w http.ResponseWriter, err error) {
-	log.Printf("Internal server error: %v\n", err)
+// RespondInternalServerError пишет причину в лог, клиенту уходит только
+// общее сообщение.
+func RespondInternalServerError(w http.ResponseWriter, logger errorLogger, err error) {
+	logger.Errorw("internal server error", "error", err)
 	RespondWithError(w, http.StatusInternalServerError, ErrInternalServer)
 }
+
+// RespondUnhandledError отвечает на ошибку, которую обработчик не знает:
+// нарушения ограничений Postgres — 409, конфликты транзакций — 503 с
+// Retry-After, остальное — 500.
+func RespondUnhandledError(w http.ResponseWriter, logger errorLogger, err error) {
+	switch pgerrors.Kind(err) {
+	case pgerrors.ErrUniqueViolation, pgerrors.ErrForeignKeyViolation:
+		RespondWithError(w, http.StatusConflict, ErrConflict)
+	case pgerrors.ErrSerializationFailure, pgerrors.ErrDeadlock:
+		w.Header().Set("Retry-After", conflictRetryAfter)
+		RespondWithError(w, http.StatusServiceUnavailable, ErrConcurrentUpdate)
+	default:
+		RespondInternalServerError(w, logger, err)
+	}
+}
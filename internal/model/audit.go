@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+type AuditAction string
+
+type AuditEntity string
+
+const (
+	AuditActionCourierCreate     AuditAction = "courier.create"
+	AuditActionCourierUpdate     AuditAction = "courier.update"
+	AuditActionCourierDelete     AuditAction = "courier.delete"
+	AuditActionCourierDeactivate AuditAction = "courier.deactivate"
+	AuditActionCourierReactivate AuditAction = "courier.reactivate"
+	AuditActionCourierErase      AuditAction = "courier.erase"
+	AuditActionDeliveryAssign    AuditAction = "delivery.assign"
+	AuditActionDeliveryUnassign  AuditAction = "delivery.unassign"
+)
+
+const (
+	// AuditEntityCourier — запись о курьере, EntityID — id курьера.
+	AuditEntityCourier AuditEntity = "courier"
+	// AuditEntityDelivery — запись о доставке, EntityID — id заказа.
+	AuditEntityDelivery AuditEntity = "delivery"
+)
+
+// AuditEntities — все сущности, по которым ведется журнал аудита.
+var AuditEntities = []AuditEntity{
+	AuditEntityCourier,
+	AuditEntityDelivery,
+}
+
+// AuditEntry — запись журнала аудита. Changes содержит только изменившиеся
+// поля сущности.
+type AuditEntry struct {
+	ID         int64
+	OccurredAt time.Time
+	Actor      string
+	Action     AuditAction
+	EntityType AuditEntity
+	EntityID   string
+	Changes    map[string]AuditChange
+	RequestID  string
+}
+
+// AuditChange — значение поля до и после действия; nil, если поля не было.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditFilter — выборка журнала: пустые поля не ограничивают результат.
+type AuditFilter struct {
+	EntityType AuditEntity
+	EntityID   string
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
@@ -10,6 +10,8 @@ type Courier struct {
 	TransportType CourierTransportType
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	// DeletedAt — время мягкого удаления; удаленные курьеры в API не отдаются
+	DeletedAt *time.Time `json:"-"`
 }
 
 type CourierStatus string
@@ -19,6 +21,8 @@ type CourierTransportType string
 const (
 	CourierStatusAvailable CourierStatus = "available"
 	CourierStatusBusy      CourierStatus = "busy"
+	// CourierStatusInactive — курьер деактивирован или удален и не получает заказы
+	CourierStatusInactive CourierStatus = "inactive"
 )
 
 const (
@@ -27,6 +31,17 @@ const (
 	TransportTypeCar     CourierTransportType = "car"
 )
 
+// TransportTypes — все типы транспорта, по которым ведется учет курьеров.
+var TransportTypes = []CourierTransportType{
+	TransportTypeOnFoot,
+	TransportTypeScooter,
+	TransportTypeCar,
+}
+
 func (c *Courier) ChangeStatus(status CourierStatus) {
 	c.Status = status
 }
+
+func (c *Courier) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
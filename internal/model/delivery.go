@@ -8,4 +8,6 @@ type Delivery struct {
 	OrderID    string
 	AssignedAt time.Time
 	Deadline   time.Time
+	// снимок заказа на момент назначения, nil если сервис заказов не ответил
+	Order *Order
 }
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type IdempotencyKey struct {
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// Completed сообщает, сохранен ли уже ответ на запрос с этим ключом.
+func (k IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
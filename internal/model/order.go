@@ -3,9 +3,30 @@ package model
 import "time"
 
 type Order struct {
-	ID        string
-	CreatedAt time.Time
-	Status    OrderStatus
+	ID                string
+	OrderNumber       string
+	RestaurantID      string
+	Items             []OrderItem
+	TotalPrice        int64
+	Address           OrderAddress
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	EstimatedDelivery time.Time
+	Status            OrderStatus
+}
+
+type OrderItem struct {
+	Name     string
+	Price    int64
+	Quantity int64
+}
+
+type OrderAddress struct {
+	Street    string
+	House     string
+	Apartment string
+	Floor     string
+	Comment   string
 }
 
 type OrderStatus string
@@ -0,0 +1,191 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"courier-service/internal/model"
+	integration "courier-service/internal/persistence/database/integration"
+	auditstorage "courier-service/internal/repository/audit"
+	courierstorage "courier-service/internal/repository/courier"
+	"courier-service/internal/repository/dbrouter"
+	deliverystorage "courier-service/internal/repository/delivery"
+	txrunner "courier-service/internal/repository/txrunner"
+	auditusecase "courier-service/internal/usecase/audit"
+	assign "courier-service/internal/usecase/delivery/assign"
+	deliverycalculator "courier-service/internal/usecase/utils"
+	metrics "courier-service/pkg/metrics/prometheus"
+)
+
+const (
+	raceCouriers   = 10
+	raceGoroutines = 50
+)
+
+type AssignRaceTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pool        *pgxpool.Pool
+	courierRepo *courierstorage.CourierRepository
+}
+
+func TestAssignRaceTestSuite(t *testing.T) {
+	suite.Run(t, new(AssignRaceTestSuite))
+}
+
+func (s *AssignRaceTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	_, connStr, err := integration.TestWithMigrations()
+	s.Require().NoError(err)
+
+	cfg, err := pgxpool.ParseConfig(connStr)
+	s.Require().NoError(err)
+	cfg.MaxConns = raceGoroutines
+
+	pool, err := pgxpool.NewWithConfig(s.ctx, cfg)
+	s.Require().NoError(err)
+	s.pool = pool
+
+	s.courierRepo = courierstorage.NewCourierRepository(dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil), zap.NewNop().Sugar())
+}
+
+func (s *AssignRaceTestSuite) TearDownSuite() {
+	s.pool.Close()
+}
+
+func (s *AssignRaceTestSuite) SetupTest() {
+	s.Require().NoError(integration.TruncateAll(s.ctx, s.pool))
+}
+
+func (s *AssignRaceTestSuite) TestConcurrentAssign_NoDoubleBooking() {
+	levels := []pgx.TxIsoLevel{pgx.ReadCommitted, pgx.RepeatableRead, pgx.Serializable}
+
+	for _, level := range levels {
+		s.Run(string(level), func() {
+			s.Require().NoError(integration.TruncateAll(s.ctx, s.pool))
+			s.seedCouriers(raceCouriers)
+
+			useCase := s.newAssignUseCase(txrunner.Config{IsoLevel: level, MaxRetries: 20})
+
+			var (
+				wg       sync.WaitGroup
+				mu       sync.Mutex
+				assigned int
+				busy     int
+				failures []error
+			)
+			start := make(chan struct{})
+			for i := 0; i < raceGoroutines; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-start
+					_, err := useCase.Assign(s.ctx, uuid.New().String())
+
+					mu.Lock()
+					defer mu.Unlock()
+					switch {
+					case err == nil:
+						assigned++
+					case errors.Is(err, assign.ErrCouriersBusy):
+						busy++
+					default:
+						failures = append(failures, err)
+					}
+				}()
+			}
+			close(start)
+			wg.Wait()
+
+			s.Empty(failures, "unexpected assign errors")
+			s.Equal(raceCouriers, assigned, "every courier should be assigned exactly once")
+			s.Equal(raceGoroutines-raceCouriers, busy)
+			s.assertNoDoubleBooking()
+		})
+	}
+}
+
+func (s *AssignRaceTestSuite) TestConcurrentAssign_RepeatedRounds() {
+	s.seedCouriers(raceCouriers)
+	useCase := s.newAssignUseCase(txrunner.Config{MaxRetries: 5})
+
+	for round := 0; round < 5; round++ {
+		var wg sync.WaitGroup
+		for i := 0; i < raceCouriers*2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = useCase.Assign(s.ctx, uuid.New().String())
+			}()
+		}
+		wg.Wait()
+
+		s.assertNoDoubleBooking()
+
+		// освобождаем курьеров, сохраняя историю доставок, и идем на новый круг
+		_, err := s.pool.Exec(s.ctx, "UPDATE delivery SET deadline = NOW() - INTERVAL '1 second'")
+		s.Require().NoError(err)
+		_, err = s.pool.Exec(s.ctx, "UPDATE couriers SET status = 'available'")
+		s.Require().NoError(err)
+	}
+}
+
+func (s *AssignRaceTestSuite) newAssignUseCase(cfg txrunner.Config) *assign.AssignDelieveryUseCase {
+	return assign.NewAssignDelieveryUseCase(
+		s.courierRepo,
+		deliverystorage.NewDeliveryRepository(dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil)),
+		txrunner.NewTxRunner(s.pool, cfg, zap.NewNop().Sugar()),
+		auditusecase.NewRecorder(auditstorage.NewAuditRepository(dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil))),
+		deliverycalculator.NewTimeCalculatorFactory(),
+		unavailableOrderGateway{},
+		metrics.NewBusinessMetricsWriter(metrics.NewBusinessMetrics(prometheus.NewRegistry())),
+		zap.NewNop().Sugar(),
+	)
+}
+
+// unavailableOrderGateway: гонка проверяется без снимков заказов
+type unavailableOrderGateway struct{}
+
+func (unavailableOrderGateway) GetOrderById(context.Context, string) (model.Order, error) {
+	return model.Order{}, errors.New("order service unavailable")
+}
+
+func (s *AssignRaceTestSuite) seedCouriers(n int) {
+	for i := 0; i < n; i++ {
+		_, err := s.courierRepo.CreateCourier(s.ctx, model.Courier{
+			Name:          fmt.Sprintf("Courier %d", i),
+			Phone:         fmt.Sprintf("+7999%07d", i),
+			Status:        model.CourierStatusAvailable,
+			TransportType: model.TransportTypeCar,
+		})
+		s.Require().NoError(err)
+	}
+}
+
+func (s *AssignRaceTestSuite) assertNoDoubleBooking() {
+	var doubleBooked int
+	err := s.pool.QueryRow(s.ctx, `
+		SELECT COUNT(*) FROM (
+			SELECT courier_id
+			FROM delivery
+			WHERE deadline > NOW()
+			GROUP BY courier_id
+			HAVING COUNT(*) > 1
+		) t`).Scan(&doubleBooked)
+	s.Require().NoError(err)
+	s.Zero(doubleBooked, "courier holds more than one active delivery")
+}
@@ -0,0 +1,205 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"courier-service/internal/model"
+	integration "courier-service/internal/persistence/database/integration"
+	auditstorage "courier-service/internal/repository/audit"
+	courierstorage "courier-service/internal/repository/courier"
+	"courier-service/internal/repository/dbrouter"
+	deliverystorage "courier-service/internal/repository/delivery"
+	txrunner "courier-service/internal/repository/txrunner"
+	auditusecase "courier-service/internal/usecase/audit"
+	assign "courier-service/internal/usecase/delivery/assign"
+	unassign "courier-service/internal/usecase/delivery/unassign"
+	deliverycalculator "courier-service/internal/usecase/utils"
+	"courier-service/pkg/actor"
+	metrics "courier-service/pkg/metrics/prometheus"
+)
+
+var errUpdateFailed = errors.New("update failed")
+
+// failingCourierRepository выполняет обновление курьера и затем возвращает
+// ошибку, как если бы сбой случился после всех записей в транзакции.
+type failingCourierRepository struct {
+	*courierstorage.CourierRepository
+}
+
+func (r failingCourierRepository) UpdateCourier(ctx context.Context, courier model.Courier) error {
+	if err := r.CourierRepository.UpdateCourier(ctx, courier); err != nil {
+		return err
+	}
+	return errUpdateFailed
+}
+
+// failingAuditRepository дописывает запись журнала и затем возвращает
+// ошибку: запись должна откатиться вместе с действием.
+type failingAuditRepository struct {
+	*auditstorage.AuditRepository
+}
+
+func (r failingAuditRepository) Append(ctx context.Context, entry model.AuditEntry) error {
+	if err := r.AuditRepository.Append(ctx, entry); err != nil {
+		return err
+	}
+	return errUpdateFailed
+}
+
+type AssignTxTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	pool         *pgxpool.Pool
+	courierRepo  *courierstorage.CourierRepository
+	deliveryRepo *deliverystorage.DeliveryRepository
+	auditRepo    *auditstorage.AuditRepository
+	txRunner     *txrunner.PgxTxRunner
+}
+
+func TestAssignTxTestSuite(t *testing.T) {
+	suite.Run(t, new(AssignTxTestSuite))
+}
+
+func (s *AssignTxTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	_, connStr, err := integration.TestWithMigrations()
+	s.Require().NoError(err)
+
+	pool, err := pgxpool.New(s.ctx, connStr)
+	s.Require().NoError(err)
+	s.pool = pool
+
+	router := dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil)
+	s.courierRepo = courierstorage.NewCourierRepository(router, zap.NewNop().Sugar())
+	s.deliveryRepo = deliverystorage.NewDeliveryRepository(router)
+	s.auditRepo = auditstorage.NewAuditRepository(router)
+	s.txRunner = txrunner.NewTxRunner(s.pool, txrunner.Config{}, zap.NewNop().Sugar())
+}
+
+func (s *AssignTxTestSuite) TearDownSuite() {
+	s.pool.Close()
+}
+
+func (s *AssignTxTestSuite) SetupTest() {
+	s.Require().NoError(integration.TruncateAll(s.ctx, s.pool))
+}
+
+func (s *AssignTxTestSuite) TestAssign_FailureAfterCreateDeliveryRollsBack() {
+	courierID := s.seedCourier(model.CourierStatusAvailable)
+	orderID := uuid.New().String()
+
+	useCase := assign.NewAssignDelieveryUseCase(
+		failingCourierRepository{s.courierRepo},
+		s.deliveryRepo,
+		s.txRunner,
+		auditusecase.NewRecorder(s.auditRepo),
+		deliverycalculator.NewTimeCalculatorFactory(),
+		unavailableOrderGateway{},
+		metrics.NewBusinessMetricsWriter(metrics.NewBusinessMetrics(prometheus.NewRegistry())),
+		zap.NewNop().Sugar(),
+	)
+
+	_, err := useCase.Assign(s.ctx, orderID)
+	s.ErrorIs(err, errUpdateFailed)
+
+	_, err = s.deliveryRepo.CouriersDelivery(s.ctx, orderID)
+	s.ErrorIs(err, deliverystorage.ErrOrderIDNotFound, "delivery insert should be rolled back")
+	s.assertCourierStatus(courierID, model.CourierStatusAvailable)
+}
+
+func (s *AssignTxTestSuite) TestUnassign_FailureAfterDeleteDeliveryRollsBack() {
+	courierID := s.seedCourier(model.CourierStatusBusy)
+	orderID := uuid.New().String()
+	now := time.Now()
+	_, err := s.deliveryRepo.CreateDelivery(s.ctx, model.Delivery{
+		CourierID:  courierID,
+		OrderID:    orderID,
+		AssignedAt: now,
+		Deadline:   now.Add(time.Hour),
+	})
+	s.Require().NoError(err)
+
+	useCase := unassign.NewUnassignDelieveryUseCase(failingCourierRepository{s.courierRepo}, s.deliveryRepo, s.txRunner, auditusecase.NewRecorder(s.auditRepo))
+
+	_, err = useCase.Unassign(s.ctx, orderID)
+	s.ErrorIs(err, errUpdateFailed)
+
+	delivery, err := s.deliveryRepo.CouriersDelivery(s.ctx, orderID)
+	s.Require().NoError(err, "delivery delete should be rolled back")
+	s.Equal(courierID, delivery.CourierID)
+	s.assertCourierStatus(courierID, model.CourierStatusBusy)
+}
+
+func (s *AssignTxTestSuite) TestAssign_RecordsAuditEntry() {
+	s.seedCourier(model.CourierStatusAvailable)
+	orderID := uuid.New().String()
+
+	_, err := s.newAssignUseCase(auditusecase.NewRecorder(s.auditRepo)).Assign(actor.NewContext(s.ctx, "dispatcher"), orderID)
+	s.Require().NoError(err)
+
+	entries, err := s.auditRepo.List(s.ctx, model.AuditFilter{EntityType: model.AuditEntityDelivery, EntityID: orderID})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Equal(model.AuditActionDeliveryAssign, entries[0].Action)
+	s.Equal("dispatcher", entries[0].Actor)
+	s.Contains(entries[0].Changes, "courier_id")
+}
+
+func (s *AssignTxTestSuite) TestAssign_AuditFailureRollsBack() {
+	courierID := s.seedCourier(model.CourierStatusAvailable)
+	orderID := uuid.New().String()
+
+	_, err := s.newAssignUseCase(auditusecase.NewRecorder(failingAuditRepository{s.auditRepo})).Assign(s.ctx, orderID)
+	s.ErrorIs(err, errUpdateFailed)
+
+	_, err = s.deliveryRepo.CouriersDelivery(s.ctx, orderID)
+	s.ErrorIs(err, deliverystorage.ErrOrderIDNotFound, "delivery insert should be rolled back")
+	s.assertCourierStatus(courierID, model.CourierStatusAvailable)
+
+	entries, err := s.auditRepo.List(s.ctx, model.AuditFilter{})
+	s.Require().NoError(err)
+	s.Empty(entries, "audit entry should be rolled back with the assignment")
+}
+
+func (s *AssignTxTestSuite) newAssignUseCase(recorder *auditusecase.Recorder) *assign.AssignDelieveryUseCase {
+	return assign.NewAssignDelieveryUseCase(
+		s.courierRepo,
+		s.deliveryRepo,
+		s.txRunner,
+		recorder,
+		deliverycalculator.NewTimeCalculatorFactory(),
+		unavailableOrderGateway{},
+		metrics.NewBusinessMetricsWriter(metrics.NewBusinessMetrics(prometheus.NewRegistry())),
+		zap.NewNop().Sugar(),
+	)
+}
+
+func (s *AssignTxTestSuite) seedCourier(status model.CourierStatus) int64 {
+	id, err := s.courierRepo.CreateCourier(s.ctx, model.Courier{
+		Name:          "Tx Courier",
+		Phone:         "+79990000001",
+		Status:        status,
+		TransportType: model.TransportTypeCar,
+	})
+	s.Require().NoError(err)
+	return id
+}
+
+func (s *AssignTxTestSuite) assertCourierStatus(id int64, status model.CourierStatus) {
+	courier, err := s.courierRepo.GetCourierById(s.ctx, id)
+	s.Require().NoError(err)
+	s.Equal(status, courier.Status, "courier status change should be rolled back")
+}
@@ -9,7 +9,7 @@ import (
 func TruncateAll(ctx context.Context, pool *pgxpool.Pool) error {
 	_, err := pool.Exec(ctx,
 		`
-		TRUNCATE TABLE couriers, delivery
+		TRUNCATE TABLE couriers, delivery, idempotency_keys, rate_limit_buckets, audit_log
 		RESTART IDENTITY
 		CASCADE
 	`)
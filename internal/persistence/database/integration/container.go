@@ -3,6 +3,8 @@ package integration
 import (
 	"context"
 	"database/sql"
+	"path/filepath"
+	"runtime"
 	"sync"
 	"time"
 
@@ -52,7 +54,7 @@ func TestWithMigrations() (*postgres.PostgresContainer, string, error) {
 				creationError = cerr
 			}
 		}()
-		if err := goose.Up(db, "../../../migrations"); err != nil {
+		if err := goose.Up(db, migrationsDir()); err != nil {
 			creationError = err
 		}
 	})
@@ -63,3 +65,10 @@ func TestWithMigrations() (*postgres.PostgresContainer, string, error) {
 
 	return container, connStr, nil
 }
+
+// migrationsDir вычисляет путь к миграциям относительно этого файла,
+// чтобы хелпер работал из тестов на любой глубине вложенности.
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "..", "migrations")
+}
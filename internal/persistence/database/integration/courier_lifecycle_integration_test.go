@@ -0,0 +1,168 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"courier-service/internal/model"
+	integration "courier-service/internal/persistence/database/integration"
+	auditstorage "courier-service/internal/repository/audit"
+	courierstorage "courier-service/internal/repository/courier"
+	"courier-service/internal/repository/dbrouter"
+	deliverystorage "courier-service/internal/repository/delivery"
+	txrunner "courier-service/internal/repository/txrunner"
+	auditusecase "courier-service/internal/usecase/audit"
+	courierusecase "courier-service/internal/usecase/courier"
+	complete "courier-service/internal/usecase/delivery/complete"
+	unassign "courier-service/internal/usecase/delivery/unassign"
+	deliverycalculator "courier-service/internal/usecase/utils"
+	metrics "courier-service/pkg/metrics/prometheus"
+)
+
+// CourierLifecycleTestSuite проверяет, что удаление и деактивация курьера
+// не расходятся с его незавершенными доставками.
+type CourierLifecycleTestSuite struct {
+	suite.Suite
+	ctx          context.Context
+	pool         *pgxpool.Pool
+	courierRepo  *courierstorage.CourierRepository
+	deliveryRepo *deliverystorage.DeliveryRepository
+	couriers     *courierusecase.CourierUseCase
+	complete     *complete.CompleteDeliveryUseCase
+	unassign     *unassign.UnassignDelieveryUseCase
+}
+
+func TestCourierLifecycleTestSuite(t *testing.T) {
+	suite.Run(t, new(CourierLifecycleTestSuite))
+}
+
+func (s *CourierLifecycleTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	_, connStr, err := integration.TestWithMigrations()
+	s.Require().NoError(err)
+
+	pool, err := pgxpool.New(s.ctx, connStr)
+	s.Require().NoError(err)
+	s.pool = pool
+
+	router := dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil)
+	logger := zap.NewNop().Sugar()
+	s.courierRepo = courierstorage.NewCourierRepository(router, logger)
+	s.deliveryRepo = deliverystorage.NewDeliveryRepository(router)
+	txRunner := txrunner.NewTxRunner(s.pool, txrunner.Config{}, logger)
+	recorder := auditusecase.NewRecorder(auditstorage.NewAuditRepository(router))
+
+	s.couriers = courierusecase.NewCourierUseCase(
+		s.courierRepo,
+		txRunner,
+		recorder,
+		deliverycalculator.NewTimeCalculatorFactory(),
+		metrics.NewBusinessMetricsWriter(metrics.NewBusinessMetrics(prometheus.NewRegistry())),
+		logger,
+	)
+	s.complete = complete.NewCompleteDeliveryUseCase(s.courierRepo, s.deliveryRepo, txRunner)
+	s.unassign = unassign.NewUnassignDelieveryUseCase(s.courierRepo, s.deliveryRepo, txRunner, recorder)
+}
+
+func (s *CourierLifecycleTestSuite) TearDownSuite() {
+	s.pool.Close()
+}
+
+func (s *CourierLifecycleTestSuite) SetupTest() {
+	s.Require().NoError(integration.TruncateAll(s.ctx, s.pool))
+}
+
+// seedFreedByDeadline создает курьера, которого проверка дедлайнов уже
+// освободила, хотя его доставка еще не завершена.
+func (s *CourierLifecycleTestSuite) seedFreedByDeadline() (int64, string) {
+	courierID, err := s.courierRepo.CreateCourier(s.ctx, model.Courier{
+		Name:          "Lifecycle Courier",
+		Phone:         "+79990000031",
+		Status:        model.CourierStatusBusy,
+		TransportType: model.TransportTypeCar,
+	})
+	s.Require().NoError(err)
+
+	orderID := uuid.New().String()
+	_, err = s.pool.Exec(s.ctx,
+		"INSERT INTO delivery (courier_id, order_id, assigned_at, deadline) VALUES ($1, $2, NOW() - INTERVAL '2 hours', NOW() - INTERVAL '1 hour')",
+		courierID, orderID)
+	s.Require().NoError(err)
+
+	freed, err := s.courierRepo.FreeCouriersWithInterval(s.ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), freed)
+	return courierID, orderID
+}
+
+func (s *CourierLifecycleTestSuite) courier(id int64) model.Courier {
+	courier, err := s.courierRepo.GetCourierForUpdate(s.ctx, id)
+	s.Require().NoError(err)
+	return courier
+}
+
+func (s *CourierLifecycleTestSuite) TestOpenDeliveryBlocksDeleteAndDeactivate() {
+	courierID, _ := s.seedFreedByDeadline()
+
+	s.ErrorIs(s.couriers.DeactivateCourier(s.ctx, courierID), courierusecase.ErrCourierOnDelivery)
+	s.ErrorIs(s.couriers.DeleteCourier(s.ctx, courierID), courierusecase.ErrCourierOnDelivery)
+	s.ErrorIs(s.couriers.EraseCourier(s.ctx, courierID), courierusecase.ErrCourierOnDelivery)
+
+	courier := s.courier(courierID)
+	s.False(courier.IsDeleted())
+	s.Equal(model.CourierStatusAvailable, courier.Status)
+}
+
+func (s *CourierLifecycleTestSuite) TestDeactivateThenComplete() {
+	courierID, orderID := s.seedFreedByDeadline()
+
+	s.Require().NoError(s.complete.Complete(s.ctx, orderID))
+	s.Require().NoError(s.couriers.DeactivateCourier(s.ctx, courierID))
+
+	// повторное событие о завершении не возвращает курьера на линию
+	s.Require().NoError(s.complete.Complete(s.ctx, orderID))
+	s.Equal(model.CourierStatusInactive, s.courier(courierID).Status)
+
+	// то же для курьера, деактивированного до завершения заказа
+	s.Require().NoError(s.couriers.ReactivateCourier(s.ctx, courierID))
+	_, err := s.pool.Exec(s.ctx,
+		"UPDATE delivery SET completed_at = NULL WHERE order_id = $1", orderID)
+	s.Require().NoError(err)
+	_, err = s.pool.Exec(s.ctx, "UPDATE couriers SET status = 'inactive' WHERE id = $1", courierID)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.complete.Complete(s.ctx, orderID))
+	s.Equal(model.CourierStatusInactive, s.courier(courierID).Status)
+}
+
+func (s *CourierLifecycleTestSuite) TestDeleteThenUnassign() {
+	courierID, orderID := s.seedFreedByDeadline()
+
+	s.ErrorIs(s.couriers.DeleteCourier(s.ctx, courierID), courierusecase.ErrCourierOnDelivery)
+
+	// курьер, удаленный до этой проверки, не мешает снять заказ
+	_, err := s.pool.Exec(s.ctx,
+		"UPDATE couriers SET status = 'inactive', deleted_at = NOW() WHERE id = $1", courierID)
+	s.Require().NoError(err)
+
+	unassignedCourierID, err := s.unassign.Unassign(s.ctx, orderID)
+	s.Require().NoError(err)
+	s.Equal(courierID, unassignedCourierID)
+
+	_, err = s.deliveryRepo.CouriersDelivery(s.ctx, orderID)
+	s.ErrorIs(err, deliverystorage.ErrOrderIDNotFound)
+
+	courier := s.courier(courierID)
+	s.True(courier.IsDeleted())
+	s.Equal(model.CourierStatusInactive, courier.Status)
+}
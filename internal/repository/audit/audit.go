@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"courier-service/internal/model"
+	"courier-service/internal/repository/dbrouter"
+	"courier-service/internal/repository/executor"
+	db "courier-service/internal/repository/utils/database"
+)
+
+// AuditRepository — журнал аудита. Записи только добавляются: изменение и
+// удаление запрещены триггером в базе.
+type AuditRepository struct {
+	exec *executor.Executor
+}
+
+func NewAuditRepository(db *dbrouter.Router) *AuditRepository {
+	return &AuditRepository{exec: executor.New(db)}
+}
+
+// Append добавляет запись в журнал; внутри транзакции PgxTxRunner запись
+// фиксируется и откатывается вместе с действием, которое она описывает.
+func (r *AuditRepository) Append(ctx context.Context, entry model.AuditEntry) error {
+	changes := entry.Changes
+	if changes == nil {
+		changes = map[string]model.AuditChange{}
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("marshal audit changes: %w", err)
+	}
+
+	var requestID *string
+	if entry.RequestID != "" {
+		requestID = &entry.RequestID
+	}
+
+	queryBuilder := sq.
+		Insert(db.AuditLogTable).
+		Columns(db.ActorColumn, db.ActionColumn, db.EntityTypeColumn, db.EntityIDColumn, db.ChangesColumn, db.RequestIDColumn).
+		Values(entry.Actor, entry.Action, entry.EntityType, entry.EntityID, changesJSON, requestID).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.exec.Writer(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// List возвращает записи журнала по фильтру, новые первыми.
+func (r *AuditRepository) List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEntry, error) {
+	queryBuilder := sq.
+		Select(db.IDColumn, db.OccurredAtColumn, db.ActorColumn, db.ActionColumn, db.EntityTypeColumn, db.EntityIDColumn, db.ChangesColumn, db.RequestIDColumn).
+		From(db.AuditLogTable).
+		OrderBy(db.OccurredAtColumn+" DESC", db.IDColumn+" DESC").
+		PlaceholderFormat(sq.Dollar)
+
+	if filter.EntityType != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{db.EntityTypeColumn: filter.EntityType})
+	}
+	if filter.EntityID != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{db.EntityIDColumn: filter.EntityID})
+	}
+	if !filter.From.IsZero() {
+		queryBuilder = queryBuilder.Where(sq.GtOrEq{db.OccurredAtColumn: filter.From})
+	}
+	if !filter.To.IsZero() {
+		queryBuilder = queryBuilder.Where(sq.Lt{db.OccurredAtColumn: filter.To})
+	}
+	if filter.Limit > 0 {
+		queryBuilder = queryBuilder.Limit(uint64(filter.Limit))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.exec.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []model.AuditEntry
+	for rows.Next() {
+		var (
+			entry       model.AuditEntry
+			changesJSON []byte
+			requestID   *string
+		)
+		err := rows.Scan(&entry.ID, &entry.OccurredAt, &entry.Actor, &entry.Action, &entry.EntityType, &entry.EntityID, &changesJSON, &requestID)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changesJSON, &entry.Changes); err != nil {
+			return nil, fmt.Errorf("unmarshal audit changes: %w", err)
+		}
+		if requestID != nil {
+			entry.RequestID = *requestID
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
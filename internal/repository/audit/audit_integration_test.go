@@ -0,0 +1,117 @@
+//go:build integration
+// +build integration
+
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+
+	"courier-service/internal/model"
+	integration "courier-service/internal/persistence/database/integration"
+	auditstorage "courier-service/internal/repository/audit"
+	"courier-service/internal/repository/dbrouter"
+)
+
+type AuditTestSuite struct {
+	suite.Suite
+	ctx         context.Context
+	pool        *pgxpool.Pool
+	auditRepo   *auditstorage.AuditRepository
+	pgContainer *postgres.PostgresContainer
+}
+
+func TestAuditRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditTestSuite))
+}
+
+func (s *AuditTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	container, connStr, err := integration.TestWithMigrations()
+	s.Require().NoError(err)
+	s.pgContainer = container
+
+	pool, err := pgxpool.New(s.ctx, connStr)
+	s.Require().NoError(err)
+	s.pool = pool
+	s.auditRepo = auditstorage.NewAuditRepository(dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil))
+}
+
+func (s *AuditTestSuite) TearDownSuite() {
+	s.pool.Close()
+}
+
+func (s *AuditTestSuite) SetupTest() {
+	s.Require().NoError(integration.TruncateAll(s.ctx, s.pool))
+}
+
+func (s *AuditTestSuite) append(action model.AuditAction, entity model.AuditEntity, entityID string) {
+	err := s.auditRepo.Append(s.ctx, model.AuditEntry{
+		Actor:      "admin",
+		Action:     action,
+		EntityType: entity,
+		EntityID:   entityID,
+		Changes: map[string]model.AuditChange{
+			"status": {Before: "available", After: "inactive"},
+		},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *AuditTestSuite) TestAppendAndList() {
+	s.append(model.AuditActionCourierCreate, model.AuditEntityCourier, "1")
+	s.append(model.AuditActionCourierDeactivate, model.AuditEntityCourier, "1")
+	s.append(model.AuditActionCourierCreate, model.AuditEntityCourier, "2")
+	s.append(model.AuditActionDeliveryAssign, model.AuditEntityDelivery, "order-1")
+
+	entries, err := s.auditRepo.List(s.ctx, model.AuditFilter{EntityType: model.AuditEntityCourier, EntityID: "1"})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 2)
+	s.Equal(model.AuditActionCourierDeactivate, entries[0].Action, "newest entries come first")
+	s.Equal(model.AuditActionCourierCreate, entries[1].Action)
+	s.Equal("admin", entries[0].Actor)
+	s.Empty(entries[0].RequestID)
+	s.Equal(model.AuditChange{Before: "available", After: "inactive"}, entries[0].Changes["status"])
+
+	entries, err = s.auditRepo.List(s.ctx, model.AuditFilter{EntityType: model.AuditEntityCourier})
+	s.Require().NoError(err)
+	s.Len(entries, 3)
+
+	entries, err = s.auditRepo.List(s.ctx, model.AuditFilter{Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Equal(model.AuditActionDeliveryAssign, entries[0].Action)
+}
+
+func (s *AuditTestSuite) TestListTimeRange() {
+	s.append(model.AuditActionCourierCreate, model.AuditEntityCourier, "1")
+
+	entries, err := s.auditRepo.List(s.ctx, model.AuditFilter{From: time.Now().Add(-time.Minute)})
+	s.Require().NoError(err)
+	s.Len(entries, 1)
+
+	entries, err = s.auditRepo.List(s.ctx, model.AuditFilter{To: time.Now().Add(-time.Minute)})
+	s.Require().NoError(err)
+	s.Empty(entries)
+}
+
+func (s *AuditTestSuite) TestAppendOnly() {
+	s.append(model.AuditActionCourierCreate, model.AuditEntityCourier, "1")
+
+	_, err := s.pool.Exec(s.ctx, "UPDATE audit_log SET actor = 'intruder'")
+	s.Error(err, "audit_log rows must not be updated")
+
+	_, err = s.pool.Exec(s.ctx, "DELETE FROM audit_log")
+	s.Error(err, "audit_log rows must not be deleted")
+
+	entries, err := s.auditRepo.List(s.ctx, model.AuditFilter{})
+	s.Require().NoError(err)
+	s.Require().Len(entries, 1)
+	s.Equal("admin", entries[0].Actor)
+}
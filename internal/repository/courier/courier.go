@@ -4,32 +4,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"courier-service/internal/model"
+	"courier-service/internal/repository/dbrouter"
 	entity "courier-service/internal/repository/entity"
+	"courier-service/internal/repository/executor"
 	db "courier-service/internal/repository/utils/database"
+	"courier-service/pkg/database/pgerrors"
 )
 
+// constraints переводит нарушения ограничений couriers в ошибки репозитория.
+var constraints = pgerrors.Constraints{
+	db.CourierPhoneKey: ErrPhoneNumberExists,
+}
+
 type CourierRepository struct {
-	pool   *pgxpool.Pool
+	exec   *executor.Executor
 	logger logger
 }
 
-func NewCourierRepository(pool *pgxpool.Pool, logger logger) *CourierRepository {
-	return &CourierRepository{pool: pool, logger: logger}
+func NewCourierRepository(db *dbrouter.Router, logger logger) *CourierRepository {
+	return &CourierRepository{exec: executor.New(db), logger: logger}
 }
 
 func (r *CourierRepository) GetCourierById(ctx context.Context, id int64) (model.Courier, error) {
 	queryBuilder := sq.
 		Select(db.IDColumn, db.NameColumn, db.PhoneColumn, db.StatusColumn, db.TransportTypeColumn, db.CreatedAtColumn, db.UpdatedAtColumn).
 		From(db.CourierTable).
-		Where(sq.Eq{db.IDColumn: id}). //
+		Where(sq.Eq{db.IDColumn: id, db.DeletedAtColumn: nil}).
 		PlaceholderFormat(sq.Dollar)
 
 	query, args, err := queryBuilder.ToSql()
@@ -40,7 +46,7 @@ func (r *CourierRepository) GetCourierById(ctx context.Context, id int64) (model
 
 	var c entity.CourierDB
 
-	err = r.pool.QueryRow(ctx, query, args...).Scan(
+	err = r.exec.Reader(ctx).QueryRow(ctx, query, args...).Scan(
 		&c.ID, &c.Name, &c.Phone, &c.Status, &c.TransportType, &c.CreatedAt, &c.UpdatedAt,
 	)
 
@@ -58,6 +64,7 @@ func (r *CourierRepository) GetAllCouriers(ctx context.Context) ([]model.Courier
 	queryBuilder := sq.
 		Select(db.IDColumn, db.NameColumn, db.PhoneColumn, db.StatusColumn, db.TransportTypeColumn, db.CreatedAtColumn, db.UpdatedAtColumn).
 		From(db.CourierTable).
+		Where(sq.Eq{db.DeletedAtColumn: nil}).
 		PlaceholderFormat(sq.Dollar)
 
 	query, args, err := queryBuilder.ToSql()
@@ -65,7 +72,7 @@ func (r *CourierRepository) GetAllCouriers(ctx context.Context) ([]model.Courier
 		return nil, err
 	}
 
-	rows, err := r.pool.Query(ctx, query, args...)
+	rows, err := r.exec.Reader(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,12 +105,9 @@ func (r *CourierRepository) CreateCourier(ctx context.Context, courier model.Cou
 		return 0, err
 	}
 
-	err = r.pool.QueryRow(ctx, query, args...).Scan(&id)
+	err = r.exec.Writer(ctx).QueryRow(ctx, query, args...).Scan(&id)
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key value") {
-			return 0, ErrPhoneNumberExists
-		}
-		return 0, fmt.Errorf("database error: %w", err)
+		return 0, fmt.Errorf("database error: %w", pgerrors.Translate(err, constraints))
 	}
 
 	return id, nil
@@ -130,7 +134,7 @@ func (r *CourierRepository) UpdateCourier(ctx context.Context, courier model.Cou
 	queryBuilder := sq.
 		Update(db.CourierTable).
 		SetMap(sets).
-		Where(sq.Eq{db.IDColumn: courier.ID}).
+		Where(sq.Eq{db.IDColumn: courier.ID, db.DeletedAtColumn: nil}).
 		PlaceholderFormat(sq.Dollar)
 
 	query, args, err := queryBuilder.ToSql()
@@ -139,9 +143,9 @@ func (r *CourierRepository) UpdateCourier(ctx context.Context, courier model.Cou
 		return err
 	}
 
-	result, err := r.pool.Exec(ctx, query, args...)
+	result, err := r.exec.Writer(ctx).Exec(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("database error: %w", err)
+		return fmt.Errorf("database error: %w", pgerrors.Translate(err, constraints))
 	}
 
 	if result.RowsAffected() == 0 {
@@ -164,7 +168,9 @@ func (r *CourierRepository) FindAvailableCourier(ctx context.Context) (model.Cou
 		return model.Courier{}, err
 	}
 
-	// Основной запрос
+	// Основной запрос. Выбранная строка блокируется до конца транзакции,
+	// а уже заблокированные конкурентными назначениями курьеры пропускаются.
+	// NO KEY UPDATE не конфликтует с FK-проверкой при вставке в delivery.
 	queryBuilder := sq.
 		Select(db.CourierID, db.CourierName, db.CourierPhone, db.CourierStatus, db.CourierTransportType).
 		From(db.CourierTable).
@@ -173,9 +179,10 @@ func (r *CourierRepository) FindAvailableCourier(ctx context.Context) (model.Cou
 			db.CourierIDColumn,
 			db.CourierID,
 		), subqueryArgs...).
-		Where(sq.Eq{db.CourierStatus: db.StatusAvailable}).
+		Where(sq.Eq{db.CourierStatus: db.StatusAvailable, db.CourierDeletedAt: nil}).
 		OrderBy("COALESCE(d.cnt, 0) ASC").
 		Limit(1).
+		Suffix(db.BuildLockingClause(db.LockNoKeyUpdate, db.CourierTable, true)).
 		PlaceholderFormat(sq.Dollar)
 
 	query, args, err := queryBuilder.ToSql()
@@ -184,7 +191,7 @@ func (r *CourierRepository) FindAvailableCourier(ctx context.Context) (model.Cou
 	}
 
 	var c entity.CourierDB
-	err = r.pool.QueryRow(ctx, query, args...).
+	err = r.exec.Writer(ctx).QueryRow(ctx, query, args...).
 		Scan(&c.ID, &c.Name, &c.Phone, &c.Status, &c.TransportType)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -196,7 +203,144 @@ func (r *CourierRepository) FindAvailableCourier(ctx context.Context) (model.Cou
 	return c.ToModel(), nil
 }
 
-func (r *CourierRepository) FreeCouriersWithInterval(ctx context.Context) error {
+// GetCourierForUpdate возвращает курьера, в том числе удаленного, и
+// блокирует его строку до конца транзакции.
+func (r *CourierRepository) GetCourierForUpdate(ctx context.Context, id int64) (model.Courier, error) {
+	queryBuilder := sq.
+		Select(db.IDColumn, db.NameColumn, db.PhoneColumn, db.StatusColumn, db.TransportTypeColumn, db.CreatedAtColumn, db.UpdatedAtColumn, db.DeletedAtColumn).
+		From(db.CourierTable).
+		Where(sq.Eq{db.IDColumn: id}).
+		Suffix(db.BuildLockingClause(db.LockNoKeyUpdate, "", false)).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return model.Courier{}, err
+	}
+
+	var c entity.CourierDB
+	err = r.exec.Writer(ctx).QueryRow(ctx, query, args...).Scan(
+		&c.ID, &c.Name, &c.Phone, &c.Status, &c.TransportType, &c.CreatedAt, &c.UpdatedAt, &c.DeletedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Courier{}, ErrCourierNotFound
+	}
+	if err != nil {
+		return model.Courier{}, err
+	}
+
+	return c.ToModel(), nil
+}
+
+// DeleteCourier мягко удаляет курьера: строка остается для истории
+// доставок, но курьер пропадает из выборок и не получает заказы.
+func (r *CourierRepository) DeleteCourier(ctx context.Context, id int64) error {
+	now := time.Now()
+	queryBuilder := sq.
+		Update(db.CourierTable).
+		SetMap(sq.Eq{
+			db.StatusColumn:    db.StatusInactive,
+			db.DeletedAtColumn: now,
+			db.UpdatedAtColumn: now,
+		}).
+		Where(sq.Eq{db.IDColumn: id, db.DeletedAtColumn: nil}).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.exec.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCourierNotFound
+	}
+	return nil
+}
+
+// EraseCourier обезличивает курьера: имя и телефон заменяются, курьер
+// считается удаленным. Доставки курьера остаются для отчетности.
+func (r *CourierRepository) EraseCourier(ctx context.Context, id int64) error {
+	now := time.Now()
+	queryBuilder := sq.
+		Update(db.CourierTable).
+		SetMap(sq.Eq{
+			db.NameColumn:      db.ErasedName,
+			db.PhoneColumn:     sq.Expr("?::text || "+db.IDColumn, db.ErasedPhonePrefix),
+			db.StatusColumn:    db.StatusInactive,
+			db.DeletedAtColumn: sq.Expr("COALESCE("+db.DeletedAtColumn+", ?)", now),
+			db.ErasedAtColumn:  sq.Expr("COALESCE("+db.ErasedAtColumn+", ?)", now),
+			db.UpdatedAtColumn: now,
+		}).
+		Where(sq.Eq{db.IDColumn: id}).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.exec.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrCourierNotFound
+	}
+	return nil
+}
+
+// ReleaseCourier возвращает на линию курьера, занятого доставкой.
+// Деактивированного, удаленного или уже свободного курьера не меняет.
+func (r *CourierRepository) ReleaseCourier(ctx context.Context, id int64) error {
+	queryBuilder := sq.
+		Update(db.CourierTable).
+		SetMap(sq.Eq{
+			db.StatusColumn:    db.StatusAvailable,
+			db.UpdatedAtColumn: time.Now(),
+		}).
+		Where(sq.Eq{db.IDColumn: id, db.StatusColumn: db.StatusBusy, db.DeletedAtColumn: nil}).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.exec.Writer(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// HasOpenDelivery сообщает, есть ли у курьера незавершенная доставка.
+// Статус для этого не подходит: по дедлайну курьер освобождается раньше,
+// чем завершается заказ.
+func (r *CourierRepository) HasOpenDelivery(ctx context.Context, id int64) (bool, error) {
+	queryBuilder := sq.
+		Select(db.CountAll).
+		From(db.DeliveryTable).
+		Where(sq.Eq{db.CourierIDColumn: id, db.CompletedAtColumn: nil}).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	if err := r.exec.Writer(ctx).QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return false, fmt.Errorf("database error: %w", err)
+	}
+	return count > 0, nil
+}
+
+// FreeCouriersWithInterval освобождает курьеров, у которых истек дедлайн
+// последней доставки, и возвращает их число.
+func (r *CourierRepository) FreeCouriersWithInterval(ctx context.Context) (int64, error) {
 	sm := sq.Eq{
 		db.UpdatedAtColumn: time.Now(),
 		db.StatusColumn:    db.StatusAvailable,
@@ -223,21 +367,56 @@ func (r *CourierRepository) FreeCouriersWithInterval(ctx context.Context) error
 
 	query, args, err := queryBuilder.ToSql()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	ct, err := r.pool.Exec(ctx, query, args...)
+	ct, err := r.exec.Writer(ctx).Exec(ctx, query, args...)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	if ct.RowsAffected() > 0 {
 		r.logger.Debugf("FreeCouriers updated rows: %d", ct.RowsAffected())
 	}
 
-	return nil
+	return ct.RowsAffected(), nil
+}
+
+// CountAvailableCouriersByTransport возвращает число свободных курьеров
+// по типу транспорта; типы без свободных курьеров в результат не попадают.
+func (r *CourierRepository) CountAvailableCouriersByTransport(ctx context.Context) (map[model.CourierTransportType]int, error) {
+	queryBuilder := sq.
+		Select(db.TransportTypeColumn, db.CountAll).
+		From(db.CourierTable).
+		Where(sq.Eq{db.StatusColumn: db.StatusAvailable}).
+		GroupBy(db.TransportTypeColumn).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.exec.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[model.CourierTransportType]int)
+	for rows.Next() {
+		var transportType string
+		var count int
+		if err := rows.Scan(&transportType, &count); err != nil {
+			return nil, err
+		}
+		counts[model.CourierTransportType(transportType)] = count
+	}
+	return counts, rows.Err()
 }
 
+// ExistsCourierByPhone учитывает и удаленных курьеров: их телефон остается
+// занят, пока данные курьера не стерты.
 func (r *CourierRepository) ExistsCourierByPhone(ctx context.Context, phone string) (bool, error) {
 	queryBuilder := sq.
 		Select(db.CountAll).
@@ -251,7 +430,7 @@ func (r *CourierRepository) ExistsCourierByPhone(ctx context.Context, phone stri
 	}
 
 	var count int64
-	err = r.pool.QueryRow(ctx, query, args...).Scan(&count)
+	err = r.exec.Reader(ctx).QueryRow(ctx, query, args...).Scan(&count)
 	if err != nil {
 		return false, err
 	}
@@ -272,7 +451,7 @@ func (r *CourierRepository) GetCourierIDByOrderID(ctx context.Context, orderID s
 	}
 
 	var courierID int64
-	err = r.pool.QueryRow(ctx, query, args...).Scan(&courierID)
+	err = r.exec.Reader(ctx).QueryRow(ctx, query, args...).Scan(&courierID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return 0, ErrOrderNotFound
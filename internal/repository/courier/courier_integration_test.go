@@ -5,6 +5,7 @@ package courier_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"courier-service/internal/model"
 	"courier-service/internal/persistence/database/integration"
 	courierstorage "courier-service/internal/repository/courier"
+	"courier-service/internal/repository/dbrouter"
 )
 
 type CourierTestSuite struct {
@@ -56,7 +58,7 @@ func (s *CourierTestSuite) SetupTest() {
 	s.mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 	s.mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
 
-	s.repo = courierstorage.NewCourierRepository(s.pool, s.mockLogger)
+	s.repo = courierstorage.NewCourierRepository(dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil), s.mockLogger)
 
 	err := integration.TruncateAll(s.ctx, s.pool)
 	s.Require().NoError(err)
@@ -478,8 +480,13 @@ func (s *CourierTestSuite) TestFreeCouriers() {
 			tt.setupDeliveries(id)
 
 			// вызываем освобождение
-			err = s.repo.FreeCouriersWithInterval(ctx)
+			freed, err := s.repo.FreeCouriersWithInterval(ctx)
 			s.Require().NoError(err)
+			if tt.expectedStatus == model.CourierStatusAvailable {
+				s.Equal(int64(1), freed)
+			} else {
+				s.Zero(freed)
+			}
 
 			// проверяем статус
 			updated, err := s.repo.GetCourierById(ctx, id)
@@ -488,3 +495,170 @@ func (s *CourierTestSuite) TestFreeCouriers() {
 		})
 	}
 }
+
+func (s *CourierTestSuite) TestCountAvailableByTransport() {
+	ctx := context.Background()
+
+	couriers := []model.Courier{
+		{Name: "John", Phone: "+79991234567", Status: model.CourierStatusAvailable, TransportType: model.TransportTypeCar},
+		{Name: "Jane", Phone: "+79991234568", Status: model.CourierStatusAvailable, TransportType: model.TransportTypeCar},
+		{Name: "Bob", Phone: "+79991234569", Status: model.CourierStatusBusy, TransportType: model.TransportTypeScooter},
+		{Name: "Ann", Phone: "+79991234570", Status: model.CourierStatusAvailable, TransportType: model.TransportTypeOnFoot},
+	}
+	for _, c := range couriers {
+		_, err := s.repo.CreateCourier(ctx, c)
+		s.Require().NoError(err)
+	}
+
+	counts, err := s.repo.CountAvailableCouriersByTransport(ctx)
+
+	s.Require().NoError(err)
+	s.Equal(map[model.CourierTransportType]int{
+		model.TransportTypeCar:    2,
+		model.TransportTypeOnFoot: 1,
+	}, counts)
+}
+
+func (s *CourierTestSuite) TestDelete_HidesCourier() {
+	ctx := context.Background()
+
+	id, err := s.repo.CreateCourier(ctx, model.Courier{
+		Name:          "John Doe",
+		Phone:         "+79990000010",
+		Status:        model.CourierStatusAvailable,
+		TransportType: "car",
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.repo.DeleteCourier(ctx, id))
+
+	_, err = s.repo.GetCourierById(ctx, id)
+	s.ErrorIs(err, courierstorage.ErrCourierNotFound)
+
+	couriers, err := s.repo.GetAllCouriers(ctx)
+	s.Require().NoError(err)
+	s.Empty(couriers)
+
+	_, err = s.repo.FindAvailableCourier(ctx)
+	s.ErrorIs(err, courierstorage.ErrCouriersBusy)
+
+	err = s.repo.UpdateCourier(ctx, model.Courier{ID: id, Name: "Jane Doe"})
+	s.ErrorIs(err, courierstorage.ErrCourierNotFound)
+
+	s.ErrorIs(s.repo.DeleteCourier(ctx, id), courierstorage.ErrCourierNotFound)
+
+	// строка остается: удаленного курьера видно под блокировкой
+	locked, err := s.repo.GetCourierForUpdate(ctx, id)
+	s.Require().NoError(err)
+	s.True(locked.IsDeleted())
+	s.Equal(model.CourierStatusInactive, locked.Status)
+
+	exists, err := s.repo.ExistsCourierByPhone(ctx, "+79990000010")
+	s.Require().NoError(err)
+	s.True(exists, "phone of a deleted courier stays taken until erasure")
+}
+
+func (s *CourierTestSuite) TestErase_AnonymizesAndKeepsDeliveries() {
+	ctx := context.Background()
+
+	id, err := s.repo.CreateCourier(ctx, model.Courier{
+		Name:          "John Doe",
+		Phone:         "+79990000011",
+		Status:        model.CourierStatusAvailable,
+		TransportType: "car",
+	})
+	s.Require().NoError(err)
+
+	orderID := uuid.New().String()
+	_, err = s.pool.Exec(ctx,
+		"INSERT INTO delivery (courier_id, order_id, assigned_at, deadline) VALUES ($1, $2, NOW(), NOW())",
+		id, orderID)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.repo.EraseCourier(ctx, id))
+	// повторное стирание не падает на уникальности телефона
+	s.Require().NoError(s.repo.EraseCourier(ctx, id))
+
+	erased, err := s.repo.GetCourierForUpdate(ctx, id)
+	s.Require().NoError(err)
+	s.True(erased.IsDeleted())
+	s.Equal("erased", erased.Name)
+	s.Equal(fmt.Sprintf("erased-%d", id), erased.Phone)
+
+	exists, err := s.repo.ExistsCourierByPhone(ctx, "+79990000011")
+	s.Require().NoError(err)
+	s.False(exists)
+
+	courierID, err := s.repo.GetCourierIDByOrderID(ctx, orderID)
+	s.Require().NoError(err)
+	s.Equal(id, courierID, "delivery history is preserved")
+
+	s.ErrorIs(s.repo.EraseCourier(ctx, 999999), courierstorage.ErrCourierNotFound)
+}
+
+func (s *CourierTestSuite) TestReleaseCourier_OnlyBusy() {
+	ctx := context.Background()
+
+	create := func(phone string, status model.CourierStatus) int64 {
+		id, err := s.repo.CreateCourier(ctx, model.Courier{
+			Name:          "John Doe",
+			Phone:         phone,
+			Status:        status,
+			TransportType: "car",
+		})
+		s.Require().NoError(err)
+		return id
+	}
+	busy := create("+79990000020", model.CourierStatusBusy)
+	inactive := create("+79990000021", model.CourierStatusInactive)
+	deleted := create("+79990000022", model.CourierStatusBusy)
+	_, err := s.pool.Exec(ctx, "UPDATE couriers SET deleted_at = NOW() WHERE id = $1", deleted)
+	s.Require().NoError(err)
+
+	for _, id := range []int64{busy, inactive, deleted} {
+		s.Require().NoError(s.repo.ReleaseCourier(ctx, id))
+	}
+
+	for id, want := range map[int64]model.CourierStatus{
+		busy:     model.CourierStatusAvailable,
+		inactive: model.CourierStatusInactive,
+		deleted:  model.CourierStatusBusy,
+	} {
+		courier, err := s.repo.GetCourierForUpdate(ctx, id)
+		s.Require().NoError(err)
+		s.Equal(want, courier.Status)
+	}
+}
+
+func (s *CourierTestSuite) TestHasOpenDelivery() {
+	ctx := context.Background()
+
+	id, err := s.repo.CreateCourier(ctx, model.Courier{
+		Name:          "John Doe",
+		Phone:         "+79990000023",
+		Status:        model.CourierStatusAvailable,
+		TransportType: "car",
+	})
+	s.Require().NoError(err)
+
+	open, err := s.repo.HasOpenDelivery(ctx, id)
+	s.Require().NoError(err)
+	s.False(open)
+
+	_, err = s.pool.Exec(ctx,
+		"INSERT INTO delivery (courier_id, order_id, assigned_at, deadline, completed_at) VALUES ($1, $2, NOW(), NOW(), NOW())",
+		id, uuid.New().String())
+	s.Require().NoError(err)
+	open, err = s.repo.HasOpenDelivery(ctx, id)
+	s.Require().NoError(err)
+	s.False(open, "completed delivery is not open")
+
+	// доставка с истекшим дедлайном остается открытой до завершения заказа
+	_, err = s.pool.Exec(ctx,
+		"INSERT INTO delivery (courier_id, order_id, assigned_at, deadline) VALUES ($1, $2, NOW(), NOW() - INTERVAL '1 hour')",
+		id, uuid.New().String())
+	s.Require().NoError(err)
+	open, err = s.repo.HasOpenDelivery(ctx, id)
+	s.Require().NoError(err)
+	s.True(open)
+}
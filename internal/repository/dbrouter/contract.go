@@ -0,0 +1,7 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
+package dbrouter
+
+type logger interface {
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+}
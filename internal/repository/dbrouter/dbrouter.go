@@ -0,0 +1,190 @@
+package dbrouter
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// replicaStatusQuery возвращает состояние приема WAL и отставание реплики в
+// секундах. Если реплика догнала все полученные WAL, отставания нет, даже
+// когда на мастере давно не было записей, поэтому отдельно проверяется, что
+// WAL receiver подключен и получает сообщения. Поля pg_stat_wal_receiver
+// видны роли с pg_read_all_stats; без нее реплика считается не готовой.
+const replicaStatusQuery = `
+SELECT
+	pg_is_in_recovery(),
+	COALESCE(r.status = 'streaming', false),
+	EXTRACT(EPOCH FROM now() - r.last_msg_receipt_time)::float8,
+	COALESCE(
+		CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+		END, 0)::float8
+FROM (SELECT 1) AS one
+LEFT JOIN pg_stat_wal_receiver r ON true`
+
+var (
+	ErrReplicaNotStreaming = errors.New("replica WAL receiver is not streaming")
+	ErrReplicaStale        = errors.New("replica has not heard from primary")
+)
+
+// ReplicaStatus — состояние реплики по replicaStatusQuery.
+type ReplicaStatus struct {
+	// InRecovery ложно, если запрос выполнен на мастере.
+	InRecovery bool
+	Streaming  bool
+	// ReceiveAge — сколько прошло с последнего сообщения от мастера;
+	// nil, если время неизвестно.
+	ReceiveAge *time.Duration
+	ReplayLag  time.Duration
+}
+
+// Lag возвращает отставание реплики или ошибку, если реплика не получает
+// WAL: без потока от мастера нулевое отставание ничего не значит.
+func (s ReplicaStatus) Lag(maxReceiveAge time.Duration) (time.Duration, error) {
+	if !s.InRecovery {
+		return 0, nil
+	}
+	if !s.Streaming {
+		return 0, ErrReplicaNotStreaming
+	}
+	if s.ReceiveAge == nil || *s.ReceiveAge > maxReceiveAge {
+		return 0, ErrReplicaStale
+	}
+	return s.ReplayLag, nil
+}
+
+// Config задает, когда чтения можно отправлять на реплику.
+type Config struct {
+	// ReadYourWritesWindow — сколько после записи через Write чтения идут
+	// на мастер, чтобы процесс видел свои изменения.
+	ReadYourWritesWindow time.Duration
+	// MaxLag — отставание, после которого чтения возвращаются на мастер.
+	MaxLag time.Duration
+	// MaxReceiveAge — сколько реплика может не получать сообщений от
+	// мастера, прежде чем считаться отключенной.
+	MaxReceiveAge time.Duration
+	// LagProbe измеряет отставание реплики; по умолчанию запрос к реплике.
+	LagProbe func(ctx context.Context) (time.Duration, error)
+}
+
+// Router выбирает пул для запроса: записи и чтения сразу после записи идут
+// на мастер, остальные чтения — на реплику, пока она не отстает больше
+// MaxLag. Без реплики все запросы идут на мастер.
+type Router struct {
+	primary *pgxpool.Pool
+	replica *pgxpool.Pool
+	config  Config
+	now     func() time.Time
+
+	// lastWrite — время последней записи в наносекундах Unix, 0 — записей не было
+	lastWrite atomic.Int64
+	// replicaReady — последний замер отставания успешен и в пределах MaxLag
+	replicaReady atomic.Bool
+}
+
+// NewRouter создает роутер; replica может быть nil.
+func NewRouter(primary, replica *pgxpool.Pool, config Config, now func() time.Time) *Router {
+	if now == nil {
+		now = time.Now
+	}
+	if config.LagProbe == nil && replica != nil {
+		maxReceiveAge := config.MaxReceiveAge
+		config.LagProbe = func(ctx context.Context) (time.Duration, error) {
+			status, err := queryReplicaStatus(ctx, replica)
+			if err != nil {
+				return 0, err
+			}
+			return status.Lag(maxReceiveAge)
+		}
+	}
+	return &Router{primary: primary, replica: replica, config: config, now: now}
+}
+
+func queryReplicaStatus(ctx context.Context, replica *pgxpool.Pool) (ReplicaStatus, error) {
+	var (
+		status     ReplicaStatus
+		receiveAge *float64
+		replayLag  float64
+	)
+	err := replica.QueryRow(ctx, replicaStatusQuery).Scan(&status.InRecovery, &status.Streaming, &receiveAge, &replayLag)
+	if err != nil {
+		return ReplicaStatus{}, err
+	}
+	if receiveAge != nil {
+		age := seconds(*receiveAge)
+		status.ReceiveAge = &age
+	}
+	status.ReplayLag = seconds(replayLag)
+	return status, nil
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// Primary возвращает пул мастера без учета записи, например для транзакций.
+func (r *Router) Primary() *pgxpool.Pool {
+	return r.primary
+}
+
+// Write возвращает пул мастера и открывает окно read-your-writes.
+func (r *Router) Write() *pgxpool.Pool {
+	r.lastWrite.Store(r.now().UnixNano())
+	return r.primary
+}
+
+// Read возвращает пул для запроса только на чтение.
+func (r *Router) Read() *pgxpool.Pool {
+	if r.replica == nil || !r.replicaReady.Load() {
+		return r.primary
+	}
+	if last := r.lastWrite.Load(); last != 0 && r.now().Sub(time.Unix(0, last)) < r.config.ReadYourWritesWindow {
+		return r.primary
+	}
+	return r.replica
+}
+
+// CheckLag измеряет отставание реплики. При ошибке или отставании больше
+// MaxLag чтения идут на мастер до следующего успешного замера.
+func (r *Router) CheckLag(ctx context.Context) (time.Duration, error) {
+	if r.replica == nil {
+		return 0, nil
+	}
+	lag, err := r.config.LagProbe(ctx)
+	r.replicaReady.Store(err == nil && lag <= r.config.MaxLag)
+	return lag, err
+}
+
+// MonitorLag проверяет отставание реплики раз в interval до отмены ctx и
+// логирует переключения чтений между репликой и мастером.
+func (r *Router) MonitorLag(ctx context.Context, interval time.Duration, l logger) {
+	if r.replica == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		wasReady := r.replicaReady.Load()
+		lag, err := r.CheckLag(ctx)
+		switch ready := r.replicaReady.Load(); {
+		case ready && !wasReady:
+			l.Infof("Read queries routed to replica (lag %s)", lag)
+		case !ready && wasReady && err != nil:
+			l.Warnf("Replica lag check failed, read queries routed to primary: %v", err)
+		case !ready && wasReady:
+			l.Warnf("Replica lag %s exceeds %s, read queries routed to primary", lag, r.config.MaxLag)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
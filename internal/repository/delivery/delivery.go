@@ -4,29 +4,43 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 
 	"courier-service/internal/model"
+	"courier-service/internal/repository/dbrouter"
+	"courier-service/internal/repository/entity"
+	"courier-service/internal/repository/executor"
 	db "courier-service/internal/repository/utils/database"
+	"courier-service/pkg/database/pgerrors"
 )
 
+// constraints переводит нарушения ограничений delivery в ошибки репозитория.
+var constraints = pgerrors.Constraints{
+	db.DeliveryOrderIDKey:    ErrOrderIDExists,
+	db.DeliveryCourierIDFkey: ErrCourierNotFound,
+}
+
 type DeliveryRepository struct {
-	pool *pgxpool.Pool
+	exec *executor.Executor
 }
 
-func NewDeliveryRepository(pool *pgxpool.Pool) *DeliveryRepository {
-	return &DeliveryRepository{pool: pool}
+func NewDeliveryRepository(db *dbrouter.Router) *DeliveryRepository {
+	return &DeliveryRepository{exec: executor.New(db)}
 }
 
 func (r *DeliveryRepository) CreateDelivery(ctx context.Context, delivery model.Delivery) (model.Delivery, error) {
+	snapshot, err := entity.MarshalOrderSnapshot(delivery.Order)
+	if err != nil {
+		return model.Delivery{}, err
+	}
+
 	queryBuilder := sq.
 		Insert(db.DeliveryTable).
-		Columns(db.OrderIDColumn, db.CourierIDColumn, db.AssignedAtColumn, db.DeadlineColumn).
-		Values(delivery.OrderID, delivery.CourierID, delivery.AssignedAt, delivery.Deadline).
+		Columns(db.OrderIDColumn, db.CourierIDColumn, db.AssignedAtColumn, db.DeadlineColumn, db.OrderSnapshotColumn).
+		Values(delivery.OrderID, delivery.CourierID, delivery.AssignedAt, delivery.Deadline, snapshot).
 		Suffix(db.BuildReturningStatement(db.IDColumn, db.CourierIDColumn, db.OrderIDColumn, db.DeadlineColumn)).
 		PlaceholderFormat(sq.Dollar)
 
@@ -35,15 +49,12 @@ func (r *DeliveryRepository) CreateDelivery(ctx context.Context, delivery model.
 		return model.Delivery{}, err
 	}
 
-	err = r.pool.QueryRow(ctx, query, args...).Scan(
+	err = r.exec.Writer(ctx).QueryRow(ctx, query, args...).Scan(
 		&delivery.ID, &delivery.CourierID, &delivery.OrderID, &delivery.Deadline,
 	)
 
 	if err != nil {
-		if strings.Contains(err.Error(), "duplicate key value") {
-			return model.Delivery{}, ErrOrderIDExists
-		}
-		return model.Delivery{}, err
+		return model.Delivery{}, pgerrors.Translate(err, constraints)
 	}
 
 	return delivery, nil
@@ -51,7 +62,14 @@ func (r *DeliveryRepository) CreateDelivery(ctx context.Context, delivery model.
 
 func (r *DeliveryRepository) CouriersDelivery(ctx context.Context, orderID string) (model.Delivery, error) {
 	queryBuilder := sq.
-		Select(db.DeliveryOrderID, db.CourierID).
+		Select(
+			db.DeliveryID,
+			db.DeliveryOrderID,
+			db.CourierID,
+			db.DeliveryAssignedAt,
+			db.DeliveryDeadline,
+			db.DeliveryOrderSnapshot,
+		).
 		From(db.DeliveryTable).
 		Join(fmt.Sprintf("%s ON %s = %s", db.CourierTable, db.DeliveryCourierID, db.CourierID)).
 		Where(sq.Eq{db.DeliveryOrderID: orderID}). //
@@ -62,8 +80,18 @@ func (r *DeliveryRepository) CouriersDelivery(ctx context.Context, orderID strin
 		return model.Delivery{}, err
 	}
 
-	var delivery model.Delivery
-	err = r.pool.QueryRow(ctx, query, args...).Scan(&delivery.OrderID, &delivery.CourierID)
+	var (
+		delivery model.Delivery
+		snapshot []byte
+	)
+	err = r.exec.Reader(ctx).QueryRow(ctx, query, args...).Scan(
+		&delivery.ID,
+		&delivery.OrderID,
+		&delivery.CourierID,
+		&delivery.AssignedAt,
+		&delivery.Deadline,
+		&snapshot,
+	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return model.Delivery{}, ErrOrderIDNotFound
@@ -71,6 +99,11 @@ func (r *DeliveryRepository) CouriersDelivery(ctx context.Context, orderID strin
 		return model.Delivery{}, err
 	}
 
+	delivery.Order, err = entity.UnmarshalOrderSnapshot(snapshot)
+	if err != nil {
+		return model.Delivery{}, err
+	}
+
 	return delivery, nil
 }
 
@@ -85,7 +118,7 @@ func (r *DeliveryRepository) DeleteDelivery(ctx context.Context, orderID string)
 		return err
 	}
 
-	result, err := r.pool.Exec(ctx, query, args...)
+	result, err := r.exec.Writer(ctx).Exec(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -96,3 +129,29 @@ func (r *DeliveryRepository) DeleteDelivery(ctx context.Context, orderID string)
 
 	return nil
 }
+
+// CompleteDelivery отмечает доставку завершенной и возвращает id курьера.
+// Повторное завершение не меняет время первого.
+func (r *DeliveryRepository) CompleteDelivery(ctx context.Context, orderID string) (int64, error) {
+	queryBuilder := sq.
+		Update(db.DeliveryTable).
+		Set(db.CompletedAtColumn, sq.Expr("COALESCE("+db.CompletedAtColumn+", ?)", time.Now())).
+		Where(sq.Eq{db.OrderIDColumn: orderID}).
+		Suffix(db.BuildReturningStatement(db.CourierIDColumn)).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var courierID int64
+	err = r.exec.Writer(ctx).QueryRow(ctx, query, args...).Scan(&courierID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrOrderIDNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return courierID, nil
+}
@@ -17,7 +17,9 @@ import (
 	"courier-service/internal/model"
 	integration "courier-service/internal/persistence/database/integration"
 	courierstorage "courier-service/internal/repository/courier"
+	"courier-service/internal/repository/dbrouter"
 	deliverystorage "courier-service/internal/repository/delivery"
+	"courier-service/pkg/database/pgerrors"
 )
 
 type DeliveryTestSuite struct {
@@ -41,7 +43,7 @@ func (s *DeliveryTestSuite) SetupSuite() {
 	pool, err := pgxpool.New(s.ctx, connStr)
 	s.Require().NoError(err)
 	s.pool = pool
-	s.deliveryRepo = deliverystorage.NewDeliveryRepository(s.pool)
+	s.deliveryRepo = deliverystorage.NewDeliveryRepository(dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil))
 }
 
 func (s *DeliveryTestSuite) SetupTest() {
@@ -59,7 +61,7 @@ func (s *DeliveryTestSuite) SetupTest() {
 	s.mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 	s.mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
 
-	s.courierRepo = courierstorage.NewCourierRepository(s.pool, s.mockLogger)
+	s.courierRepo = courierstorage.NewCourierRepository(dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil), s.mockLogger)
 
 	err := integration.TruncateAll(s.ctx, s.pool)
 	s.Require().NoError(err)
@@ -147,6 +149,7 @@ func (s *DeliveryTestSuite) TestCreate() {
 			},
 			expectations: func(result model.Delivery, err error) {
 				s.ErrorIs(err, deliverystorage.ErrOrderIDExists)
+				s.ErrorIs(err, pgerrors.ErrUniqueViolation)
 				s.Equal(model.Delivery{}, result)
 			},
 		},
@@ -165,7 +168,8 @@ func (s *DeliveryTestSuite) TestCreate() {
 			},
 			before: nil,
 			expectations: func(result model.Delivery, err error) {
-				s.Error(err)
+				s.ErrorIs(err, deliverystorage.ErrCourierNotFound)
+				s.ErrorIs(err, pgerrors.ErrForeignKeyViolation)
 				s.Equal(model.Delivery{}, result)
 			},
 		},
@@ -333,3 +337,75 @@ func (s *DeliveryTestSuite) TestMultipleDeliveries() {
 	s.Require().NoError(err)
 	s.Equal(courierID2, result2.CourierID)
 }
+
+func (s *DeliveryTestSuite) TestOrderSnapshot() {
+	courierID := s.createTestCourier("Courier", "+79991234569", model.TransportTypeCar)
+	now := time.Now()
+
+	withSnapshot := model.Delivery{
+		CourierID:  courierID,
+		OrderID:    uuid.New().String(),
+		AssignedAt: now,
+		Deadline:   now.Add(time.Hour),
+	}
+	withSnapshot.Order = &model.Order{
+		ID:           withSnapshot.OrderID,
+		RestaurantID: "restaurant-1",
+		Items:        []model.OrderItem{{Name: "pizza", Price: 700, Quantity: 2}},
+		TotalPrice:   1400,
+		Address:      model.OrderAddress{Street: "Tverskaya", House: "1"},
+		Status:       model.OrderStatusCreated,
+	}
+	withoutSnapshot := model.Delivery{
+		CourierID:  courierID,
+		OrderID:    uuid.New().String(),
+		AssignedAt: now,
+		Deadline:   now.Add(time.Hour),
+	}
+
+	_, err := s.deliveryRepo.CreateDelivery(context.Background(), withSnapshot)
+	s.Require().NoError(err)
+	_, err = s.deliveryRepo.CreateDelivery(context.Background(), withoutSnapshot)
+	s.Require().NoError(err)
+
+	result, err := s.deliveryRepo.CouriersDelivery(context.Background(), withSnapshot.OrderID)
+	s.Require().NoError(err)
+	s.Require().NotNil(result.Order)
+	s.Equal(withSnapshot.Order.Items, result.Order.Items)
+	s.Equal(withSnapshot.Order.Address, result.Order.Address)
+	s.Equal(int64(1400), result.Order.TotalPrice)
+
+	result, err = s.deliveryRepo.CouriersDelivery(context.Background(), withoutSnapshot.OrderID)
+	s.Require().NoError(err)
+	s.Nil(result.Order)
+}
+
+func (s *DeliveryTestSuite) TestCompleteDelivery() {
+	ctx := context.Background()
+	courierID := s.createTestCourier("Courier", "+79991234570", model.TransportTypeCar)
+	orderID := uuid.New().String()
+	now := time.Now()
+
+	_, err := s.deliveryRepo.CreateDelivery(ctx, model.Delivery{
+		CourierID:  courierID,
+		OrderID:    orderID,
+		AssignedAt: now,
+		Deadline:   now.Add(time.Hour),
+	})
+	s.Require().NoError(err)
+
+	completedCourierID, err := s.deliveryRepo.CompleteDelivery(ctx, orderID)
+	s.Require().NoError(err)
+	s.Equal(courierID, completedCourierID)
+
+	// повторное завершение не сдвигает время
+	var first, second time.Time
+	s.Require().NoError(s.pool.QueryRow(ctx, "SELECT completed_at FROM delivery WHERE order_id = $1", orderID).Scan(&first))
+	_, err = s.deliveryRepo.CompleteDelivery(ctx, orderID)
+	s.Require().NoError(err)
+	s.Require().NoError(s.pool.QueryRow(ctx, "SELECT completed_at FROM delivery WHERE order_id = $1", orderID).Scan(&second))
+	s.Equal(first, second)
+
+	_, err = s.deliveryRepo.CompleteDelivery(ctx, uuid.New().String())
+	s.ErrorIs(err, deliverystorage.ErrOrderIDNotFound)
+}
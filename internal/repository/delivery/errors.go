@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrOrderIDExists   = errors.New("order id already exists")
 	ErrOrderIDNotFound = errors.New("order id not found")
+	ErrCourierNotFound = errors.New("courier not found")
 )
@@ -7,13 +7,14 @@ import (
 )
 
 type CourierDB struct {
-	ID            int64     `db:"id"`
-	Name          string    `db:"name"`
-	Phone         string    `db:"phone"`
-	Status        string    `db:"status"`
-	TransportType string    `db:"transport_type"`
-	CreatedAt     time.Time `db:"created_at"`
-	UpdatedAt     time.Time `db:"updated_at"`
+	ID            int64      `db:"id"`
+	Name          string     `db:"name"`
+	Phone         string     `db:"phone"`
+	Status        string     `db:"status"`
+	TransportType string     `db:"transport_type"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+	DeletedAt     *time.Time `db:"deleted_at"`
 }
 
 func (c CourierDB) ToModel() model.Courier {
@@ -25,5 +26,6 @@ func (c CourierDB) ToModel() model.Courier {
 		TransportType: model.CourierTransportType(c.TransportType),
 		CreatedAt:     c.CreatedAt,
 		UpdatedAt:     c.UpdatedAt,
+		DeletedAt:     c.DeletedAt,
 	}
 }
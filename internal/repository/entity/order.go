@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"courier-service/internal/model"
+)
+
+// OrderSnapshotDB — снимок заказа в колонке delivery.order_snapshot (JSONB).
+type OrderSnapshotDB struct {
+	ID                string         `json:"id"`
+	OrderNumber       string         `json:"order_number"`
+	RestaurantID      string         `json:"restaurant_id"`
+	Items             []OrderItemDB  `json:"items"`
+	TotalPrice        int64          `json:"total_price"`
+	Address           OrderAddressDB `json:"address"`
+	Status            string         `json:"status"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	EstimatedDelivery time.Time      `json:"estimated_delivery"`
+}
+
+type OrderItemDB struct {
+	Name     string `json:"name"`
+	Price    int64  `json:"price"`
+	Quantity int64  `json:"quantity"`
+}
+
+type OrderAddressDB struct {
+	Street    string `json:"street"`
+	House     string `json:"house"`
+	Apartment string `json:"apartment"`
+	Floor     string `json:"floor"`
+	Comment   string `json:"comment"`
+}
+
+// MarshalOrderSnapshot возвращает nil для отсутствующего снимка, чтобы в колонку попал NULL.
+func MarshalOrderSnapshot(o *model.Order) ([]byte, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	items := make([]OrderItemDB, 0, len(o.Items))
+	for _, item := range o.Items {
+		items = append(items, OrderItemDB(item))
+	}
+
+	return json.Marshal(OrderSnapshotDB{
+		ID:                o.ID,
+		OrderNumber:       o.OrderNumber,
+		RestaurantID:      o.RestaurantID,
+		Items:             items,
+		TotalPrice:        o.TotalPrice,
+		Address:           OrderAddressDB(o.Address),
+		Status:            string(o.Status),
+		CreatedAt:         o.CreatedAt,
+		UpdatedAt:         o.UpdatedAt,
+		EstimatedDelivery: o.EstimatedDelivery,
+	})
+}
+
+func UnmarshalOrderSnapshot(data []byte) (*model.Order, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var s OrderSnapshotDB
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	items := make([]model.OrderItem, 0, len(s.Items))
+	for _, item := range s.Items {
+		items = append(items, model.OrderItem(item))
+	}
+
+	return &model.Order{
+		ID:                s.ID,
+		OrderNumber:       s.OrderNumber,
+		RestaurantID:      s.RestaurantID,
+		Items:             items,
+		TotalPrice:        s.TotalPrice,
+		Address:           model.OrderAddress(s.Address),
+		Status:            model.OrderStatus(s.Status),
+		CreatedAt:         s.CreatedAt,
+		UpdatedAt:         s.UpdatedAt,
+		EstimatedDelivery: s.EstimatedDelivery,
+	}, nil
+}
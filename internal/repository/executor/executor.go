@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"courier-service/internal/repository/dbrouter"
+	"courier-service/internal/repository/txrunner"
+)
+
+// Querier — общая часть pgx.Tx и *pgxpool.Pool, через которую репозитории
+// выполняют запросы.
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Executor выбирает, где выполнить запрос репозитория: в транзакции из
+// контекста, если ее открыл PgxTxRunner, иначе в пуле роутера.
+type Executor struct {
+	db *dbrouter.Router
+}
+
+func New(db *dbrouter.Router) *Executor {
+	return &Executor{db: db}
+}
+
+// Writer возвращает транзакцию из контекста или пул мастера. Окно
+// read-your-writes открывается и для записи в транзакции, чтобы чтения
+// после коммита видели ее изменения.
+func (e *Executor) Writer(ctx context.Context) Querier {
+	primary := e.db.Write()
+	if tx, ok := txrunner.TxFromContext(ctx); ok {
+		return tx
+	}
+	return primary
+}
+
+// Reader — как Writer, но для запросов только на чтение: вне транзакции они
+// могут уйти на реплику.
+func (e *Executor) Reader(ctx context.Context) Querier {
+	if tx, ok := txrunner.TxFromContext(ctx); ok {
+		return tx
+	}
+	return e.db.Read()
+}
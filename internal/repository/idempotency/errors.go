@@ -0,0 +1,8 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrKeyNotFound      = errors.New("idempotency key not found")
+	ErrKeyAlreadyExists = errors.New("idempotency key already exists")
+)
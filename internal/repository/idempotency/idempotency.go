@@ -0,0 +1,170 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"courier-service/internal/model"
+	db "courier-service/internal/repository/utils/database"
+)
+
+type IdempotencyRepository struct {
+	pool   *pgxpool.Pool
+	logger logger
+}
+
+func NewIdempotencyRepository(pool *pgxpool.Pool, logger logger) *IdempotencyRepository {
+	return &IdempotencyRepository{pool: pool, logger: logger}
+}
+
+// ReserveKey атомарно занимает ключ под выполняющийся запрос на время lease.
+// Просроченная запись с тем же ключом, как и незавершенная с истекшей арендой
+// (запрос упал, не освободив ключ), перезаписывается; живая приводит к
+// ErrKeyAlreadyExists. Аренда считается по времени БД.
+func (r *IdempotencyRepository) ReserveKey(ctx context.Context, key model.IdempotencyKey, lease time.Duration) error {
+	queryBuilder := sq.
+		Insert(db.IdempotencyKeyTable).
+		Columns(db.KeyColumn, db.RequestHashColumn, db.ExpiresAtColumn, db.LockedUntilColumn).
+		Values(key.Key, key.RequestHash, key.ExpiresAt, sq.Expr("NOW() + ?::interval", lease)).
+		Suffix(fmt.Sprintf(`ON CONFLICT (%[1]s) DO UPDATE SET
+			%[2]s = EXCLUDED.%[2]s,
+			%[3]s = 0,
+			%[4]s = '',
+			%[5]s = NULL,
+			%[6]s = NOW(),
+			%[7]s = EXCLUDED.%[7]s,
+			%[9]s = EXCLUDED.%[9]s
+		WHERE %[8]s.%[7]s < NOW()
+			OR (%[8]s.%[3]s = 0 AND %[8]s.%[9]s < NOW())`,
+			db.KeyColumn,
+			db.RequestHashColumn,
+			db.StatusCodeColumn,
+			db.ContentTypeColumn,
+			db.ResponseBodyColumn,
+			db.CreatedAtColumn,
+			db.ExpiresAtColumn,
+			db.IdempotencyKeyTable,
+			db.LockedUntilColumn,
+		)).
+		Suffix(db.BuildReturningStatement(db.KeyColumn)).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	var reserved string
+	err = r.pool.QueryRow(ctx, query, args...).Scan(&reserved)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrKeyAlreadyExists
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) GetKey(ctx context.Context, key string) (model.IdempotencyKey, error) {
+	queryBuilder := sq.
+		Select(db.KeyColumn, db.RequestHashColumn, db.StatusCodeColumn, db.ContentTypeColumn, db.ResponseBodyColumn, db.CreatedAtColumn, db.ExpiresAtColumn).
+		From(db.IdempotencyKeyTable).
+		Where(sq.Eq{db.KeyColumn: key}).
+		Where(sq.Expr(db.ExpiresAtColumn + " > NOW()")).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return model.IdempotencyKey{}, err
+	}
+
+	var k model.IdempotencyKey
+	err = r.pool.QueryRow(ctx, query, args...).Scan(
+		&k.Key, &k.RequestHash, &k.StatusCode, &k.ContentType, &k.ResponseBody, &k.CreatedAt, &k.ExpiresAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.IdempotencyKey{}, ErrKeyNotFound
+	}
+	if err != nil {
+		return model.IdempotencyKey{}, err
+	}
+
+	return k, nil
+}
+
+func (r *IdempotencyRepository) CompleteKey(ctx context.Context, key model.IdempotencyKey) error {
+	queryBuilder := sq.
+		Update(db.IdempotencyKeyTable).
+		SetMap(sq.Eq{
+			db.StatusCodeColumn:   key.StatusCode,
+			db.ContentTypeColumn:  key.ContentType,
+			db.ResponseBodyColumn: key.ResponseBody,
+			db.LockedUntilColumn:  nil,
+		}).
+		Where(sq.Eq{db.KeyColumn: key.Key}).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteKey(ctx context.Context, key string) error {
+	queryBuilder := sq.
+		Delete(db.IdempotencyKeyTable).
+		Where(sq.Eq{db.KeyColumn: key}).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpiredKeys(ctx context.Context) error {
+	queryBuilder := sq.
+		Delete(db.IdempotencyKeyTable).
+		Where(sq.Expr(db.ExpiresAtColumn + " < NOW()")).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	ct, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() > 0 {
+		r.logger.Debugf("DeleteExpiredKeys removed rows: %d", ct.RowsAffected())
+	}
+
+	return nil
+}
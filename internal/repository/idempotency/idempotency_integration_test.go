@@ -0,0 +1,167 @@
+//go:build integration
+// +build integration
+
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+
+	"courier-service/internal/model"
+	"courier-service/internal/persistence/database/integration"
+	idempotencystorage "courier-service/internal/repository/idempotency"
+)
+
+type IdempotencyTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	pool       *pgxpool.Pool
+	repo       *idempotencystorage.IdempotencyRepository
+	ctrl       *gomock.Controller
+	mockLogger *Mocklogger
+}
+
+func TestIdempotencyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyTestSuite))
+}
+
+func (s *IdempotencyTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	_, connStr, err := integration.TestWithMigrations()
+	s.Require().NoError(err)
+
+	pool, err := pgxpool.New(s.ctx, connStr)
+	s.Require().NoError(err)
+	s.pool = pool
+}
+
+func (s *IdempotencyTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockLogger = NewMocklogger(s.ctrl)
+	s.mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.repo = idempotencystorage.NewIdempotencyRepository(s.pool, s.mockLogger)
+
+	err := integration.TruncateAll(s.ctx, s.pool)
+	s.Require().NoError(err)
+}
+
+func (s *IdempotencyTestSuite) TearDownTest() {
+	if s.ctrl != nil {
+		s.ctrl.Finish()
+	}
+}
+
+func (s *IdempotencyTestSuite) TestReserveAndComplete() {
+	ctx := context.Background()
+
+	key := model.IdempotencyKey{
+		Key:         "key-1",
+		RequestHash: "hash-1",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	s.Require().NoError(s.repo.ReserveKey(ctx, key, time.Minute))
+
+	stored, err := s.repo.GetKey(ctx, key.Key)
+	s.Require().NoError(err)
+	s.Equal("hash-1", stored.RequestHash)
+	s.False(stored.Completed())
+
+	err = s.repo.CompleteKey(ctx, model.IdempotencyKey{
+		Key:          key.Key,
+		StatusCode:   http.StatusCreated,
+		ContentType:  "application/json",
+		ResponseBody: []byte(`{"id":"1"}`),
+	})
+	s.Require().NoError(err)
+
+	stored, err = s.repo.GetKey(ctx, key.Key)
+	s.Require().NoError(err)
+	s.True(stored.Completed())
+	s.Equal(http.StatusCreated, stored.StatusCode)
+	s.Equal("application/json", stored.ContentType)
+	s.JSONEq(`{"id":"1"}`, string(stored.ResponseBody))
+}
+
+func (s *IdempotencyTestSuite) TestReserveExistingKey() {
+	ctx := context.Background()
+
+	key := model.IdempotencyKey{Key: "key-2", RequestHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+	s.Require().NoError(s.repo.ReserveKey(ctx, key, time.Minute))
+
+	err := s.repo.ReserveKey(ctx, key, time.Minute)
+	s.ErrorIs(err, idempotencystorage.ErrKeyAlreadyExists)
+}
+
+func (s *IdempotencyTestSuite) TestReserveExpiredKey() {
+	ctx := context.Background()
+
+	expired := model.IdempotencyKey{Key: "key-3", RequestHash: "old", ExpiresAt: time.Now().Add(-time.Minute)}
+	s.Require().NoError(s.repo.ReserveKey(ctx, expired, time.Minute))
+
+	_, err := s.repo.GetKey(ctx, expired.Key)
+	s.ErrorIs(err, idempotencystorage.ErrKeyNotFound)
+
+	fresh := model.IdempotencyKey{Key: "key-3", RequestHash: "new", ExpiresAt: time.Now().Add(time.Hour)}
+	s.Require().NoError(s.repo.ReserveKey(ctx, fresh, time.Minute))
+
+	stored, err := s.repo.GetKey(ctx, fresh.Key)
+	s.Require().NoError(err)
+	s.Equal("new", stored.RequestHash)
+}
+
+func (s *IdempotencyTestSuite) TestReserveAbandonedKey() {
+	ctx := context.Background()
+
+	key := model.IdempotencyKey{Key: "key-4", RequestHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+	s.Require().NoError(s.repo.ReserveKey(ctx, key, time.Millisecond))
+	time.Sleep(10 * time.Millisecond)
+
+	// аренда истекла, а ответ так и не сохранили: ретрай занимает ключ
+	s.Require().NoError(s.repo.ReserveKey(ctx, key, time.Minute))
+	s.ErrorIs(s.repo.ReserveKey(ctx, key, time.Minute), idempotencystorage.ErrKeyAlreadyExists)
+}
+
+func (s *IdempotencyTestSuite) TestReserveCompletedKeyAfterLease() {
+	ctx := context.Background()
+
+	key := model.IdempotencyKey{Key: "key-5", RequestHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+	s.Require().NoError(s.repo.ReserveKey(ctx, key, time.Millisecond))
+	s.Require().NoError(s.repo.CompleteKey(ctx, model.IdempotencyKey{Key: key.Key, StatusCode: http.StatusCreated}))
+	time.Sleep(10 * time.Millisecond)
+
+	// сохраненный ответ живет до expires_at независимо от аренды
+	s.ErrorIs(s.repo.ReserveKey(ctx, key, time.Minute), idempotencystorage.ErrKeyAlreadyExists)
+}
+
+func (s *IdempotencyTestSuite) TestDeleteKeys() {
+	ctx := context.Background()
+
+	s.Require().NoError(s.repo.ReserveKey(ctx, model.IdempotencyKey{
+		Key: "alive", RequestHash: "hash", ExpiresAt: time.Now().Add(time.Hour),
+	}, time.Minute))
+	s.Require().NoError(s.repo.ReserveKey(ctx, model.IdempotencyKey{
+		Key: "expired", RequestHash: "hash", ExpiresAt: time.Now().Add(-time.Hour),
+	}, time.Minute))
+
+	s.Require().NoError(s.repo.DeleteExpiredKeys(ctx))
+
+	var count int
+	err := s.pool.QueryRow(ctx, "SELECT COUNT(*) FROM idempotency_keys").Scan(&count)
+	s.Require().NoError(err)
+	s.Equal(1, count)
+
+	s.Require().NoError(s.repo.DeleteKey(ctx, "alive"))
+	_, err = s.repo.GetKey(ctx, "alive")
+	s.ErrorIs(err, idempotencystorage.ErrKeyNotFound)
+
+	err = s.repo.CompleteKey(ctx, model.IdempotencyKey{Key: "alive", StatusCode: http.StatusOK})
+	s.ErrorIs(err, idempotencystorage.ErrKeyNotFound)
+}
@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	db "courier-service/internal/repository/utils/database"
+	"courier-service/pkg/ratelimiter"
+)
+
+type BucketRepository struct {
+	pool   *pgxpool.Pool
+	logger logger
+}
+
+func NewBucketRepository(pool *pgxpool.Pool, logger logger) *BucketRepository {
+	return &BucketRepository{pool: pool, logger: logger}
+}
+
+// Take атомарно пополняет бакет по времени БД и пытается взять из него токен.
+// Upsert берет блокировку строки, поэтому конкурентные запросы с разных
+// реплик сервиса сериализуются на одном ключе. Время берется из БД, чтобы
+// расхождение часов между репликами не влияло на пополнение.
+func (r *BucketRepository) Take(ctx context.Context, key string, limit ratelimiter.Limit) (float64, bool, error) {
+	// доступные токены до списания с учетом пополнения с прошлого запроса
+	available := fmt.Sprintf(
+		"LEAST(?::float8, %[1]s.%[2]s + GREATEST(EXTRACT(EPOCH FROM (clock_timestamp() - %[1]s.%[3]s)), 0) * ?::float8)",
+		db.RateLimitBucketTable, db.TokensColumn, db.UpdatedAtColumn,
+	)
+	capacity, refill := float64(limit.Capacity), float64(limit.RefillRate)
+
+	queryBuilder := sq.
+		Insert(db.RateLimitBucketTable).
+		Columns(db.KeyColumn, db.TokensColumn, db.AllowedColumn, db.UpdatedAtColumn).
+		Values(key, max(capacity-1, 0), limit.Capacity > 0, sq.Expr("clock_timestamp()")).
+		Suffix(fmt.Sprintf(`ON CONFLICT (%[1]s) DO UPDATE SET
+			%[2]s = CASE WHEN %[5]s >= 1 THEN %[5]s - 1 ELSE %[5]s END,
+			%[3]s = %[5]s >= 1,
+			%[4]s = clock_timestamp()`,
+			db.KeyColumn, db.TokensColumn, db.AllowedColumn, db.UpdatedAtColumn, available,
+		), capacity, refill, capacity, refill, capacity, refill, capacity, refill).
+		Suffix(db.BuildReturningStatement(db.TokensColumn, db.AllowedColumn)).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return 0, false, err
+	}
+
+	var (
+		tokens  float64
+		allowed bool
+	)
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&tokens, &allowed); err != nil {
+		return 0, false, fmt.Errorf("database error: %w", err)
+	}
+
+	return tokens, allowed, nil
+}
+
+// DeleteIdleBuckets удаляет бакеты, к которым не обращались дольше idle.
+// Следующий запрос по ключу начнет с полного бакета, поэтому лимиты не
+// меняются, только если за idle бакет успел пополниться: idle не меньше
+// capacity/refill_rate. При нулевом refill_rate или меньшем idle удаление
+// возвращает клиенту израсходованные токены. Как и в Take, время берется из
+// БД, а не с реплики сервиса.
+func (r *BucketRepository) DeleteIdleBuckets(ctx context.Context, idle time.Duration) error {
+	queryBuilder := sq.
+		Delete(db.RateLimitBucketTable).
+		Where(sq.Expr(db.UpdatedAtColumn+" < clock_timestamp() - ?::interval", idle)).
+		PlaceholderFormat(sq.Dollar)
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return err
+	}
+
+	ct, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if ct.RowsAffected() > 0 {
+		r.logger.Debugf("DeleteIdleBuckets removed rows: %d", ct.RowsAffected())
+	}
+
+	return nil
+}
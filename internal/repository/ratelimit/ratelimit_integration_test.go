@@ -0,0 +1,218 @@
+//go:build integration
+// +build integration
+
+package ratelimit_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"courier-service/internal/persistence/database/integration"
+	ratelimitstorage "courier-service/internal/repository/ratelimit"
+	"courier-service/pkg/ratelimiter"
+	"courier-service/pkg/ratelimiter/distributed"
+	"courier-service/pkg/ratelimiter/keyed"
+)
+
+type BucketTestSuite struct {
+	suite.Suite
+	ctx        context.Context
+	pool       *pgxpool.Pool
+	repo       *ratelimitstorage.BucketRepository
+	ctrl       *gomock.Controller
+	mockLogger *Mocklogger
+}
+
+func TestBucketRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(BucketTestSuite))
+}
+
+func (s *BucketTestSuite) SetupSuite() {
+	s.ctx = context.Background()
+
+	_, connStr, err := integration.TestWithMigrations()
+	s.Require().NoError(err)
+
+	pool, err := pgxpool.New(s.ctx, connStr)
+	s.Require().NoError(err)
+	s.pool = pool
+}
+
+func (s *BucketTestSuite) SetupTest() {
+	s.ctrl = gomock.NewController(s.T())
+	s.mockLogger = NewMocklogger(s.ctrl)
+	s.mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.repo = ratelimitstorage.NewBucketRepository(s.pool, s.mockLogger)
+
+	err := integration.TruncateAll(s.ctx, s.pool)
+	s.Require().NoError(err)
+}
+
+func (s *BucketTestSuite) TearDownTest() {
+	if s.ctrl != nil {
+		s.ctrl.Finish()
+	}
+}
+
+func (s *BucketTestSuite) TestTakeUntilEmpty() {
+	limit := ratelimiter.Limit{Capacity: 3, RefillRate: 0}
+
+	for i := 2; i >= 0; i-- {
+		tokens, allowed, err := s.repo.Take(s.ctx, "k", limit)
+		s.Require().NoError(err)
+		s.True(allowed)
+		s.InDelta(float64(i), tokens, 0.001)
+	}
+
+	_, allowed, err := s.repo.Take(s.ctx, "k", limit)
+	s.Require().NoError(err)
+	s.False(allowed)
+
+	// другой ключ не делит бакет с первым
+	_, allowed, err = s.repo.Take(s.ctx, "other", limit)
+	s.Require().NoError(err)
+	s.True(allowed)
+}
+
+func (s *BucketTestSuite) TestFractionalRefill() {
+	limit := ratelimiter.Limit{Capacity: 1, RefillRate: 10}
+
+	_, allowed, err := s.repo.Take(s.ctx, "k", limit)
+	s.Require().NoError(err)
+	s.True(allowed)
+
+	_, allowed, err = s.repo.Take(s.ctx, "k", limit)
+	s.Require().NoError(err)
+	s.False(allowed)
+
+	// 10 токенов в секунду — через 150мс токен уже есть
+	time.Sleep(150 * time.Millisecond)
+
+	_, allowed, err = s.repo.Take(s.ctx, "k", limit)
+	s.Require().NoError(err)
+	s.True(allowed)
+}
+
+func (s *BucketTestSuite) TestConcurrentTakeIsAtomic() {
+	const (
+		capacity = 20
+		workers  = 100
+	)
+	limit := ratelimiter.Limit{Capacity: capacity, RefillRate: 0}
+
+	var (
+		wg      sync.WaitGroup
+		allowed atomic.Int64
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ok, err := s.repo.Take(s.ctx, "shared", limit)
+			s.NoError(err)
+			if ok {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(int64(capacity), allowed.Load())
+}
+
+func (s *BucketTestSuite) TestDeleteIdleBuckets() {
+	limit := ratelimiter.Limit{Capacity: 1, RefillRate: 1}
+
+	_, _, err := s.repo.Take(s.ctx, "old", limit)
+	s.Require().NoError(err)
+	_, err = s.pool.Exec(s.ctx, "UPDATE rate_limit_buckets SET updated_at = NOW() - INTERVAL '1 hour'")
+	s.Require().NoError(err)
+
+	_, _, err = s.repo.Take(s.ctx, "fresh", limit)
+	s.Require().NoError(err)
+
+	s.Require().NoError(s.repo.DeleteIdleBuckets(s.ctx, 10*time.Minute))
+
+	var count int
+	err = s.pool.QueryRow(s.ctx, "SELECT COUNT(*) FROM rate_limit_buckets").Scan(&count)
+	s.Require().NoError(err)
+	s.Equal(1, count)
+}
+
+// Бенчмарки сравнивают Postgres-лимитер с in-memory бакетами:
+// go test -tags=integration -run '^$' -bench . ./internal/repository/ratelimit/
+
+const benchKeys = 1000
+
+var benchLimit = ratelimiter.Limit{Capacity: 1_000_000, RefillRate: 1_000_000}
+
+func BenchmarkInMemoryLimiter(b *testing.B) {
+	l := keyed.NewLimiter(benchKeys, time.Minute, time.Now)
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			l.Allow(fmt.Sprintf("key-%d", i%benchKeys), benchLimit)
+			i++
+		}
+	})
+}
+
+func BenchmarkPostgresLimiter(b *testing.B) {
+	ctx := context.Background()
+
+	_, connStr, err := integration.TestWithMigrations()
+	require.NoError(b, err)
+
+	pool, err := pgxpool.New(ctx, connStr)
+	require.NoError(b, err)
+	defer pool.Close()
+	require.NoError(b, integration.TruncateAll(ctx, pool))
+
+	logger := zap.NewNop().Sugar()
+	l := distributed.NewLimiter(ratelimitstorage.NewBucketRepository(pool, logger), time.Second, logger)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			l.Allow(fmt.Sprintf("key-%d", i%benchKeys), benchLimit)
+			i++
+		}
+	})
+}
+
+// BenchmarkPostgresLimiter_HotKey показывает стоимость блокировки строки,
+// когда все реплики бьют в один бакет.
+func BenchmarkPostgresLimiter_HotKey(b *testing.B) {
+	ctx := context.Background()
+
+	_, connStr, err := integration.TestWithMigrations()
+	require.NoError(b, err)
+
+	pool, err := pgxpool.New(ctx, connStr)
+	require.NoError(b, err)
+	defer pool.Close()
+	require.NoError(b, integration.TruncateAll(ctx, pool))
+
+	logger := zap.NewNop().Sugar()
+	l := distributed.NewLimiter(ratelimitstorage.NewBucketRepository(pool, logger), time.Second, logger)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Allow("hot", benchLimit)
+		}
+	})
+}
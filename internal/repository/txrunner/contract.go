@@ -1,6 +1,8 @@
 //go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
 package txrunner
 
+import "time"
+
 type logger interface {
 	Debug(args ...interface{})
 	Debugf(format string, args ...interface{})
@@ -18,3 +20,11 @@ type logger interface {
 	Fatal(args ...interface{})
 	Fatalf(format string, args ...interface{})
 }
+
+type strategy interface {
+	NextDelay(attempt int) time.Duration
+}
+
+type metricsWriter interface {
+	RecordTxRetry()
+}
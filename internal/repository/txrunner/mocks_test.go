@@ -6,6 +6,7 @@ package txrunner_test
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -214,3 +215,75 @@ func (mr *MockloggerMockRecorder) Warnf(format interface{}, args ...interface{})
 	varargs := append([]interface{}{format}, args...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Warnf", reflect.TypeOf((*Mocklogger)(nil).Warnf), varargs...)
 }
+
+// Mockstrategy is a mock of strategy interface.
+type Mockstrategy struct {
+	ctrl     *gomock.Controller
+	recorder *MockstrategyMockRecorder
+}
+
+// MockstrategyMockRecorder is the mock recorder for Mockstrategy.
+type MockstrategyMockRecorder struct {
+	mock *Mockstrategy
+}
+
+// NewMockstrategy creates a new mock instance.
+func NewMockstrategy(ctrl *gomock.Controller) *Mockstrategy {
+	mock := &Mockstrategy{ctrl: ctrl}
+	mock.recorder = &MockstrategyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstrategy) EXPECT() *MockstrategyMockRecorder {
+	return m.recorder
+}
+
+// NextDelay mocks base method.
+func (m *Mockstrategy) NextDelay(attempt int) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextDelay", attempt)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// NextDelay indicates an expected call of NextDelay.
+func (mr *MockstrategyMockRecorder) NextDelay(attempt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextDelay", reflect.TypeOf((*Mockstrategy)(nil).NextDelay), attempt)
+}
+
+// MockmetricsWriter is a mock of metricsWriter interface.
+type MockmetricsWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockmetricsWriterMockRecorder
+}
+
+// MockmetricsWriterMockRecorder is the mock recorder for MockmetricsWriter.
+type MockmetricsWriterMockRecorder struct {
+	mock *MockmetricsWriter
+}
+
+// NewMockmetricsWriter creates a new mock instance.
+func NewMockmetricsWriter(ctrl *gomock.Controller) *MockmetricsWriter {
+	mock := &MockmetricsWriter{ctrl: ctrl}
+	mock.recorder = &MockmetricsWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmetricsWriter) EXPECT() *MockmetricsWriterMockRecorder {
+	return m.recorder
+}
+
+// RecordTxRetry mocks base method.
+func (m *MockmetricsWriter) RecordTxRetry() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordTxRetry")
+}
+
+// RecordTxRetry indicates an expected call of RecordTxRetry.
+func (mr *MockmetricsWriterMockRecorder) RecordTxRetry() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTxRetry", reflect.TypeOf((*MockmetricsWriter)(nil).RecordTxRetry))
+}
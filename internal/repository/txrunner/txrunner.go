@@ -2,31 +2,118 @@ package txrunner
 
 import (
 	"context"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	delay "courier-service/pkg/delay/fulljitter"
+	"courier-service/pkg/retrypolicy"
 )
 
+// Config задает уровень изоляции транзакций и политику повторов
+// при конфликтах сериализации.
+type Config struct {
+	IsoLevel   pgx.TxIsoLevel
+	MaxRetries int
+	Strategy   strategy
+	// Metrics учитывает повторы транзакций; может быть nil.
+	Metrics metricsWriter
+}
+
 type PgxTxRunner struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	config Config
+	logger logger
 }
 
-func NewTxRunner(pool *pgxpool.Pool) *PgxTxRunner {
-	return &PgxTxRunner{pool: pool}
+func NewTxRunner(pool *pgxpool.Pool, config Config, logger logger) *PgxTxRunner {
+	if config.IsoLevel == "" {
+		config.IsoLevel = pgx.ReadCommitted
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.Strategy == nil {
+		config.Strategy = delay.NewFullJitter(10*time.Millisecond, 200*time.Millisecond, 2.0, nil)
+	}
+	return &PgxTxRunner{pool: pool, config: config, logger: logger}
 }
 
+// Run выполняет fn в транзакции. Если транзакция упала из-за конфликта
+// сериализации или дедлока, она целиком повторяется до MaxRetries раз,
+// поэтому fn должна быть безопасной для повторного вызова.
+//
+// Внутри уже открытой транзакции Run создает точку сохранения: ошибка fn
+// откатывает только изменения fn, а внешняя транзакция продолжается.
 func (r *PgxTxRunner) Run(ctx context.Context, fn func(ctx context.Context) error) error {
-	tx, err := r.pool.Begin(ctx)
+	if tx, ok := TxFromContext(ctx); ok {
+		return r.runNested(ctx, tx, fn)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := r.runOnce(ctx, fn)
+		if err == nil {
+			return nil
+		}
+		if !isRetryableTxError(err) || attempt > r.config.MaxRetries {
+			return err
+		}
+
+		r.logger.Warnf("transaction attempt %d failed with retryable error, retrying: %v", attempt, err)
+		if r.config.Metrics != nil {
+			r.config.Metrics.RecordTxRetry()
+		}
+
+		select {
+		case <-time.After(r.config.Strategy.NextDelay(attempt)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (r *PgxTxRunner) runOnce(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: r.config.IsoLevel})
 	if err != nil {
 		return err
 	}
 
-	ctxTx := context.WithValue(ctx, TxKey{}, tx)
-
-	if err := fn(ctxTx); err != nil {
+	if err := fn(WithTx(ctx, tx)); err != nil {
 		_ = tx.Rollback(ctx)
 		return err
 	}
 	return tx.Commit(ctx)
 }
 
+// runNested выполняет fn в точке сохранения. Повторов здесь нет: конфликт
+// сериализации прерывает всю транзакцию, и повторяет ее внешний Run.
+func (r *PgxTxRunner) runNested(ctx context.Context, tx pgx.Tx, fn func(ctx context.Context) error) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(WithTx(ctx, savepoint)); err != nil {
+		_ = savepoint.Rollback(ctx)
+		return err
+	}
+	return savepoint.Commit(ctx)
+}
+
+func isRetryableTxError(err error) bool {
+	return retrypolicy.IsTransactionConflict(err)
+}
+
 type TxKey struct{}
+
+// WithTx кладет транзакцию в контекст для репозиториев.
+func WithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, TxKey{}, tx)
+}
+
+// TxFromContext возвращает транзакцию, открытую PgxTxRunner.
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(TxKey{}).(pgx.Tx)
+	return tx, ok
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -18,6 +19,7 @@ import (
 	"courier-service/internal/model"
 	integration "courier-service/internal/persistence/database/integration"
 	courierstorage "courier-service/internal/repository/courier"
+	"courier-service/internal/repository/dbrouter"
 	deliverystorage "courier-service/internal/repository/delivery"
 	txrunner "courier-service/internal/repository/txrunner"
 )
@@ -49,8 +51,7 @@ func (s *TxRunnerTestSuite) SetupSuite() {
 	s.Require().NoError(err)
 	s.pool = pool
 
-	s.deliveryRepo = deliverystorage.NewDeliveryRepository(s.pool)
-	s.txRunner = txrunner.NewTxRunner(s.pool)
+	s.deliveryRepo = deliverystorage.NewDeliveryRepository(dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil))
 }
 
 func (s *TxRunnerTestSuite) SetupTest() {
@@ -68,7 +69,8 @@ func (s *TxRunnerTestSuite) SetupTest() {
 	s.mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 	s.mockLogger.EXPECT().Errorf(gomock.Any(), gomock.Any()).AnyTimes()
 
-	s.courierRepo = courierstorage.NewCourierRepository(s.pool, s.mockLogger)
+	s.courierRepo = courierstorage.NewCourierRepository(dbrouter.NewRouter(s.pool, nil, dbrouter.Config{}, nil), s.mockLogger)
+	s.txRunner = txrunner.NewTxRunner(s.pool, txrunner.Config{}, s.mockLogger)
 
 	err := integration.TruncateAll(s.ctx, s.pool)
 	s.Require().NoError(err)
@@ -262,6 +264,125 @@ func (s *TxRunnerTestSuite) TestTxRunner_NestedTransactionAttempt() {
 	s.NoError(err)
 }
 
+func (s *TxRunnerTestSuite) TestTxRunner_NestedRollbackToSavepoint() {
+	ctx := context.Background()
+
+	innerErr := errors.New("inner error")
+	var outerID int64
+
+	err := s.txRunner.Run(ctx, func(txCtx context.Context) error {
+		id, err := s.courierRepo.CreateCourier(txCtx, model.Courier{
+			Name:          "Outer",
+			Phone:         "+79991234573",
+			Status:        "available",
+			TransportType: "car",
+		})
+		if err != nil {
+			return err
+		}
+		outerID = id
+
+		err = s.txRunner.Run(txCtx, func(nestedCtx context.Context) error {
+			if _, err := s.courierRepo.CreateCourier(nestedCtx, model.Courier{
+				Name:          "Inner",
+				Phone:         "+79991234574",
+				Status:        "available",
+				TransportType: "bike",
+			}); err != nil {
+				return err
+			}
+			return innerErr
+		})
+		s.ErrorIs(err, innerErr)
+
+		// внешняя транзакция продолжается после отката точки сохранения
+		return s.courierRepo.UpdateCourier(txCtx, model.Courier{ID: outerID, Status: "busy"})
+	})
+
+	s.Require().NoError(err)
+
+	outer, err := s.courierRepo.GetCourierById(ctx, outerID)
+	s.Require().NoError(err)
+	s.Equal(model.CourierStatusBusy, outer.Status)
+
+	exists, err := s.courierRepo.ExistsCourierByPhone(ctx, "+79991234574")
+	s.Require().NoError(err)
+	s.False(exists, "inner courier should be rolled back to savepoint")
+}
+
+func (s *TxRunnerTestSuite) TestTxRunner_OuterRollbackDiscardsNested() {
+	ctx := context.Background()
+
+	expectedErr := errors.New("outer error")
+	err := s.txRunner.Run(ctx, func(txCtx context.Context) error {
+		err := s.txRunner.Run(txCtx, func(nestedCtx context.Context) error {
+			_, err := s.courierRepo.CreateCourier(nestedCtx, model.Courier{
+				Name:          "Inner",
+				Phone:         "+79991234575",
+				Status:        "available",
+				TransportType: "bike",
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		return expectedErr
+	})
+
+	s.ErrorIs(err, expectedErr)
+
+	exists, err := s.courierRepo.ExistsCourierByPhone(ctx, "+79991234575")
+	s.Require().NoError(err)
+	s.False(exists, "released savepoint should be rolled back with outer transaction")
+}
+
+func (s *TxRunnerTestSuite) TestTxRunner_FailureAfterCreateDeliveryRollsBack() {
+	ctx := context.Background()
+
+	courierID, err := s.courierRepo.CreateCourier(ctx, model.Courier{
+		Name:          "Rollback Courier",
+		Phone:         "+79991234576",
+		Status:        "available",
+		TransportType: "car",
+	})
+	s.Require().NoError(err)
+
+	orderID := "550e8400-e29b-41d4-a716-446655440002"
+	expectedErr := errors.New("failure after create delivery")
+	err = s.txRunner.Run(ctx, func(txCtx context.Context) error {
+		courier, err := s.courierRepo.FindAvailableCourier(txCtx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if _, err := s.deliveryRepo.CreateDelivery(txCtx, model.Delivery{
+			CourierID:  courier.ID,
+			OrderID:    orderID,
+			AssignedAt: now,
+			Deadline:   now.Add(time.Hour),
+		}); err != nil {
+			return err
+		}
+
+		courier.ChangeStatus(model.CourierStatusBusy)
+		if err := s.courierRepo.UpdateCourier(txCtx, courier); err != nil {
+			return err
+		}
+		return expectedErr
+	})
+
+	s.ErrorIs(err, expectedErr)
+
+	_, err = s.deliveryRepo.CouriersDelivery(ctx, orderID)
+	s.ErrorIs(err, deliverystorage.ErrOrderIDNotFound, "delivery insert should be rolled back")
+
+	courier, err := s.courierRepo.GetCourierById(ctx, courierID)
+	s.Require().NoError(err)
+	s.Equal(model.CourierStatusAvailable, courier.Status, "courier status change should be rolled back")
+}
+
 func (s *TxRunnerTestSuite) TestTxRunner_EmptyTransaction() {
 	ctx := context.Background()
 
@@ -271,3 +392,71 @@ func (s *TxRunnerTestSuite) TestTxRunner_EmptyTransaction() {
 
 	s.NoError(err)
 }
+
+func (s *TxRunnerTestSuite) TestTxRunner_IsolationLevel() {
+	ctx := context.Background()
+
+	runner := txrunner.NewTxRunner(s.pool, txrunner.Config{IsoLevel: pgx.Serializable}, s.mockLogger)
+
+	var level string
+	err := runner.Run(ctx, func(txCtx context.Context) error {
+		tx := txCtx.Value(txrunner.TxKey{}).(pgx.Tx)
+		return tx.QueryRow(txCtx, "SHOW transaction_isolation").Scan(&level)
+	})
+
+	s.Require().NoError(err)
+	s.Equal("serializable", level)
+}
+
+func (s *TxRunnerTestSuite) TestTxRunner_RetriesSerializationFailure() {
+	ctx := context.Background()
+
+	metrics := NewMockmetricsWriter(s.ctrl)
+	metrics.EXPECT().RecordTxRetry().Times(2)
+	runner := txrunner.NewTxRunner(s.pool, txrunner.Config{MaxRetries: 2, Metrics: metrics}, s.mockLogger)
+
+	calls := 0
+	err := runner.Run(ctx, func(txCtx context.Context) error {
+		calls++
+		if calls < 3 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+
+	s.Require().NoError(err)
+	s.Equal(3, calls)
+}
+
+func (s *TxRunnerTestSuite) TestTxRunner_RetryLimitExceeded() {
+	ctx := context.Background()
+
+	runner := txrunner.NewTxRunner(s.pool, txrunner.Config{MaxRetries: 2}, s.mockLogger)
+
+	calls := 0
+	err := runner.Run(ctx, func(txCtx context.Context) error {
+		calls++
+		return &pgconn.PgError{Code: "40P01"}
+	})
+
+	var pgErr *pgconn.PgError
+	s.Require().ErrorAs(err, &pgErr)
+	s.Equal("40P01", pgErr.Code)
+	s.Equal(3, calls)
+}
+
+func (s *TxRunnerTestSuite) TestTxRunner_NoRetryOnRegularError() {
+	ctx := context.Background()
+
+	runner := txrunner.NewTxRunner(s.pool, txrunner.Config{MaxRetries: 5}, s.mockLogger)
+
+	calls := 0
+	expectedErr := errors.New("test error")
+	err := runner.Run(ctx, func(txCtx context.Context) error {
+		calls++
+		return expectedErr
+	})
+
+	s.ErrorIs(err, expectedErr)
+	s.Equal(1, calls)
+}
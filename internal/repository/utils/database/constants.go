@@ -16,24 +16,66 @@ const (
 	AssignedAtColumn    = "assigned_at"
 	DeadlineColumn      = "deadline"
 	CourierIDColumn     = "courier_id"
+	KeyColumn           = "key"
+	RequestHashColumn   = "request_hash"
+	StatusCodeColumn    = "status_code"
+	ContentTypeColumn   = "content_type"
+	ResponseBodyColumn  = "response_body"
+	ExpiresAtColumn     = "expires_at"
+	TokensColumn        = "tokens"
+	AllowedColumn       = "allowed"
+	OrderSnapshotColumn = "order_snapshot"
+	DeletedAtColumn     = "deleted_at"
+	ErasedAtColumn      = "erased_at"
+	CompletedAtColumn   = "completed_at"
+	OccurredAtColumn    = "occurred_at"
+	ActorColumn         = "actor"
+	ActionColumn        = "action"
+	EntityTypeColumn    = "entity_type"
+	EntityIDColumn      = "entity_id"
+	ChangesColumn       = "changes"
+	RequestIDColumn     = "request_id"
+	LockedUntilColumn   = "locked_until"
 
 	CourierTable  = "couriers"
 	DeliveryTable = "delivery"
 
+	IdempotencyKeyTable  = "idempotency_keys"
+	RateLimitBucketTable = "rate_limit_buckets"
+	AuditLogTable        = "audit_log"
+
+	// имена ограничений, которые Postgres сгенерировал по миграциям
+	CourierPhoneKey       = "couriers_phone_key"
+	DeliveryOrderIDKey    = "delivery_order_id_key"
+	DeliveryCourierIDFkey = "delivery_courier_id_fkey"
+
 	StatusBusy      = "busy"
 	StatusAvailable = "available"
+	StatusInactive  = "inactive"
+
+	// ErasedName заменяет имя курьера при стирании персональных данных;
+	// телефон заменяется на ErasedPhonePrefix и id, чтобы остаться уникальным.
+	ErasedName        = "erased"
+	ErasedPhonePrefix = "erased-"
 
 	CourierID            = CourierTable + "." + IDColumn
 	CourierName          = CourierTable + "." + NameColumn
 	CourierPhone         = CourierTable + "." + PhoneColumn
 	CourierStatus        = CourierTable + "." + StatusColumn
 	CourierTransportType = CourierTable + "." + TransportTypeColumn
+	CourierDeletedAt     = CourierTable + "." + DeletedAtColumn
 
-	DeliveryID        = DeliveryTable + "." + IDColumn
-	DeliveryOrderID   = DeliveryTable + "." + OrderIDColumn
-	DeliveryCourierID = DeliveryTable + "." + CourierIDColumn
+	DeliveryID            = DeliveryTable + "." + IDColumn
+	DeliveryOrderID       = DeliveryTable + "." + OrderIDColumn
+	DeliveryCourierID     = DeliveryTable + "." + CourierIDColumn
+	DeliveryAssignedAt    = DeliveryTable + "." + AssignedAtColumn
+	DeliveryDeadline      = DeliveryTable + "." + DeadlineColumn
+	DeliveryOrderSnapshot = DeliveryTable + "." + OrderSnapshotColumn
 
 	CountAll = "count(*)"
+
+	LockUpdate      = "FOR UPDATE"
+	LockNoKeyUpdate = "FOR NO KEY UPDATE"
 )
 
 func BuildReturningStatement(args ...string) string {
@@ -42,3 +84,14 @@ func BuildReturningStatement(args ...string) string {
 	}
 	return "RETURNING " + strings.Join(args, ", ")
 }
+
+func BuildLockingClause(strength string, table string, skipLocked bool) string {
+	clause := strength
+	if table != "" {
+		clause += " OF " + table
+	}
+	if skipLocked {
+		clause += " SKIP LOCKED"
+	}
+	return clause
+}
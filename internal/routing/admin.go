@@ -0,0 +1,10 @@
+package routing
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+func registerAdminRoutes(r chi.Router, c adminHandler) {
+	r.Get("/admin/log-level", c.GetLogLevel)
+	r.Put("/admin/log-level", c.SetLogLevel)
+}
@@ -0,0 +1,9 @@
+package routing
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+func registerAuditRoutes(r chi.Router, c auditHandler) {
+	r.Get("/audit", c.ListAudit)
+}
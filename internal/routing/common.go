@@ -10,3 +10,8 @@ func registerCommonRoutes(r chi.Router) {
 	r.Get("/ping", common.Ping)
 	r.Get("/health", common.Healthcheck)
 }
+
+func registerProbeRoutes(r chi.Router, c probesHandler) {
+	r.Get("/livez", c.Livez)
+	r.Get("/readyz", c.Readyz)
+}
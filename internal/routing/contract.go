@@ -1,6 +1,14 @@
 package routing
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"courier-service/internal/model"
+	l "courier-service/pkg/logger/zap"
+	"courier-service/pkg/ratelimiter"
+)
 
 type httpMetricsWriter interface {
 	RecordRequest(method, path, status string)
@@ -18,19 +26,45 @@ type courierHandler interface {
 	GetAllCouriers(w http.ResponseWriter, r *http.Request)
 	CreateCourier(w http.ResponseWriter, r *http.Request)
 	UpdateCourier(w http.ResponseWriter, r *http.Request)
+	DeleteCourier(w http.ResponseWriter, r *http.Request)
+	DeactivateCourier(w http.ResponseWriter, r *http.Request)
+	ReactivateCourier(w http.ResponseWriter, r *http.Request)
+	EraseCourier(w http.ResponseWriter, r *http.Request)
 }
 
 type deliveryHandler interface {
 	AssignDelivery(w http.ResponseWriter, r *http.Request)
 	UnassignDelivery(w http.ResponseWriter, r *http.Request)
+	GetDelivery(w http.ResponseWriter, r *http.Request)
+}
+
+type auditHandler interface {
+	ListAudit(w http.ResponseWriter, r *http.Request)
+}
+
+type probesHandler interface {
+	Livez(w http.ResponseWriter, r *http.Request)
+	Readyz(w http.ResponseWriter, r *http.Request)
+}
+
+type adminHandler interface {
+	GetLogLevel(w http.ResponseWriter, r *http.Request)
+	SetLogLevel(w http.ResponseWriter, r *http.Request)
 }
 
 type metricsHandler interface {
 	ServeHTTP(w http.ResponseWriter, r *http.Request)
 }
 
+type idempotencyStore interface {
+	ReserveKey(ctx context.Context, key model.IdempotencyKey, lease time.Duration) error
+	GetKey(ctx context.Context, key string) (model.IdempotencyKey, error)
+	CompleteKey(ctx context.Context, key model.IdempotencyKey) error
+	DeleteKey(ctx context.Context, key string) error
+}
+
 type rateLimiter interface {
-	Allow() bool
+	Allow(key string, limit ratelimiter.Limit) ratelimiter.Status
 }
 
 type logger interface {
@@ -40,6 +74,7 @@ type logger interface {
 
 	Info(args ...interface{})
 	Infof(format string, args ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
 
 	Warn(args ...interface{})
 	Warnf(format string, args ...interface{})
@@ -49,4 +84,6 @@ type logger interface {
 
 	Fatal(args ...interface{})
 	Fatalf(format string, args ...interface{})
+
+	With(ctx context.Context) *l.Logger
 }
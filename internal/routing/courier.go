@@ -9,4 +9,8 @@ func registerCourierRoutes(r chi.Router, c courierHandler) {
 	r.Get("/courier/{id}", c.GetCourierById)
 	r.Post("/courier", c.CreateCourier)
 	r.Put("/courier", c.UpdateCourier)
+	r.Delete("/courier/{id}", c.DeleteCourier)
+	r.Post("/courier/{id}/deactivate", c.DeactivateCourier)
+	r.Post("/courier/{id}/reactivate", c.ReactivateCourier)
+	r.Post("/courier/{id}/erase", c.EraseCourier)
 }
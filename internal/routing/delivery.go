@@ -7,4 +7,5 @@ import (
 func registerDeliveryRoutes(r chi.Router, c deliveryHandler) {
 	r.Post("/delivery/assign", c.AssignDelivery)
 	r.Post("/delivery/unassign", c.UnassignDelivery)
+	r.Get("/delivery/{order_id}", c.GetDelivery)
 }
@@ -1,30 +1,48 @@
 package routing
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/go-chi/chi/v5"
 
+	actormiddleware "courier-service/internal/handlers/middleware/actor"
+	idempotencymiddleware "courier-service/internal/handlers/middleware/idempotency"
 	loggingmiddleware "courier-service/internal/handlers/middleware/logging"
 	ratelimitmiddleware "courier-service/internal/handlers/middleware/ratelimit"
+	requestidmiddleware "courier-service/internal/handlers/middleware/requestid"
+	tracingmiddleware "courier-service/internal/handlers/middleware/tracing"
 )
 
 func Router(
 	logger logger,
 	rateLimiter rateLimiter,
+	rateLimitPolicy *ratelimitmiddleware.PolicyStore,
 	metricsWriter httpMetricsWriter,
 	metricsHandler metricsHandler,
 	pathNormalizer pathNormalizer,
+	idempotencyStore idempotencyStore,
+	idempotencyTTL time.Duration,
+	idempotencyLease time.Duration,
 	courierController courierHandler,
 	deliveryController deliveryHandler,
+	auditController auditHandler,
+	probesController probesHandler,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
-	// /metrics endpoint БЕЗ rate limiting
+	// /metrics и пробы БЕЗ rate limiting
 	r.Handle("/metrics", metricsHandler)
+	registerProbeRoutes(r, probesController)
 
 	r.Group(func(r chi.Router) {
 		r.Use(
+			requestidmiddleware.RequestIDMiddleware(),
+			actormiddleware.ActorMiddleware(),
+			tracingmiddleware.TracingMiddleware(pathNormalizer),
 			ratelimitmiddleware.RateLimitMiddleware(
 				rateLimiter,
+				rateLimitPolicy,
 				logger,
 				metricsWriter,
 				pathNormalizer,
@@ -34,12 +52,29 @@ func Router(
 				metricsWriter,
 				pathNormalizer,
 			),
+			idempotencymiddleware.IdempotencyMiddleware(
+				idempotencyStore,
+				idempotencyTTL,
+				idempotencyLease,
+				logger,
+			),
 		)
 
 		registerCommonRoutes(r)
 		registerCourierRoutes(r, courierController)
 		registerDeliveryRoutes(r, deliveryController)
+		registerAuditRoutes(r, auditController)
 	})
 
 	return r
 }
+
+// InternalRouter — служебный listener (pprof_addr), который не публикуется
+// наружу: pprof и управление уровнем логирования. Admin-эндпоинты не имеют
+// auth, поэтому на публичный роутер их не выносить.
+func InternalRouter(adminController adminHandler, pprofHandler http.Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Handle("/debug/pprof/*", pprofHandler)
+	registerAdminRoutes(r, adminController)
+	return r
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"context"
+	"slices"
+
+	"courier-service/internal/model"
+)
+
+const (
+	// DefaultLimit — сколько записей отдается, если лимит не задан.
+	DefaultLimit = 100
+	// MaxLimit ограничивает выборку за один запрос.
+	MaxLimit = 1000
+)
+
+type AuditUseCase struct {
+	repository auditRepository
+}
+
+func NewAuditUseCase(repository auditRepository) *AuditUseCase {
+	return &AuditUseCase{repository: repository}
+}
+
+// List возвращает записи журнала по сущности и интервалу [From, To), новые
+// первыми. Лимит вне (0, MaxLimit] заменяется ближайшим допустимым.
+func (u *AuditUseCase) List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEntry, error) {
+	if filter.EntityType != "" && !slices.Contains(model.AuditEntities, filter.EntityType) {
+		return nil, ErrUnknownEntity
+	}
+	if filter.EntityID != "" && filter.EntityType == "" {
+		return nil, ErrEntityRequired
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return nil, ErrInvalidTimeRange
+	}
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = DefaultLimit
+	case filter.Limit > MaxLimit:
+		filter.Limit = MaxLimit
+	}
+
+	entries, err := u.repository.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []model.AuditEntry{}
+	}
+	return entries, nil
+}
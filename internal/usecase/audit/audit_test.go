@@ -0,0 +1,145 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"courier-service/internal/model"
+	"courier-service/internal/usecase/audit"
+	"courier-service/pkg/actor"
+	"courier-service/pkg/requestid"
+)
+
+func TestAuditUseCase_List(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		filter    model.AuditFilter
+		wantLimit int
+		wantErr   error
+	}{
+		{
+			name:      "default limit",
+			filter:    model.AuditFilter{EntityType: model.AuditEntityCourier, EntityID: "1"},
+			wantLimit: audit.DefaultLimit,
+		},
+		{
+			name:      "limit capped",
+			filter:    model.AuditFilter{From: from, To: to, Limit: audit.MaxLimit + 1},
+			wantLimit: audit.MaxLimit,
+		},
+		{
+			name:      "limit kept",
+			filter:    model.AuditFilter{EntityType: model.AuditEntityDelivery, Limit: 10},
+			wantLimit: 10,
+		},
+		{
+			name:    "unknown entity",
+			filter:  model.AuditFilter{EntityType: "order"},
+			wantErr: audit.ErrUnknownEntity,
+		},
+		{
+			name:    "entity id without entity type",
+			filter:  model.AuditFilter{EntityID: "1"},
+			wantErr: audit.ErrEntityRequired,
+		},
+		{
+			name:    "inverted time range",
+			filter:  model.AuditFilter{From: to, To: from},
+			wantErr: audit.ErrInvalidTimeRange,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := NewMockauditRepository(ctrl)
+			if tc.wantErr == nil {
+				expected := tc.filter
+				expected.Limit = tc.wantLimit
+				repo.EXPECT().List(gomock.Any(), expected).Return(nil, nil)
+			}
+
+			entries, err := audit.NewAuditUseCase(repo).List(context.Background(), tc.filter)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, entries)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := audit.Snapshot{"name": "John", "phone": "+79991234567", "status": "available", "deleted": false}
+	after := audit.Snapshot{"name": "John", "phone": "+79997654321", "status": "inactive", "deleted": false}
+
+	changes := audit.Diff(before, after)
+
+	assert.Equal(t, map[string]model.AuditChange{
+		"phone":  {Before: "******", After: "******"},
+		"status": {Before: "available", After: "inactive"},
+	}, changes)
+}
+
+func TestDiff_CreateAndRemove(t *testing.T) {
+	created := audit.Diff(nil, audit.Snapshot{"name": "John", "status": "available"})
+	assert.Equal(t, map[string]model.AuditChange{
+		"name":   {After: "******"},
+		"status": {After: "available"},
+	}, created)
+
+	removed := audit.Diff(audit.Snapshot{"courier_id": int64(1)}, nil)
+	assert.Equal(t, map[string]model.AuditChange{
+		"courier_id": {Before: int64(1)},
+	}, removed)
+}
+
+func TestRecorder_Record(t *testing.T) {
+	ctx := actor.NewContext(requestid.NewContext(context.Background(), "req-1"), "admin")
+
+	t.Run("entry carries actor and request id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := NewMockauditRepository(ctrl)
+		repo.EXPECT().Append(gomock.Any(), model.AuditEntry{
+			Actor:      "admin",
+			Action:     model.AuditActionCourierDeactivate,
+			EntityType: model.AuditEntityCourier,
+			EntityID:   "1",
+			Changes: map[string]model.AuditChange{
+				"status": {Before: "available", After: "inactive"},
+			},
+			RequestID: "req-1",
+		}).Return(nil)
+
+		err := audit.NewRecorder(repo).Record(ctx, model.AuditActionCourierDeactivate, model.AuditEntityCourier, "1",
+			audit.Snapshot{"status": "available"}, audit.Snapshot{"status": "inactive"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("no changes are not recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := NewMockauditRepository(ctrl)
+
+		err := audit.NewRecorder(repo).Record(ctx, model.AuditActionCourierUpdate, model.AuditEntityCourier, "1",
+			audit.Snapshot{"status": "available"}, audit.Snapshot{"status": "available"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("append error is returned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := NewMockauditRepository(ctrl)
+		repo.EXPECT().Append(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+		err := audit.NewRecorder(repo).Record(ctx, model.AuditActionCourierCreate, model.AuditEntityCourier, "1",
+			nil, audit.Snapshot{"status": "available"})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,13 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
+package audit
+
+import (
+	"context"
+
+	"courier-service/internal/model"
+)
+
+type auditRepository interface {
+	Append(ctx context.Context, entry model.AuditEntry) error
+	List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEntry, error)
+}
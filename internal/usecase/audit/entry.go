@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"time"
+
+	"courier-service/internal/model"
+	"courier-service/pkg/actor"
+	"courier-service/pkg/requestid"
+)
+
+// redacted заменяет значения персональных полей. Журнал только дописывается,
+// поэтому стереть из него имя и телефон курьера по запросу было бы нельзя.
+const redacted = "******"
+
+// personalFields — поля снимков с персональными данными.
+var personalFields = map[string]bool{
+	"name":  true,
+	"phone": true,
+}
+
+// Snapshot — поля сущности, которые попадают в журнал аудита; nil — сущности
+// не было до действия или не стало после него.
+type Snapshot map[string]any
+
+func CourierSnapshot(c model.Courier) Snapshot {
+	return Snapshot{
+		"name":           c.Name,
+		"phone":          c.Phone,
+		"status":         string(c.Status),
+		"transport_type": string(c.TransportType),
+		"deleted":        c.IsDeleted(),
+	}
+}
+
+func DeliverySnapshot(d model.Delivery) Snapshot {
+	return Snapshot{
+		"courier_id":  d.CourierID,
+		"assigned_at": d.AssignedAt.UTC().Format(time.RFC3339),
+		"deadline":    d.Deadline.UTC().Format(time.RFC3339),
+	}
+}
+
+// NewEntry собирает запись журнала: актор и id запроса берутся из контекста,
+// в Changes попадают только изменившиеся поля.
+func NewEntry(ctx context.Context, action model.AuditAction, entity model.AuditEntity, entityID string, before, after Snapshot) model.AuditEntry {
+	return model.AuditEntry{
+		Actor:      actor.FromContext(ctx),
+		Action:     action,
+		EntityType: entity,
+		EntityID:   entityID,
+		Changes:    Diff(before, after),
+		RequestID:  requestid.FromContext(ctx),
+	}
+}
+
+// Diff возвращает поля, значения которых различаются в снимках. Значения
+// персональных полей маскируются, но их изменение остается видно.
+func Diff(before, after Snapshot) map[string]model.AuditChange {
+	changes := make(map[string]model.AuditChange)
+	for field, value := range after {
+		old, existed := before[field]
+		if existed && reflect.DeepEqual(old, value) {
+			continue
+		}
+		change := model.AuditChange{After: value}
+		if existed {
+			change.Before = old
+		}
+		changes[field] = mask(field, change)
+	}
+	for field, old := range before {
+		if _, exists := after[field]; !exists {
+			changes[field] = mask(field, model.AuditChange{Before: old})
+		}
+	}
+	return changes
+}
+
+func mask(field string, change model.AuditChange) model.AuditChange {
+	if !personalFields[field] {
+		return change
+	}
+	if change.Before != nil {
+		change.Before = redacted
+	}
+	if change.After != nil {
+		change.After = redacted
+	}
+	return change
+}
+
+// CourierID — EntityID записи о курьере.
+func CourierID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// Recorder пишет записи о действиях use case'ов. Его нужно вызывать в той же
+// транзакции, что и само действие, чтобы запись не разошлась с данными.
+type Recorder struct {
+	repository auditRepository
+}
+
+func NewRecorder(repository auditRepository) *Recorder {
+	return &Recorder{repository: repository}
+}
+
+// Record добавляет запись о действии; действие без изменений не пишется.
+func (r *Recorder) Record(ctx context.Context, action model.AuditAction, entity model.AuditEntity, entityID string, before, after Snapshot) error {
+	entry := NewEntry(ctx, action, entity, entityID, before, after)
+	if len(entry.Changes) == 0 {
+		return nil
+	}
+	return r.repository.Append(ctx, entry)
+}
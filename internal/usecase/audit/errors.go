@@ -0,0 +1,9 @@
+package audit
+
+import "errors"
+
+var (
+	ErrUnknownEntity    = errors.New("unknown audit entity")
+	ErrEntityRequired   = errors.New("entity type is required to filter by entity id")
+	ErrInvalidTimeRange = errors.New("time range start is after its end")
+)
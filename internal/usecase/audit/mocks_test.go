@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package audit_test is a generated GoMock package.
+package audit_test
+
+import (
+	context "context"
+	model "courier-service/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockauditRepository is a mock of auditRepository interface.
+type MockauditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditRepositoryMockRecorder
+}
+
+// MockauditRepositoryMockRecorder is the mock recorder for MockauditRepository.
+type MockauditRepositoryMockRecorder struct {
+	mock *MockauditRepository
+}
+
+// NewMockauditRepository creates a new mock instance.
+func NewMockauditRepository(ctrl *gomock.Controller) *MockauditRepository {
+	mock := &MockauditRepository{ctrl: ctrl}
+	mock.recorder = &MockauditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditRepository) EXPECT() *MockauditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockauditRepository) Append(ctx context.Context, entry model.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockauditRepositoryMockRecorder) Append(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockauditRepository)(nil).Append), ctx, entry)
+}
+
+// List mocks base method.
+func (m *MockauditRepository) List(ctx context.Context, filter model.AuditFilter) ([]model.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]model.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockauditRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockauditRepository)(nil).List), ctx, filter)
+}
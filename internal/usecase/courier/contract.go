@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"courier-service/internal/model"
+	"courier-service/internal/usecase/audit"
 	utils "courier-service/internal/usecase/utils"
 )
 
@@ -15,7 +16,20 @@ type courierRepository interface {
 	UpdateCourier(ctx context.Context, courier model.Courier) error
 	FindAvailableCourier(ctx context.Context) (model.Courier, error)
 	ExistsCourierByPhone(ctx context.Context, phone string) (bool, error)
-	FreeCouriersWithInterval(ctx context.Context) error
+	FreeCouriersWithInterval(ctx context.Context) (int64, error)
+	CountAvailableCouriersByTransport(ctx context.Context) (map[model.CourierTransportType]int, error)
+	GetCourierForUpdate(ctx context.Context, id int64) (model.Courier, error)
+	DeleteCourier(ctx context.Context, id int64) error
+	EraseCourier(ctx context.Context, id int64) error
+	HasOpenDelivery(ctx context.Context, id int64) (bool, error)
+}
+
+type txRunner interface {
+	Run(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type auditRecorder interface {
+	Record(ctx context.Context, action model.AuditAction, entity model.AuditEntity, entityID string, before, after audit.Snapshot) error
 }
 
 type DeliveryCalculator = utils.DeliveryCalculator
@@ -24,6 +38,11 @@ type deliveryCalculatorFactory interface {
 	GetDeliveryCalculator(courierType model.CourierTransportType) DeliveryCalculator
 }
 
+type metricsWriter interface {
+	RecordDeadlineMisses(count int64)
+	SetAvailableCouriers(transportType string, count int)
+}
+
 type logger interface {
 	Debug(args ...interface{})
 	Debugf(format string, args ...interface{})
@@ -8,19 +8,38 @@ import (
 
 	"courier-service/internal/model"
 	courierRepo "courier-service/internal/repository/courier"
+	"courier-service/internal/usecase/audit"
 )
 
 type CourierUseCase struct {
 	repository courierRepository
+	txRunner   txRunner
+	audit      auditRecorder
 	factory    deliveryCalculatorFactory
+	metrics    metricsWriter
 	logger     logger
+
+	// checkInterval передает новый интервал в CheckFreeCouriersWithInterval
+	checkInterval chan time.Duration
 }
 
-func NewCourierUseCase(repository courierRepository, factory deliveryCalculatorFactory, logger logger) *CourierUseCase {
+func NewCourierUseCase(
+	repository courierRepository,
+	txRunner txRunner,
+	audit auditRecorder,
+	factory deliveryCalculatorFactory,
+	metrics metricsWriter,
+	logger logger,
+) *CourierUseCase {
 	return &CourierUseCase{
 		repository: repository,
+		txRunner:   txRunner,
+		audit:      audit,
 		factory:    factory,
+		metrics:    metrics,
 		logger:     logger,
+
+		checkInterval: make(chan time.Duration, 1),
 	}
 }
 
@@ -32,15 +51,55 @@ func (u *CourierUseCase) CheckFreeCouriersWithInterval(ctx context.Context, inte
 		select {
 		case <-ctx.Done():
 			return
+		case interval := <-u.checkInterval:
+			ticker.Reset(interval)
+			u.logger.Infof("Free couriers check interval changed to %s", interval)
 		case t := <-ticker.C:
-			if err := u.repository.FreeCouriersWithInterval(ctx); err != nil {
+			// курьер освобождается по дедлайну, только если доставка его пропустила
+			freed, err := u.repository.FreeCouriersWithInterval(ctx)
+			if err != nil {
 				u.logger.Errorf("Failed to check free couriers: %v", err)
+			} else {
+				u.metrics.RecordDeadlineMisses(freed)
 			}
+			u.recordAvailableCouriers(ctx)
 			u.logger.Debugf("Checked free couriers at %s", t.Format(time.RFC3339))
 		}
 	}
 }
 
+// SetCheckInterval меняет интервал запущенной проверки свободных курьеров.
+// Если предыдущее значение еще не применено, оно заменяется новым.
+func (u *CourierUseCase) SetCheckInterval(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	for {
+		select {
+		case u.checkInterval <- interval:
+			return
+		default:
+		}
+		select {
+		case <-u.checkInterval:
+		default:
+		}
+	}
+}
+
+// recordAvailableCouriers обновляет gauge свободных курьеров; типы
+// транспорта без свободных курьеров выставляются в ноль.
+func (u *CourierUseCase) recordAvailableCouriers(ctx context.Context) {
+	counts, err := u.repository.CountAvailableCouriersByTransport(ctx)
+	if err != nil {
+		u.logger.Errorf("Failed to count available couriers: %v", err)
+		return
+	}
+	for _, transportType := range model.TransportTypes {
+		u.metrics.SetAvailableCouriers(string(transportType), counts[transportType])
+	}
+}
+
 func (u *CourierUseCase) GetCourierById(ctx context.Context, id int64) (model.Courier, error) {
 	courier, err := u.repository.GetCourierById(ctx, id)
 
@@ -81,13 +140,32 @@ func (u *CourierUseCase) CreateCourier(ctx context.Context, courier model.Courie
 		return 0, err
 	}
 
-	return u.repository.CreateCourier(ctx, courier)
+	// проверка выше не защищает от параллельного создания с тем же телефоном
+	var id int64
+	err := u.txRunner.Run(ctx, func(txCtx context.Context) error {
+		var err error
+		id, err = u.repository.CreateCourier(txCtx, courier)
+		if err != nil {
+			return err
+		}
+		return u.recordCourier(txCtx, model.AuditActionCourierCreate, id, nil, audit.CourierSnapshot(courier))
+	})
+	if errors.Is(err, courierRepo.ErrPhoneNumberExists) {
+		return 0, ErrPhoneNumberExists
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
 }
 
 func (u *CourierUseCase) UpdateCourier(ctx context.Context, courier model.Courier) error {
 	if courier.Name == "" && courier.Phone == "" && courier.Status == "" && courier.TransportType == "" {
 		return ErrInvalidUpdate
 	}
+	if courier.Status == model.CourierStatusInactive {
+		return ErrInactiveStatusChange
+	}
 	if courier.TransportType != "" {
 		if u.factory.GetDeliveryCalculator(courier.TransportType) == nil {
 			return ErrUnknownTransportType
@@ -104,15 +182,166 @@ func (u *CourierUseCase) UpdateCourier(ctx context.Context, courier model.Courie
 		}
 	}
 
-	if err := u.repository.UpdateCourier(ctx, courier); err != nil {
-		if errors.Is(err, courierRepo.ErrCourierNotFound) {
+	err := u.withLockedCourier(ctx, courier.ID, func(txCtx context.Context, locked model.Courier) error {
+		if locked.IsDeleted() {
 			return ErrCourierNotFound
 		}
+		// иначе обновление обошло бы проверку доставок и аудит реактивации
+		if courier.Status != "" && locked.Status == model.CourierStatusInactive {
+			return ErrInactiveStatusChange
+		}
+		if err := u.repository.UpdateCourier(txCtx, courier); err != nil {
+			return err
+		}
+		return u.recordCourier(txCtx, model.AuditActionCourierUpdate, courier.ID,
+			audit.CourierSnapshot(locked), audit.CourierSnapshot(applyUpdate(locked, courier)))
+	})
+	if errors.Is(err, courierRepo.ErrCourierNotFound) {
+		return ErrCourierNotFound
+	}
+	if errors.Is(err, courierRepo.ErrPhoneNumberExists) {
+		return ErrPhoneNumberExists
+	}
+	return err
+}
+
+// applyUpdate возвращает курьера после частичного обновления: пустые поля
+// update не меняют текущих значений.
+func applyUpdate(courier, update model.Courier) model.Courier {
+	if update.Name != "" {
+		courier.Name = update.Name
+	}
+	if update.Phone != "" {
+		courier.Phone = update.Phone
+	}
+	if update.Status != "" {
+		courier.Status = update.Status
+	}
+	if update.TransportType != "" {
+		courier.TransportType = update.TransportType
+	}
+	return courier
+}
+
+// DeleteCourier мягко удаляет курьера. Курьера с активной доставкой удалить
+// нельзя: сначала доставку нужно завершить или снять.
+func (u *CourierUseCase) DeleteCourier(ctx context.Context, id int64) error {
+	return u.withLockedCourier(ctx, id, func(txCtx context.Context, courier model.Courier) error {
+		if courier.IsDeleted() {
+			return ErrCourierNotFound
+		}
+		if err := u.checkNotOnDelivery(txCtx, courier); err != nil {
+			return err
+		}
+		if err := u.repository.DeleteCourier(txCtx, id); err != nil {
+			return err
+		}
+		after := audit.CourierSnapshot(courier)
+		after["deleted"] = true
+		return u.recordCourier(txCtx, model.AuditActionCourierDelete, id, audit.CourierSnapshot(courier), after)
+	})
+}
+
+// DeactivateCourier снимает курьера с линии: он остается в списках, но не
+// получает новых заказов. Повторная деактивация ничего не меняет.
+func (u *CourierUseCase) DeactivateCourier(ctx context.Context, id int64) error {
+	return u.withLockedCourier(ctx, id, func(txCtx context.Context, courier model.Courier) error {
+		switch {
+		case courier.IsDeleted():
+			return ErrCourierNotFound
+		case courier.Status == model.CourierStatusInactive:
+			return nil
+		}
+		if err := u.checkNotOnDelivery(txCtx, courier); err != nil {
+			return err
+		}
+		return u.setStatus(txCtx, model.AuditActionCourierDeactivate, courier, model.CourierStatusInactive)
+	})
+}
+
+// ReactivateCourier возвращает деактивированного курьера на линию; для
+// активного курьера ничего не делает.
+func (u *CourierUseCase) ReactivateCourier(ctx context.Context, id int64) error {
+	return u.withLockedCourier(ctx, id, func(txCtx context.Context, courier model.Courier) error {
+		switch {
+		case courier.IsDeleted():
+			return ErrCourierNotFound
+		case courier.Status != model.CourierStatusInactive:
+			return nil
+		}
+		return u.setStatus(txCtx, model.AuditActionCourierReactivate, courier, model.CourierStatusAvailable)
+	})
+}
+
+// EraseCourier обезличивает курьера по запросу на удаление персональных
+// данных. Стереть можно и ранее удаленного курьера; доставки сохраняются.
+func (u *CourierUseCase) EraseCourier(ctx context.Context, id int64) error {
+	return u.withLockedCourier(ctx, id, func(txCtx context.Context, courier model.Courier) error {
+		if !courier.IsDeleted() {
+			if err := u.checkNotOnDelivery(txCtx, courier); err != nil {
+				return err
+			}
+		}
+		if err := u.repository.EraseCourier(txCtx, id); err != nil {
+			return err
+		}
+		// стертые имя и телефон записываются как удаленные поля
+		after := audit.CourierSnapshot(courier)
+		delete(after, "name")
+		delete(after, "phone")
+		after["status"] = string(model.CourierStatusInactive)
+		after["deleted"] = true
+		return u.recordCourier(txCtx, model.AuditActionCourierErase, id, audit.CourierSnapshot(courier), after)
+	})
+}
+
+// checkNotOnDelivery возвращает ErrCourierOnDelivery, пока у курьера есть
+// незавершенная доставка. Одного статуса мало: по дедлайну курьер становится
+// свободным, хотя заказ еще не завершен.
+func (u *CourierUseCase) checkNotOnDelivery(ctx context.Context, courier model.Courier) error {
+	if courier.Status == model.CourierStatusBusy {
+		return ErrCourierOnDelivery
+	}
+	open, err := u.repository.HasOpenDelivery(ctx, courier.ID)
+	if err != nil {
 		return err
 	}
+	if open {
+		return ErrCourierOnDelivery
+	}
 	return nil
 }
 
+func (u *CourierUseCase) setStatus(ctx context.Context, action model.AuditAction, courier model.Courier, status model.CourierStatus) error {
+	if err := u.repository.UpdateCourier(ctx, model.Courier{ID: courier.ID, Status: status}); err != nil {
+		return err
+	}
+	after := courier
+	after.Status = status
+	return u.recordCourier(ctx, action, courier.ID, audit.CourierSnapshot(courier), audit.CourierSnapshot(after))
+}
+
+// recordCourier пишет в журнал аудита действие над курьером; вызывается в
+// транзакции действия.
+func (u *CourierUseCase) recordCourier(ctx context.Context, action model.AuditAction, id int64, before, after audit.Snapshot) error {
+	return u.audit.Record(ctx, action, model.AuditEntityCourier, audit.CourierID(id), before, after)
+}
+
+// withLockedCourier выполняет fn в транзакции, заблокировав курьера, чтобы
+// его статус не поменялся назначением между проверкой и записью.
+func (u *CourierUseCase) withLockedCourier(ctx context.Context, id int64, fn func(txCtx context.Context, courier model.Courier) error) error {
+	return u.txRunner.Run(ctx, func(txCtx context.Context) error {
+		courier, err := u.repository.GetCourierForUpdate(txCtx, id)
+		if err != nil {
+			if errors.Is(err, courierRepo.ErrCourierNotFound) {
+				return ErrCourierNotFound
+			}
+			return err
+		}
+		return fn(txCtx, courier)
+	})
+}
+
 func ValidPhoneNumber(phone string) bool {
 	phoneRegex := `^\+[0-9]{11}$`
 	return regexp.MustCompile(phoneRegex).MatchString(phone)
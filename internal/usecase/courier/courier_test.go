@@ -3,6 +3,7 @@ package courier_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 	"courier-service/internal/model"
 	courierRepo "courier-service/internal/repository/courier"
+	"courier-service/internal/usecase/audit"
 	"courier-service/internal/usecase/courier"
 )
 
@@ -73,7 +75,7 @@ func TestCourierUseCase_GetById(t *testing.T) {
 			mockRepo := NewMockcourierRepository(ctrl)
 			mockFactory := NewMockdeliveryCalculatorFactory(ctrl)
 			mockLogger := NewMocklogger(ctrl)
-			uc := courier.NewCourierUseCase(mockRepo, mockFactory, mockLogger)
+			uc := courier.NewCourierUseCase(mockRepo, NewMocktxRunner(ctrl), NewMockauditRecorder(ctrl), mockFactory, NewMockmetricsWriter(ctrl), mockLogger)
 
 			ctx := context.Background()
 
@@ -142,7 +144,7 @@ func TestCourierUseCase_GetAll(t *testing.T) {
 			mockRepo := NewMockcourierRepository(ctrl)
 			mockFactory := NewMockdeliveryCalculatorFactory(ctrl)
 			mockLogger := NewMocklogger(ctrl)
-			uc := courier.NewCourierUseCase(mockRepo, mockFactory, mockLogger)
+			uc := courier.NewCourierUseCase(mockRepo, NewMocktxRunner(ctrl), NewMockauditRecorder(ctrl), mockFactory, NewMockmetricsWriter(ctrl), mockLogger)
 
 			ctx := context.Background()
 
@@ -316,6 +318,33 @@ func TestCourierUseCase_Create(t *testing.T) {
 				assert.Equal(t, courier.ErrPhoneNumberExists, err)
 			},
 		},
+		{
+			name: "error: phone taken by concurrent create",
+			request: model.Courier{
+				Name:          "John Doe",
+				Phone:         "+79991234567",
+				Status:        "available",
+				TransportType: "car",
+			},
+			prepare: func(repo *MockcourierRepository, factory *MockdeliveryCalculatorFactory, ctrl *gomock.Controller) {
+				calculator := NewMockDeliveryCalculator(ctrl)
+				factory.EXPECT().
+					GetDeliveryCalculator(model.TransportTypeCar).
+					Return(calculator)
+
+				repo.EXPECT().
+					ExistsCourierByPhone(gomock.Any(), "+79991234567").
+					Return(false, nil)
+
+				repo.EXPECT().
+					CreateCourier(gomock.Any(), gomock.Any()).
+					Return(int64(0), fmt.Errorf("database error: %w", courierRepo.ErrPhoneNumberExists))
+			},
+			expectations: func(t *testing.T, id int64, err error) {
+				assert.Equal(t, int64(0), id)
+				assert.Equal(t, courier.ErrPhoneNumberExists, err)
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -329,7 +358,7 @@ func TestCourierUseCase_Create(t *testing.T) {
 			mockRepo := NewMockcourierRepository(ctrl)
 			mockFactory := NewMockdeliveryCalculatorFactory(ctrl)
 			mockLogger := NewMocklogger(ctrl)
-			uc := courier.NewCourierUseCase(mockRepo, mockFactory, mockLogger)
+			uc := courier.NewCourierUseCase(mockRepo, passthroughTxRunner(ctrl), ignoringAuditRecorder(ctrl), mockFactory, NewMockmetricsWriter(ctrl), mockLogger)
 
 			ctx := context.Background()
 
@@ -365,6 +394,9 @@ func TestCourierUseCase_Update(t *testing.T) {
 				Name: nameUpdate,
 			},
 			prepare: func(repo *MockcourierRepository, factory *MockdeliveryCalculatorFactory, ctrl *gomock.Controller) {
+				repo.EXPECT().
+					GetCourierForUpdate(gomock.Any(), int64(1)).
+					Return(model.Courier{ID: 1, Name: "John Doe"}, nil)
 				repo.EXPECT().
 					UpdateCourier(gomock.Any(), gomock.Any()).
 					Return(nil)
@@ -438,14 +470,60 @@ func TestCourierUseCase_Update(t *testing.T) {
 			},
 			prepare: func(repo *MockcourierRepository, factory *MockdeliveryCalculatorFactory, ctrl *gomock.Controller) {
 				repo.EXPECT().
-					UpdateCourier(gomock.Any(), gomock.Any()).
-					Return(courierRepo.ErrCourierNotFound)
+					GetCourierForUpdate(gomock.Any(), int64(999)).
+					Return(model.Courier{}, courierRepo.ErrCourierNotFound)
 			},
 			expectations: func(t *testing.T, err error) {
 				assert.Error(t, err)
 				assert.Equal(t, courier.ErrCourierNotFound, err)
 			},
 		},
+		{
+			name: "error: deactivation through update",
+			request: model.Courier{
+				ID:     1,
+				Status: model.CourierStatusInactive,
+			},
+			prepare: func(repo *MockcourierRepository, factory *MockdeliveryCalculatorFactory, ctrl *gomock.Controller) {
+				// до блокировки курьера и проверки доставок дело не доходит
+			},
+			expectations: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, courier.ErrInactiveStatusChange)
+			},
+		},
+		{
+			name: "error: reactivation through update",
+			request: model.Courier{
+				ID:     1,
+				Status: model.CourierStatusAvailable,
+			},
+			prepare: func(repo *MockcourierRepository, factory *MockdeliveryCalculatorFactory, ctrl *gomock.Controller) {
+				repo.EXPECT().
+					GetCourierForUpdate(gomock.Any(), int64(1)).
+					Return(model.Courier{ID: 1, Status: model.CourierStatusInactive}, nil)
+			},
+			expectations: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, courier.ErrInactiveStatusChange)
+			},
+		},
+		{
+			name: "success: inactive courier renamed",
+			request: model.Courier{
+				ID:   1,
+				Name: nameUpdate,
+			},
+			prepare: func(repo *MockcourierRepository, factory *MockdeliveryCalculatorFactory, ctrl *gomock.Controller) {
+				repo.EXPECT().
+					GetCourierForUpdate(gomock.Any(), int64(1)).
+					Return(model.Courier{ID: 1, Status: model.CourierStatusInactive}, nil)
+				repo.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectations: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
 		{
 			name: "error: repository error",
 			request: model.Courier{
@@ -453,6 +531,9 @@ func TestCourierUseCase_Update(t *testing.T) {
 				Name: nameUpdate,
 			},
 			prepare: func(repo *MockcourierRepository, factory *MockdeliveryCalculatorFactory, ctrl *gomock.Controller) {
+				repo.EXPECT().
+					GetCourierForUpdate(gomock.Any(), int64(1)).
+					Return(model.Courier{ID: 1, Name: "John Doe"}, nil)
 				repo.EXPECT().
 					UpdateCourier(gomock.Any(), gomock.Any()).
 					Return(errors.New("database error"))
@@ -475,7 +556,7 @@ func TestCourierUseCase_Update(t *testing.T) {
 			mockRepo := NewMockcourierRepository(ctrl)
 			mockFactory := NewMockdeliveryCalculatorFactory(ctrl)
 			mockLogger := NewMocklogger(ctrl)
-			uc := courier.NewCourierUseCase(mockRepo, mockFactory, mockLogger)
+			uc := courier.NewCourierUseCase(mockRepo, passthroughTxRunner(ctrl), ignoringAuditRecorder(ctrl), mockFactory, NewMockmetricsWriter(ctrl), mockLogger)
 
 			ctx := context.Background()
 
@@ -522,7 +603,7 @@ func TestCheckFreeCouriers(t *testing.T) {
 		tickerInterval    time.Duration
 		runDuration       time.Duration
 		cancelImmediately bool
-		prepare           func(repo *MockcourierRepository, logger *Mocklogger)
+		prepare           func(repo *MockcourierRepository, metrics *MockmetricsWriter, logger *Mocklogger)
 		expectations      func(t *testing.T)
 	}{
 		{
@@ -530,10 +611,26 @@ func TestCheckFreeCouriers(t *testing.T) {
 			tickerInterval:    50 * time.Millisecond,
 			runDuration:       150 * time.Millisecond,
 			cancelImmediately: false,
-			prepare: func(repo *MockcourierRepository, logger *Mocklogger) {
+			prepare: func(repo *MockcourierRepository, metrics *MockmetricsWriter, logger *Mocklogger) {
 				repo.EXPECT().
 					FreeCouriersWithInterval(gomock.Any()).
-					Return(nil).
+					Return(int64(1), nil).
+					MinTimes(2)
+				metrics.EXPECT().
+					RecordDeadlineMisses(int64(1)).
+					MinTimes(2)
+				repo.EXPECT().
+					CountAvailableCouriersByTransport(gomock.Any()).
+					Return(map[model.CourierTransportType]int{model.TransportTypeCar: 3}, nil).
+					MinTimes(2)
+				metrics.EXPECT().
+					SetAvailableCouriers(string(model.TransportTypeCar), 3).
+					MinTimes(2)
+				metrics.EXPECT().
+					SetAvailableCouriers(string(model.TransportTypeScooter), 0).
+					MinTimes(2)
+				metrics.EXPECT().
+					SetAvailableCouriers(string(model.TransportTypeOnFoot), 0).
 					MinTimes(2)
 				logger.EXPECT().
 					Debugf(gomock.Any(), gomock.Any()).
@@ -547,10 +644,14 @@ func TestCheckFreeCouriers(t *testing.T) {
 			tickerInterval:    50 * time.Millisecond,
 			runDuration:       150 * time.Millisecond,
 			cancelImmediately: false,
-			prepare: func(repo *MockcourierRepository, logger *Mocklogger) {
+			prepare: func(repo *MockcourierRepository, metrics *MockmetricsWriter, logger *Mocklogger) {
 				repo.EXPECT().
 					FreeCouriersWithInterval(gomock.Any()).
-					Return(courierRepo.ErrCouriersBusy).
+					Return(int64(0), courierRepo.ErrCouriersBusy).
+					MinTimes(2)
+				repo.EXPECT().
+					CountAvailableCouriersByTransport(gomock.Any()).
+					Return(nil, courierRepo.ErrCouriersBusy).
 					MinTimes(2)
 				logger.EXPECT().
 					Errorf(gomock.Any(), gomock.Any()).
@@ -567,7 +668,7 @@ func TestCheckFreeCouriers(t *testing.T) {
 			tickerInterval:    50 * time.Millisecond,
 			runDuration:       0,
 			cancelImmediately: true,
-			prepare: func(repo *MockcourierRepository, logger *Mocklogger) {
+			prepare: func(repo *MockcourierRepository, metrics *MockmetricsWriter, logger *Mocklogger) {
 			},
 			expectations: func(t *testing.T) {
 			},
@@ -584,14 +685,15 @@ func TestCheckFreeCouriers(t *testing.T) {
 
 			mockCourierRepo := NewMockcourierRepository(ctrl)
 			mockFactory := NewMockdeliveryCalculatorFactory(ctrl)
+			mockMetrics := NewMockmetricsWriter(ctrl)
 			mockLogger := NewMocklogger(ctrl)
-			uc := courier.NewCourierUseCase(mockCourierRepo, mockFactory, mockLogger)
+			uc := courier.NewCourierUseCase(mockCourierRepo, NewMocktxRunner(ctrl), NewMockauditRecorder(ctrl), mockFactory, mockMetrics, mockLogger)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 
 			if tc.prepare != nil {
-				tc.prepare(mockCourierRepo, mockLogger)
+				tc.prepare(mockCourierRepo, mockMetrics, mockLogger)
 			}
 
 			go uc.CheckFreeCouriersWithInterval(ctx, tc.tickerInterval)
@@ -611,3 +713,328 @@ func TestCheckFreeCouriers(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckFreeCouriers_SetCheckInterval(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCourierRepo := NewMockcourierRepository(ctrl)
+	mockMetrics := NewMockmetricsWriter(ctrl)
+	mockLogger := NewMocklogger(ctrl)
+	uc := courier.NewCourierUseCase(mockCourierRepo, NewMocktxRunner(ctrl), NewMockauditRecorder(ctrl), NewMockdeliveryCalculatorFactory(ctrl), mockMetrics, mockLogger)
+
+	mockLogger.EXPECT().Infof(gomock.Any(), 20*time.Millisecond).Times(1)
+	mockLogger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	mockCourierRepo.EXPECT().FreeCouriersWithInterval(gomock.Any()).Return(int64(0), nil).MinTimes(2)
+	mockCourierRepo.EXPECT().CountAvailableCouriersByTransport(gomock.Any()).
+		Return(map[model.CourierTransportType]int{}, nil).MinTimes(2)
+	mockMetrics.EXPECT().RecordDeadlineMisses(int64(0)).MinTimes(2)
+	mockMetrics.EXPECT().SetAvailableCouriers(gomock.Any(), 0).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		uc.CheckFreeCouriersWithInterval(ctx, time.Hour)
+		close(done)
+	}()
+
+	// без смены интервала проверка не сработала бы до конца теста
+	uc.SetCheckInterval(20 * time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	<-done
+}
+
+func TestCourierUseCase_Lifecycle(t *testing.T) {
+	deletedAt := time.Now()
+	available := model.Courier{ID: 1, Status: model.CourierStatusAvailable}
+	busy := model.Courier{ID: 1, Status: model.CourierStatusBusy}
+	inactive := model.Courier{ID: 1, Status: model.CourierStatusInactive}
+	deleted := model.Courier{ID: 1, Status: model.CourierStatusInactive, DeletedAt: &deletedAt}
+
+	type action func(uc *courier.CourierUseCase, ctx context.Context) error
+	deleteCourier := func(uc *courier.CourierUseCase, ctx context.Context) error { return uc.DeleteCourier(ctx, 1) }
+	deactivate := func(uc *courier.CourierUseCase, ctx context.Context) error { return uc.DeactivateCourier(ctx, 1) }
+	reactivate := func(uc *courier.CourierUseCase, ctx context.Context) error { return uc.ReactivateCourier(ctx, 1) }
+	erase := func(uc *courier.CourierUseCase, ctx context.Context) error { return uc.EraseCourier(ctx, 1) }
+
+	tests := []struct {
+		name    string
+		action  action
+		locked  model.Courier
+		lockErr error
+		prepare func(repo *MockcourierRepository)
+		audited model.AuditAction
+		wantErr error
+	}{
+		{
+			name:   "delete: available courier",
+			action: deleteCourier,
+			locked: available,
+			prepare: func(repo *MockcourierRepository) {
+				repo.EXPECT().HasOpenDelivery(gomock.Any(), int64(1)).Return(false, nil)
+				repo.EXPECT().DeleteCourier(gomock.Any(), int64(1)).Return(nil)
+			},
+			audited: model.AuditActionCourierDelete,
+		},
+		{
+			name:   "delete: open delivery after deadline",
+			action: deleteCourier,
+			locked: available,
+			prepare: func(repo *MockcourierRepository) {
+				repo.EXPECT().HasOpenDelivery(gomock.Any(), int64(1)).Return(true, nil)
+			},
+			wantErr: courier.ErrCourierOnDelivery,
+		},
+		{
+			name:    "delete: courier on delivery",
+			action:  deleteCourier,
+			locked:  busy,
+			wantErr: courier.ErrCourierOnDelivery,
+		},
+		{
+			name:    "delete: already deleted",
+			action:  deleteCourier,
+			locked:  deleted,
+			wantErr: courier.ErrCourierNotFound,
+		},
+		{
+			name:    "delete: not found",
+			action:  deleteCourier,
+			lockErr: courierRepo.ErrCourierNotFound,
+			wantErr: courier.ErrCourierNotFound,
+		},
+		{
+			name:   "deactivate: available courier",
+			action: deactivate,
+			locked: available,
+			prepare: func(repo *MockcourierRepository) {
+				repo.EXPECT().HasOpenDelivery(gomock.Any(), int64(1)).Return(false, nil)
+				repo.EXPECT().
+					UpdateCourier(gomock.Any(), model.Courier{ID: 1, Status: model.CourierStatusInactive}).
+					Return(nil)
+			},
+			audited: model.AuditActionCourierDeactivate,
+		},
+		{
+			name:   "deactivate: already inactive",
+			action: deactivate,
+			locked: inactive,
+		},
+		{
+			name:   "deactivate: open delivery after deadline",
+			action: deactivate,
+			locked: available,
+			prepare: func(repo *MockcourierRepository) {
+				repo.EXPECT().HasOpenDelivery(gomock.Any(), int64(1)).Return(true, nil)
+			},
+			wantErr: courier.ErrCourierOnDelivery,
+		},
+		{
+			name:    "deactivate: courier on delivery",
+			action:  deactivate,
+			locked:  busy,
+			wantErr: courier.ErrCourierOnDelivery,
+		},
+		{
+			name:    "deactivate: deleted courier",
+			action:  deactivate,
+			locked:  deleted,
+			wantErr: courier.ErrCourierNotFound,
+		},
+		{
+			name:   "reactivate: inactive courier",
+			action: reactivate,
+			locked: inactive,
+			prepare: func(repo *MockcourierRepository) {
+				repo.EXPECT().
+					UpdateCourier(gomock.Any(), model.Courier{ID: 1, Status: model.CourierStatusAvailable}).
+					Return(nil)
+			},
+			audited: model.AuditActionCourierReactivate,
+		},
+		{
+			name:   "reactivate: courier on delivery stays busy",
+			action: reactivate,
+			locked: busy,
+		},
+		{
+			name:    "reactivate: deleted courier",
+			action:  reactivate,
+			locked:  deleted,
+			wantErr: courier.ErrCourierNotFound,
+		},
+		{
+			name:   "erase: deleted courier",
+			action: erase,
+			locked: deleted,
+			prepare: func(repo *MockcourierRepository) {
+				repo.EXPECT().EraseCourier(gomock.Any(), int64(1)).Return(nil)
+			},
+			audited: model.AuditActionCourierErase,
+		},
+		{
+			name:   "erase: active courier",
+			action: erase,
+			locked: available,
+			prepare: func(repo *MockcourierRepository) {
+				repo.EXPECT().HasOpenDelivery(gomock.Any(), int64(1)).Return(false, nil)
+				repo.EXPECT().EraseCourier(gomock.Any(), int64(1)).Return(nil)
+			},
+			audited: model.AuditActionCourierErase,
+		},
+		{
+			name:   "erase: open delivery after deadline",
+			action: erase,
+			locked: available,
+			prepare: func(repo *MockcourierRepository) {
+				repo.EXPECT().HasOpenDelivery(gomock.Any(), int64(1)).Return(true, nil)
+			},
+			wantErr: courier.ErrCourierOnDelivery,
+		},
+		{
+			name:    "erase: courier on delivery",
+			action:  erase,
+			locked:  busy,
+			wantErr: courier.ErrCourierOnDelivery,
+		},
+		{
+			name:    "erase: lock error",
+			action:  erase,
+			lockErr: assert.AnError,
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := NewMockcourierRepository(ctrl)
+			mockTxRunner := NewMocktxRunner(ctrl)
+			mockTxRunner.EXPECT().
+				Run(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+			mockRepo.EXPECT().
+				GetCourierForUpdate(gomock.Any(), int64(1)).
+				Return(tc.locked, tc.lockErr)
+			if tc.prepare != nil {
+				tc.prepare(mockRepo)
+			}
+			mockAudit := NewMockauditRecorder(ctrl)
+			if tc.audited != "" {
+				mockAudit.EXPECT().
+					Record(gomock.Any(), tc.audited, model.AuditEntityCourier, "1", gomock.Any(), gomock.Any()).
+					Return(nil)
+			}
+
+			uc := courier.NewCourierUseCase(mockRepo, mockTxRunner, mockAudit, NewMockdeliveryCalculatorFactory(ctrl), NewMockmetricsWriter(ctrl), NewMocklogger(ctrl))
+
+			err := tc.action(uc, context.Background())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCourierUseCase_Audit(t *testing.T) {
+	locked := model.Courier{
+		ID:            1,
+		Name:          "John Doe",
+		Phone:         "+79991234567",
+		Status:        model.CourierStatusAvailable,
+		TransportType: model.TransportTypeCar,
+	}
+
+	t.Run("update records changed courier", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockcourierRepository(ctrl)
+		mockAudit := NewMockauditRecorder(ctrl)
+
+		update := model.Courier{ID: 1, Phone: "+79997654321"}
+		mockRepo.EXPECT().ExistsCourierByPhone(gomock.Any(), update.Phone).Return(false, nil)
+		mockRepo.EXPECT().GetCourierForUpdate(gomock.Any(), int64(1)).Return(locked, nil)
+		mockRepo.EXPECT().UpdateCourier(gomock.Any(), update).Return(nil)
+
+		after := locked
+		after.Phone = update.Phone
+		mockAudit.EXPECT().
+			Record(gomock.Any(), model.AuditActionCourierUpdate, model.AuditEntityCourier, "1",
+				audit.CourierSnapshot(locked), audit.CourierSnapshot(after)).
+			Return(nil)
+
+		uc := courier.NewCourierUseCase(mockRepo, passthroughTxRunner(ctrl), mockAudit, NewMockdeliveryCalculatorFactory(ctrl), NewMockmetricsWriter(ctrl), NewMocklogger(ctrl))
+		assert.NoError(t, uc.UpdateCourier(context.Background(), update))
+	})
+
+	t.Run("erase drops personal fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockcourierRepository(ctrl)
+		mockAudit := NewMockauditRecorder(ctrl)
+
+		mockRepo.EXPECT().GetCourierForUpdate(gomock.Any(), int64(1)).Return(locked, nil)
+		mockRepo.EXPECT().HasOpenDelivery(gomock.Any(), int64(1)).Return(false, nil)
+		mockRepo.EXPECT().EraseCourier(gomock.Any(), int64(1)).Return(nil)
+		mockAudit.EXPECT().
+			Record(gomock.Any(), model.AuditActionCourierErase, model.AuditEntityCourier, "1", audit.CourierSnapshot(locked), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ model.AuditAction, _ model.AuditEntity, _ string, _, after audit.Snapshot) error {
+				assert.NotContains(t, after, "name")
+				assert.NotContains(t, after, "phone")
+				assert.Equal(t, true, after["deleted"])
+				return nil
+			})
+
+		uc := courier.NewCourierUseCase(mockRepo, passthroughTxRunner(ctrl), mockAudit, NewMockdeliveryCalculatorFactory(ctrl), NewMockmetricsWriter(ctrl), NewMocklogger(ctrl))
+		assert.NoError(t, uc.EraseCourier(context.Background(), 1))
+	})
+
+	t.Run("audit failure aborts create", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := NewMockcourierRepository(ctrl)
+		mockFactory := NewMockdeliveryCalculatorFactory(ctrl)
+		mockAudit := NewMockauditRecorder(ctrl)
+
+		created := locked
+		created.ID = 0
+		mockFactory.EXPECT().GetDeliveryCalculator(model.TransportTypeCar).Return(NewMockDeliveryCalculator(ctrl))
+		mockRepo.EXPECT().ExistsCourierByPhone(gomock.Any(), created.Phone).Return(false, nil)
+		mockRepo.EXPECT().CreateCourier(gomock.Any(), created).Return(int64(1), nil)
+		mockAudit.EXPECT().
+			Record(gomock.Any(), model.AuditActionCourierCreate, model.AuditEntityCourier, "1", nil, audit.CourierSnapshot(created)).
+			Return(assert.AnError)
+
+		uc := courier.NewCourierUseCase(mockRepo, passthroughTxRunner(ctrl), mockAudit, mockFactory, NewMockmetricsWriter(ctrl), NewMocklogger(ctrl))
+		id, err := uc.CreateCourier(context.Background(), created)
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Zero(t, id)
+	})
+}
+
+// passthroughTxRunner выполняет функцию без транзакции.
+func passthroughTxRunner(ctrl *gomock.Controller) *MocktxRunner {
+	txRunner := NewMocktxRunner(ctrl)
+	txRunner.EXPECT().
+		Run(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+	return txRunner
+}
+
+func ignoringAuditRecorder(ctrl *gomock.Controller) *MockauditRecorder {
+	recorder := NewMockauditRecorder(ctrl)
+	recorder.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return recorder
+}
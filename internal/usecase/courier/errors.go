@@ -11,6 +11,10 @@ var (
 	ErrIdRequired         = errors.New("id is required")
 	ErrInvalidUpdate      = errors.New("no fields provided for update")
 	ErrCouriersBusy       = errors.New("all couriers are busy")
+	ErrCourierOnDelivery  = errors.New("courier has an active delivery")
+	// ErrInactiveStatusChange — статус inactive меняется только через
+	// DeactivateCourier и ReactivateCourier.
+	ErrInactiveStatusChange = errors.New("inactive status is changed by deactivate and reactivate only")
 
 	ErrUnknownTransportType = errors.New("unknown transport type")
 	ErrNoOrderID            = errors.New("order id is required")
@@ -7,6 +7,7 @@ package courier_test
 import (
 	context "context"
 	model "courier-service/internal/model"
+	audit "courier-service/internal/usecase/audit"
 	courier "courier-service/internal/usecase/courier"
 	reflect "reflect"
 
@@ -36,6 +37,21 @@ func (m *MockcourierRepository) EXPECT() *MockcourierRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountAvailableCouriersByTransport mocks base method.
+func (m *MockcourierRepository) CountAvailableCouriersByTransport(ctx context.Context) (map[model.CourierTransportType]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAvailableCouriersByTransport", ctx)
+	ret0, _ := ret[0].(map[model.CourierTransportType]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAvailableCouriersByTransport indicates an expected call of CountAvailableCouriersByTransport.
+func (mr *MockcourierRepositoryMockRecorder) CountAvailableCouriersByTransport(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAvailableCouriersByTransport", reflect.TypeOf((*MockcourierRepository)(nil).CountAvailableCouriersByTransport), ctx)
+}
+
 // CreateCourier mocks base method.
 func (m *MockcourierRepository) CreateCourier(ctx context.Context, courier model.Courier) (int64, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +67,34 @@ func (mr *MockcourierRepositoryMockRecorder) CreateCourier(ctx, courier interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourier", reflect.TypeOf((*MockcourierRepository)(nil).CreateCourier), ctx, courier)
 }
 
+// DeleteCourier mocks base method.
+func (m *MockcourierRepository) DeleteCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourier indicates an expected call of DeleteCourier.
+func (mr *MockcourierRepositoryMockRecorder) DeleteCourier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourier", reflect.TypeOf((*MockcourierRepository)(nil).DeleteCourier), ctx, id)
+}
+
+// EraseCourier mocks base method.
+func (m *MockcourierRepository) EraseCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseCourier indicates an expected call of EraseCourier.
+func (mr *MockcourierRepositoryMockRecorder) EraseCourier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseCourier", reflect.TypeOf((*MockcourierRepository)(nil).EraseCourier), ctx, id)
+}
+
 // ExistsCourierByPhone mocks base method.
 func (m *MockcourierRepository) ExistsCourierByPhone(ctx context.Context, phone string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -82,11 +126,12 @@ func (mr *MockcourierRepositoryMockRecorder) FindAvailableCourier(ctx interface{
 }
 
 // FreeCouriersWithInterval mocks base method.
-func (m *MockcourierRepository) FreeCouriersWithInterval(ctx context.Context) error {
+func (m *MockcourierRepository) FreeCouriersWithInterval(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FreeCouriersWithInterval", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // FreeCouriersWithInterval indicates an expected call of FreeCouriersWithInterval.
@@ -125,6 +170,36 @@ func (mr *MockcourierRepositoryMockRecorder) GetCourierById(ctx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierById", reflect.TypeOf((*MockcourierRepository)(nil).GetCourierById), ctx, id)
 }
 
+// GetCourierForUpdate mocks base method.
+func (m *MockcourierRepository) GetCourierForUpdate(ctx context.Context, id int64) (model.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierForUpdate", ctx, id)
+	ret0, _ := ret[0].(model.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierForUpdate indicates an expected call of GetCourierForUpdate.
+func (mr *MockcourierRepositoryMockRecorder) GetCourierForUpdate(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierForUpdate", reflect.TypeOf((*MockcourierRepository)(nil).GetCourierForUpdate), ctx, id)
+}
+
+// HasOpenDelivery mocks base method.
+func (m *MockcourierRepository) HasOpenDelivery(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOpenDelivery", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOpenDelivery indicates an expected call of HasOpenDelivery.
+func (mr *MockcourierRepositoryMockRecorder) HasOpenDelivery(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOpenDelivery", reflect.TypeOf((*MockcourierRepository)(nil).HasOpenDelivery), ctx, id)
+}
+
 // UpdateCourier mocks base method.
 func (m *MockcourierRepository) UpdateCourier(ctx context.Context, courier model.Courier) error {
 	m.ctrl.T.Helper()
@@ -139,6 +214,80 @@ func (mr *MockcourierRepositoryMockRecorder) UpdateCourier(ctx, courier interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourier", reflect.TypeOf((*MockcourierRepository)(nil).UpdateCourier), ctx, courier)
 }
 
+// MocktxRunner is a mock of txRunner interface.
+type MocktxRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MocktxRunnerMockRecorder
+}
+
+// MocktxRunnerMockRecorder is the mock recorder for MocktxRunner.
+type MocktxRunnerMockRecorder struct {
+	mock *MocktxRunner
+}
+
+// NewMocktxRunner creates a new mock instance.
+func NewMocktxRunner(ctrl *gomock.Controller) *MocktxRunner {
+	mock := &MocktxRunner{ctrl: ctrl}
+	mock.recorder = &MocktxRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktxRunner) EXPECT() *MocktxRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MocktxRunner) Run(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MocktxRunnerMockRecorder) Run(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MocktxRunner)(nil).Run), ctx, fn)
+}
+
+// MockauditRecorder is a mock of auditRecorder interface.
+type MockauditRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditRecorderMockRecorder
+}
+
+// MockauditRecorderMockRecorder is the mock recorder for MockauditRecorder.
+type MockauditRecorderMockRecorder struct {
+	mock *MockauditRecorder
+}
+
+// NewMockauditRecorder creates a new mock instance.
+func NewMockauditRecorder(ctrl *gomock.Controller) *MockauditRecorder {
+	mock := &MockauditRecorder{ctrl: ctrl}
+	mock.recorder = &MockauditRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditRecorder) EXPECT() *MockauditRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockauditRecorder) Record(ctx context.Context, action model.AuditAction, entity model.AuditEntity, entityID string, before, after audit.Snapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, action, entity, entityID, before, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockauditRecorderMockRecorder) Record(ctx, action, entity, entityID, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockauditRecorder)(nil).Record), ctx, action, entity, entityID, before, after)
+}
+
 // MockdeliveryCalculatorFactory is a mock of deliveryCalculatorFactory interface.
 type MockdeliveryCalculatorFactory struct {
 	ctrl     *gomock.Controller
@@ -176,6 +325,53 @@ func (mr *MockdeliveryCalculatorFactoryMockRecorder) GetDeliveryCalculator(couri
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryCalculator", reflect.TypeOf((*MockdeliveryCalculatorFactory)(nil).GetDeliveryCalculator), courierType)
 }
 
+// MockmetricsWriter is a mock of metricsWriter interface.
+type MockmetricsWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockmetricsWriterMockRecorder
+}
+
+// MockmetricsWriterMockRecorder is the mock recorder for MockmetricsWriter.
+type MockmetricsWriterMockRecorder struct {
+	mock *MockmetricsWriter
+}
+
+// NewMockmetricsWriter creates a new mock instance.
+func NewMockmetricsWriter(ctrl *gomock.Controller) *MockmetricsWriter {
+	mock := &MockmetricsWriter{ctrl: ctrl}
+	mock.recorder = &MockmetricsWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmetricsWriter) EXPECT() *MockmetricsWriterMockRecorder {
+	return m.recorder
+}
+
+// RecordDeadlineMisses mocks base method.
+func (m *MockmetricsWriter) RecordDeadlineMisses(count int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordDeadlineMisses", count)
+}
+
+// RecordDeadlineMisses indicates an expected call of RecordDeadlineMisses.
+func (mr *MockmetricsWriterMockRecorder) RecordDeadlineMisses(count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDeadlineMisses", reflect.TypeOf((*MockmetricsWriter)(nil).RecordDeadlineMisses), count)
+}
+
+// SetAvailableCouriers mocks base method.
+func (m *MockmetricsWriter) SetAvailableCouriers(transportType string, count int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAvailableCouriers", transportType, count)
+}
+
+// SetAvailableCouriers indicates an expected call of SetAvailableCouriers.
+func (mr *MockmetricsWriterMockRecorder) SetAvailableCouriers(transportType, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAvailableCouriers", reflect.TypeOf((*MockmetricsWriter)(nil).SetAvailableCouriers), transportType, count)
+}
+
 // Mocklogger is a mock of logger interface.
 type Mocklogger struct {
 	ctrl     *gomock.Controller
@@ -8,26 +8,50 @@ import (
 	"courier-service/internal/model"
 	courierrepoerrors "courier-service/internal/repository/courier"
 	deliveryrepoerrors "courier-service/internal/repository/delivery"
+	"courier-service/internal/usecase/audit"
+)
+
+// Исходы назначения для метрики delivery_assignments_total.
+const (
+	outcomeAssigned        = "assigned"
+	outcomeNoCourier       = "no_courier"
+	outcomeAlreadyAssigned = "already_assigned"
+	outcomeFailed          = "failed"
+
+	// transportTypeNone — метка для попыток, в которых курьер не нашелся.
+	transportTypeNone = "none"
 )
 
 type AssignDelieveryUseCase struct {
 	courierRepository  courierRepository
 	deliveryRepository deliveryRepository
 	txRunner           txRunner
+	audit              auditRecorder
 	factory            deliveryCalculatorFactory
+	orderGateway       orderGateway
+	metrics            metricsWriter
+	logger             logger
 }
 
 func NewAssignDelieveryUseCase(
 	courierRepository courierRepository,
 	deliveryRepository deliveryRepository,
 	txRunner txRunner,
+	audit auditRecorder,
 	factory deliveryCalculatorFactory,
+	orderGateway orderGateway,
+	metrics metricsWriter,
+	logger logger,
 ) *AssignDelieveryUseCase {
 	return &AssignDelieveryUseCase{
 		courierRepository:  courierRepository,
 		deliveryRepository: deliveryRepository,
 		txRunner:           txRunner,
+		audit:              audit,
 		factory:            factory,
+		orderGateway:       orderGateway,
+		metrics:            metrics,
+		logger:             logger,
 	}
 }
 
@@ -36,8 +60,11 @@ func (u *AssignDelieveryUseCase) Assign(ctx context.Context, OrderID string) (De
 		return DeliveryAssignResponse{}, ErrNoOrderID
 	}
 	var resp DeliveryAssignResponse
+	// снимок берется до транзакции, чтобы не держать блокировку курьера на время gRPC вызова
+	order := u.orderSnapshot(ctx, OrderID)
 	var courier model.Courier
 	var delivery model.Delivery
+	transportType := transportTypeNone
 	err := u.txRunner.Run(ctx, func(txCtx context.Context) error {
 		c, err := u.courierRepository.FindAvailableCourier(txCtx)
 		if err != nil {
@@ -46,6 +73,7 @@ func (u *AssignDelieveryUseCase) Assign(ctx context.Context, OrderID string) (De
 			}
 			return err
 		}
+		transportType = string(c.TransportType)
 
 		dc := u.factory.GetDeliveryCalculator(c.TransportType)
 		if dc == nil {
@@ -56,6 +84,7 @@ func (u *AssignDelieveryUseCase) Assign(ctx context.Context, OrderID string) (De
 			CourierID:  c.ID,
 			AssignedAt: time.Now(),
 			Deadline:   dc.CalculateDeadline(),
+			Order:      order,
 		}
 
 		d, err := u.deliveryRepository.CreateDelivery(txCtx, deliveryDomain)
@@ -70,14 +99,49 @@ func (u *AssignDelieveryUseCase) Assign(ctx context.Context, OrderID string) (De
 		if err := u.courierRepository.UpdateCourier(txCtx, c); err != nil {
 			return err
 		}
+		if err := u.audit.Record(txCtx, model.AuditActionDeliveryAssign, model.AuditEntityDelivery, OrderID, nil, audit.DeliverySnapshot(d)); err != nil {
+			return err
+		}
 
 		courier = c
 		delivery = d
 		return nil
 	})
+	u.recordAssignment(transportType, order, delivery, err)
 	if err != nil {
 		return DeliveryAssignResponse{}, err
 	}
 	resp = deliveryAssignResponse(courier, delivery)
 	return resp, nil
 }
+
+func (u *AssignDelieveryUseCase) recordAssignment(transportType string, order *model.Order, delivery model.Delivery, err error) {
+	u.metrics.RecordAssignment(transportType, assignmentOutcome(err))
+	if err == nil && order != nil && !order.CreatedAt.IsZero() {
+		u.metrics.RecordOrderToAssignment(transportType, delivery.AssignedAt.Sub(order.CreatedAt).Seconds())
+	}
+}
+
+func assignmentOutcome(err error) string {
+	switch {
+	case err == nil:
+		return outcomeAssigned
+	case errors.Is(err, ErrCouriersBusy):
+		return outcomeNoCourier
+	case errors.Is(err, ErrOrderIDExists):
+		return outcomeAlreadyAssigned
+	default:
+		return outcomeFailed
+	}
+}
+
+// orderSnapshot не блокирует назначение: без ответа сервиса заказов
+// доставка создается без снимка.
+func (u *AssignDelieveryUseCase) orderSnapshot(ctx context.Context, orderID string) *model.Order {
+	order, err := u.orderGateway.GetOrderById(ctx, orderID)
+	if err != nil {
+		u.logger.Warnf("failed to get order %s for delivery snapshot: %v", orderID, err)
+		return nil
+	}
+	return &order
+}
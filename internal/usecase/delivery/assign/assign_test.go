@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	ordergw "courier-service/internal/gateway/order"
 	"courier-service/internal/model"
 	courierstorage "courier-service/internal/repository/courier"
 	deliverystorage "courier-service/internal/repository/delivery"
@@ -25,6 +26,7 @@ func TestAssignDelivery(t *testing.T) {
 			deliveryRepository *MockdeliveryRepository,
 			txRunner *MocktxRunner,
 			factory *MockdeliveryCalculatorFactory,
+			orderGateway *MockorderGateway,
 			ctrl *gomock.Controller,
 		)
 		expectations func(t *testing.T, resp assign.DeliveryAssignResponse, err error)
@@ -37,10 +39,20 @@ func TestAssignDelivery(t *testing.T) {
 				deliveryRepository *MockdeliveryRepository,
 				txRunner *MocktxRunner,
 				factory *MockdeliveryCalculatorFactory,
+				orderGateway *MockorderGateway,
 				ctrl *gomock.Controller,
 			) {
 				now := time.Now()
 
+				orderGateway.EXPECT().
+					GetOrderById(gomock.Any(), "550e8400-e29b-41d4-a716-446655440001").
+					Return(model.Order{
+						ID:           "550e8400-e29b-41d4-a716-446655440001",
+						RestaurantID: "restaurant-1",
+						TotalPrice:   1500,
+						Address:      model.OrderAddress{Street: "Tverskaya", House: "1"},
+						Status:       model.OrderStatusCreated,
+					}, nil)
 				txRunner.EXPECT().
 					Run(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
@@ -67,13 +79,12 @@ func TestAssignDelivery(t *testing.T) {
 
 				deliveryRepository.EXPECT().
 					CreateDelivery(gomock.Any(), gomock.Any()).
-					Return(model.Delivery{
-						ID:         1,
-						CourierID:  1,
-						OrderID:    "550e8400-e29b-41d4-a716-446655440001",
-						AssignedAt: now,
-						Deadline:   now.Add(5 * time.Minute),
-					}, nil)
+					DoAndReturn(func(ctx context.Context, d model.Delivery) (model.Delivery, error) {
+						assert.NotNil(t, d.Order)
+						assert.Equal(t, "restaurant-1", d.Order.RestaurantID)
+						d.ID = 1
+						return d, nil
+					})
 
 				courierRepository.EXPECT().
 					UpdateCourier(gomock.Any(), gomock.Any()).
@@ -87,6 +98,72 @@ func TestAssignDelivery(t *testing.T) {
 				assert.Equal(t, int64(1), resp.CourierID)
 				assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", resp.OrderID)
 				assert.Equal(t, "car", resp.TransportType)
+				if assert.NotNil(t, resp.Order) {
+					assert.Equal(t, int64(1500), resp.Order.TotalPrice)
+					assert.Equal(t, "Tverskaya", resp.Order.Address.Street)
+				}
+			},
+		},
+		{
+			name:    "success: order service unavailable, delivery assigned without snapshot",
+			orderID: "550e8400-e29b-41d4-a716-446655440005",
+			prepare: func(
+				courierRepository *MockcourierRepository,
+				deliveryRepository *MockdeliveryRepository,
+				txRunner *MocktxRunner,
+				factory *MockdeliveryCalculatorFactory,
+				orderGateway *MockorderGateway,
+				ctrl *gomock.Controller,
+			) {
+				now := time.Now()
+
+				orderGateway.EXPECT().
+					GetOrderById(gomock.Any(), gomock.Any()).
+					Return(model.Order{}, ordergw.ErrServiceUnavailable)
+				txRunner.EXPECT().
+					Run(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				calculator := NewMockDeliveryCalculator(ctrl)
+				factory.EXPECT().
+					GetDeliveryCalculator(model.TransportTypeCar).
+					Return(calculator)
+				calculator.EXPECT().
+					CalculateDeadline().
+					Return(now.Add(5 * time.Minute))
+
+				courierRepository.EXPECT().
+					FindAvailableCourier(gomock.Any()).
+					Return(model.Courier{
+						ID:            1,
+						Name:          "John",
+						Phone:         "+79991234567",
+						Status:        model.CourierStatusAvailable,
+						TransportType: "car",
+					}, nil)
+
+				deliveryRepository.EXPECT().
+					CreateDelivery(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, d model.Delivery) (model.Delivery, error) {
+						assert.Nil(t, d.Order)
+						d.ID = 1
+						return d, nil
+					})
+
+				courierRepository.EXPECT().
+					UpdateCourier(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, c model.Courier) error {
+						assert.Equal(t, model.CourierStatusBusy, c.Status)
+						return nil
+					})
+			},
+			expectations: func(t *testing.T, resp assign.DeliveryAssignResponse, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), resp.CourierID)
+				assert.Equal(t, "550e8400-e29b-41d4-a716-446655440005", resp.OrderID)
+				assert.Nil(t, resp.Order)
 			},
 		},
 		{
@@ -97,6 +174,7 @@ func TestAssignDelivery(t *testing.T) {
 				deliveryRepository *MockdeliveryRepository,
 				txRunner *MocktxRunner,
 				factory *MockdeliveryCalculatorFactory,
+				orderGateway *MockorderGateway,
 				ctrl *gomock.Controller,
 			) {
 				// No mock expectations - validation happens before any repo calls
@@ -115,8 +193,12 @@ func TestAssignDelivery(t *testing.T) {
 				deliveryRepository *MockdeliveryRepository,
 				txRunner *MocktxRunner,
 				factory *MockdeliveryCalculatorFactory,
+				orderGateway *MockorderGateway,
 				ctrl *gomock.Controller,
 			) {
+				orderGateway.EXPECT().
+					GetOrderById(gomock.Any(), gomock.Any()).
+					Return(model.Order{}, ordergw.ErrServiceUnavailable)
 				txRunner.EXPECT().
 					Run(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
@@ -140,10 +222,14 @@ func TestAssignDelivery(t *testing.T) {
 				deliveryRepository *MockdeliveryRepository,
 				txRunner *MocktxRunner,
 				factory *MockdeliveryCalculatorFactory,
+				orderGateway *MockorderGateway,
 				ctrl *gomock.Controller,
 			) {
 				now := time.Now()
 
+				orderGateway.EXPECT().
+					GetOrderById(gomock.Any(), gomock.Any()).
+					Return(model.Order{}, ordergw.ErrServiceUnavailable)
 				txRunner.EXPECT().
 					Run(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
@@ -187,10 +273,14 @@ func TestAssignDelivery(t *testing.T) {
 				deliveryRepository *MockdeliveryRepository,
 				txRunner *MocktxRunner,
 				factory *MockdeliveryCalculatorFactory,
+				orderGateway *MockorderGateway,
 				ctrl *gomock.Controller,
 			) {
 				now := time.Now()
 
+				orderGateway.EXPECT().
+					GetOrderById(gomock.Any(), gomock.Any()).
+					Return(model.Order{}, ordergw.ErrServiceUnavailable)
 				txRunner.EXPECT().
 					Run(gomock.Any(), gomock.Any()).
 					DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
@@ -250,12 +340,32 @@ func TestAssignDelivery(t *testing.T) {
 			mockTxRunner := NewMocktxRunner(ctrl)
 			mockFactory := NewMockdeliveryCalculatorFactory(ctrl)
 
-			uc := assign.NewAssignDelieveryUseCase(mockCourierRepo, mockDeliveryRepo, mockTxRunner, mockFactory)
+			mockOrderGateway := NewMockorderGateway(ctrl)
+			mockLogger := NewMocklogger(ctrl)
+			mockLogger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+			mockMetrics := NewMockmetricsWriter(ctrl)
+			mockMetrics.EXPECT().RecordAssignment(gomock.Any(), gomock.Any()).AnyTimes()
+			mockMetrics.EXPECT().RecordOrderToAssignment(gomock.Any(), gomock.Any()).AnyTimes()
+			mockAudit := NewMockauditRecorder(ctrl)
+			mockAudit.EXPECT().
+				Record(gomock.Any(), model.AuditActionDeliveryAssign, model.AuditEntityDelivery, tc.orderID, nil, gomock.Any()).
+				Return(nil).
+				AnyTimes()
+			uc := assign.NewAssignDelieveryUseCase(
+				mockCourierRepo,
+				mockDeliveryRepo,
+				mockTxRunner,
+				mockAudit,
+				mockFactory,
+				mockOrderGateway,
+				mockMetrics,
+				mockLogger,
+			)
 
 			ctx := context.Background()
 
 			if tc.prepare != nil {
-				tc.prepare(mockCourierRepo, mockDeliveryRepo, mockTxRunner, mockFactory, ctrl)
+				tc.prepare(mockCourierRepo, mockDeliveryRepo, mockTxRunner, mockFactory, mockOrderGateway, ctrl)
 			}
 
 			result, err := uc.Assign(ctx, tc.orderID)
@@ -266,3 +376,155 @@ func TestAssignDelivery(t *testing.T) {
 		})
 	}
 }
+
+func TestAssignDelivery_Metrics(t *testing.T) {
+	t.Parallel()
+
+	const orderID = "550e8400-e29b-41d4-a716-446655440009"
+	car := model.Courier{ID: 1, Status: model.CourierStatusAvailable, TransportType: model.TransportTypeCar}
+
+	tests := []struct {
+		name    string
+		courier model.Courier
+		findErr error
+		order   model.Order
+		orderOK bool
+		prepare func(metrics *MockmetricsWriter)
+	}{
+		{
+			name:    "assigned: outcome and time since order creation",
+			courier: car,
+			order:   model.Order{ID: orderID, CreatedAt: time.Now().Add(-90 * time.Second)},
+			orderOK: true,
+			prepare: func(metrics *MockmetricsWriter) {
+				metrics.EXPECT().RecordAssignment("car", "assigned")
+				metrics.EXPECT().
+					RecordOrderToAssignment("car", gomock.Any()).
+					Do(func(_ string, seconds float64) {
+						assert.InDelta(t, 90, seconds, 5)
+					})
+			},
+		},
+		{
+			name:    "assigned without order snapshot: no latency",
+			courier: car,
+			prepare: func(metrics *MockmetricsWriter) {
+				metrics.EXPECT().RecordAssignment("car", "assigned")
+			},
+		},
+		{
+			name:    "no available courier",
+			findErr: courierstorage.ErrCouriersBusy,
+			prepare: func(metrics *MockmetricsWriter) {
+				metrics.EXPECT().RecordAssignment("none", "no_courier")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			courierRepository := NewMockcourierRepository(ctrl)
+			deliveryRepository := NewMockdeliveryRepository(ctrl)
+			txRunner := NewMocktxRunner(ctrl)
+			factory := NewMockdeliveryCalculatorFactory(ctrl)
+			orderGateway := NewMockorderGateway(ctrl)
+			metrics := NewMockmetricsWriter(ctrl)
+			auditRecorder := NewMockauditRecorder(ctrl)
+			logger := NewMocklogger(ctrl)
+			logger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+			if tc.orderOK {
+				orderGateway.EXPECT().GetOrderById(gomock.Any(), orderID).Return(tc.order, nil)
+			} else {
+				orderGateway.EXPECT().GetOrderById(gomock.Any(), orderID).Return(model.Order{}, ordergw.ErrOrderNotFound)
+			}
+			txRunner.EXPECT().
+				Run(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+			courierRepository.EXPECT().FindAvailableCourier(gomock.Any()).Return(tc.courier, tc.findErr)
+			if tc.findErr == nil {
+				calculator := NewMockDeliveryCalculator(ctrl)
+				factory.EXPECT().GetDeliveryCalculator(tc.courier.TransportType).Return(calculator)
+				calculator.EXPECT().CalculateDeadline().Return(time.Now().Add(time.Hour))
+				deliveryRepository.EXPECT().
+					CreateDelivery(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d model.Delivery) (model.Delivery, error) {
+						return d, nil
+					})
+				courierRepository.EXPECT().UpdateCourier(gomock.Any(), gomock.Any()).Return(nil)
+				auditRecorder.EXPECT().
+					Record(gomock.Any(), model.AuditActionDeliveryAssign, model.AuditEntityDelivery, orderID, nil, gomock.Any()).
+					Return(nil)
+			}
+			tc.prepare(metrics)
+
+			uc := assign.NewAssignDelieveryUseCase(
+				courierRepository,
+				deliveryRepository,
+				txRunner,
+				auditRecorder,
+				factory,
+				orderGateway,
+				metrics,
+				logger,
+			)
+			_, _ = uc.Assign(context.Background(), orderID)
+		})
+	}
+}
+
+func TestAssignDelivery_AuditFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	orderID := "550e8400-e29b-41d4-a716-446655440001"
+	courierRepository := NewMockcourierRepository(ctrl)
+	deliveryRepository := NewMockdeliveryRepository(ctrl)
+	txRunner := NewMocktxRunner(ctrl)
+	auditRecorder := NewMockauditRecorder(ctrl)
+	factory := NewMockdeliveryCalculatorFactory(ctrl)
+	orderGateway := NewMockorderGateway(ctrl)
+	metrics := NewMockmetricsWriter(ctrl)
+	logger := NewMocklogger(ctrl)
+	logger.EXPECT().Warnf(gomock.Any(), gomock.Any()).AnyTimes()
+
+	courier := model.Courier{ID: 1, Status: model.CourierStatusAvailable, TransportType: model.TransportTypeCar}
+	orderGateway.EXPECT().GetOrderById(gomock.Any(), orderID).Return(model.Order{}, ordergw.ErrOrderNotFound)
+	txRunner.EXPECT().
+		Run(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	courierRepository.EXPECT().FindAvailableCourier(gomock.Any()).Return(courier, nil)
+	calculator := NewMockDeliveryCalculator(ctrl)
+	factory.EXPECT().GetDeliveryCalculator(model.TransportTypeCar).Return(calculator)
+	calculator.EXPECT().CalculateDeadline().Return(time.Now().Add(time.Hour))
+	deliveryRepository.EXPECT().
+		CreateDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d model.Delivery) (model.Delivery, error) {
+			return d, nil
+		})
+	courierRepository.EXPECT().UpdateCourier(gomock.Any(), gomock.Any()).Return(nil)
+	auditRecorder.EXPECT().
+		Record(gomock.Any(), model.AuditActionDeliveryAssign, model.AuditEntityDelivery, orderID, nil, gomock.Any()).
+		Return(assert.AnError)
+	metrics.EXPECT().RecordAssignment(string(model.TransportTypeCar), "failed")
+
+	uc := assign.NewAssignDelieveryUseCase(
+		courierRepository,
+		deliveryRepository,
+		txRunner,
+		auditRecorder,
+		factory,
+		orderGateway,
+		metrics,
+		logger,
+	)
+	_, err := uc.Assign(context.Background(), orderID)
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"courier-service/internal/model"
+	"courier-service/internal/usecase/audit"
 	utils "courier-service/internal/usecase/utils"
 )
 
@@ -15,7 +16,7 @@ type courierRepository interface {
 	UpdateCourier(ctx context.Context, courier model.Courier) error
 	FindAvailableCourier(ctx context.Context) (model.Courier, error)
 	ExistsCourierByPhone(ctx context.Context, phone string) (bool, error)
-	FreeCouriersWithInterval(ctx context.Context) error
+	FreeCouriersWithInterval(ctx context.Context) (int64, error)
 	GetCourierIDByOrderID(ctx context.Context, orderID string) (int64, error)
 }
 
@@ -29,8 +30,39 @@ type txRunner interface {
 	Run(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
+type auditRecorder interface {
+	Record(ctx context.Context, action model.AuditAction, entity model.AuditEntity, entityID string, before, after audit.Snapshot) error
+}
+
 type deliveryCalculatorFactory interface {
 	GetDeliveryCalculator(courierType model.CourierTransportType) DeliveryCalculator
 }
 
 type DeliveryCalculator = utils.DeliveryCalculator
+
+type orderGateway interface {
+	GetOrderById(ctx context.Context, orderID string) (model.Order, error)
+}
+
+type metricsWriter interface {
+	RecordAssignment(transportType, outcome string)
+	RecordOrderToAssignment(transportType string, duration float64)
+}
+
+type logger interface {
+	Debug(args ...interface{})
+	Debugf(format string, args ...interface{})
+	Debugw(msg string, keysAndValues ...interface{})
+
+	Info(args ...interface{})
+	Infof(format string, args ...interface{})
+
+	Warn(args ...interface{})
+	Warnf(format string, args ...interface{})
+
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+
+	Fatal(args ...interface{})
+	Fatalf(format string, args ...interface{})
+}
@@ -11,6 +11,7 @@ type DeliveryAssignResponse struct {
 	OrderID       string
 	TransportType string
 	Deadline      time.Time
+	Order         *model.Order
 }
 
 func deliveryAssignResponse(courier model.Courier, delivery model.Delivery) DeliveryAssignResponse {
@@ -19,5 +20,6 @@ func deliveryAssignResponse(courier model.Courier, delivery model.Delivery) Deli
 		OrderID:       delivery.OrderID,
 		TransportType: string(courier.TransportType),
 		Deadline:      delivery.Deadline,
+		Order:         delivery.Order,
 	}
 }
@@ -3,35 +3,39 @@ package complete
 import (
 	"context"
 	"errors"
-	"fmt"
 
-	"courier-service/internal/model"
-	courierrepo "courier-service/internal/repository/courier"
+	deliveryrepo "courier-service/internal/repository/delivery"
 )
 
 type CompleteDeliveryUseCase struct {
-	courierRepository courierRepository
+	courierRepository  courierRepository
+	deliveryRepository deliveryRepository
+	txRunner           txRunner
 }
 
-func NewCompleteDeliveryUseCase(courierRepository courierRepository) *CompleteDeliveryUseCase {
-	return &CompleteDeliveryUseCase{courierRepository: courierRepository}
+func NewCompleteDeliveryUseCase(
+	courierRepository courierRepository,
+	deliveryRepository deliveryRepository,
+	txRunner txRunner,
+) *CompleteDeliveryUseCase {
+	return &CompleteDeliveryUseCase{
+		courierRepository:  courierRepository,
+		deliveryRepository: deliveryRepository,
+		txRunner:           txRunner,
+	}
 }
 
+// Complete завершает доставку и возвращает на линию занятого ею курьера.
+// Деактивированный или удаленный за время доставки курьер остается как есть.
 func (u *CompleteDeliveryUseCase) Complete(ctx context.Context, OrderID string) error {
-	courierID, err := u.courierRepository.GetCourierIDByOrderID(ctx, OrderID)
-	if err != nil {
-		if errors.Is(err, courierrepo.ErrOrderNotFound) {
-			return ErrOrderNotFound
+	return u.txRunner.Run(ctx, func(txCtx context.Context) error {
+		courierID, err := u.deliveryRepository.CompleteDelivery(txCtx, OrderID)
+		if err != nil {
+			if errors.Is(err, deliveryrepo.ErrOrderIDNotFound) {
+				return ErrOrderNotFound
+			}
+			return err
 		}
-		return fmt.Errorf("database error: %w", err)
-	}
-
-	err = u.courierRepository.UpdateCourier(ctx, model.Courier{
-		ID: courierID, Status: model.CourierStatusAvailable,
+		return u.courierRepository.ReleaseCourier(txCtx, courierID)
 	})
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
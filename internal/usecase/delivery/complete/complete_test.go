@@ -1 +1,68 @@
 package complete_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	deliveryrepo "courier-service/internal/repository/delivery"
+	"courier-service/internal/usecase/delivery/complete"
+)
+
+func TestCompleteDelivery(t *testing.T) {
+	const orderID = "550e8400-e29b-41d4-a716-446655440000"
+
+	tests := []struct {
+		name    string
+		prepare func(courierRepository *MockcourierRepository, deliveryRepository *MockdeliveryRepository)
+		wantErr error
+	}{
+		{
+			name: "success: courier released",
+			prepare: func(courierRepository *MockcourierRepository, deliveryRepository *MockdeliveryRepository) {
+				deliveryRepository.EXPECT().CompleteDelivery(gomock.Any(), orderID).Return(int64(1), nil)
+				courierRepository.EXPECT().ReleaseCourier(gomock.Any(), int64(1)).Return(nil)
+			},
+		},
+		{
+			name: "error: order not found",
+			prepare: func(courierRepository *MockcourierRepository, deliveryRepository *MockdeliveryRepository) {
+				deliveryRepository.EXPECT().CompleteDelivery(gomock.Any(), orderID).Return(int64(0), deliveryrepo.ErrOrderIDNotFound)
+			},
+			wantErr: complete.ErrOrderNotFound,
+		},
+		{
+			name: "error: release failed",
+			prepare: func(courierRepository *MockcourierRepository, deliveryRepository *MockdeliveryRepository) {
+				deliveryRepository.EXPECT().CompleteDelivery(gomock.Any(), orderID).Return(int64(1), nil)
+				courierRepository.EXPECT().ReleaseCourier(gomock.Any(), int64(1)).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			courierRepository := NewMockcourierRepository(ctrl)
+			deliveryRepository := NewMockdeliveryRepository(ctrl)
+			txRunner := NewMocktxRunner(ctrl)
+			txRunner.EXPECT().
+				Run(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+			tc.prepare(courierRepository, deliveryRepository)
+
+			uc := complete.NewCompleteDeliveryUseCase(courierRepository, deliveryRepository, txRunner)
+			err := uc.Complete(context.Background(), orderID)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
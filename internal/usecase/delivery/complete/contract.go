@@ -1,18 +1,18 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
 package complete
 
 import (
 	"context"
-
-	"courier-service/internal/model"
 )
 
 type courierRepository interface {
-	GetCourierById(ctx context.Context, id int64) (model.Courier, error)
-	GetAllCouriers(ctx context.Context) ([]model.Courier, error)
-	CreateCourier(ctx context.Context, courier model.Courier) (int64, error)
-	UpdateCourier(ctx context.Context, courier model.Courier) error
-	FindAvailableCourier(ctx context.Context) (model.Courier, error)
-	ExistsCourierByPhone(ctx context.Context, phone string) (bool, error)
-	FreeCouriersWithInterval(ctx context.Context) error
-	GetCourierIDByOrderID(ctx context.Context, orderID string) (int64, error)
+	ReleaseCourier(ctx context.Context, id int64) error
+}
+
+type deliveryRepository interface {
+	CompleteDelivery(ctx context.Context, orderID string) (int64, error)
+}
+
+type txRunner interface {
+	Run(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package complete_test is a generated GoMock package.
+package complete_test
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcourierRepository is a mock of courierRepository interface.
+type MockcourierRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcourierRepositoryMockRecorder
+}
+
+// MockcourierRepositoryMockRecorder is the mock recorder for MockcourierRepository.
+type MockcourierRepositoryMockRecorder struct {
+	mock *MockcourierRepository
+}
+
+// NewMockcourierRepository creates a new mock instance.
+func NewMockcourierRepository(ctrl *gomock.Controller) *MockcourierRepository {
+	mock := &MockcourierRepository{ctrl: ctrl}
+	mock.recorder = &MockcourierRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcourierRepository) EXPECT() *MockcourierRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ReleaseCourier mocks base method.
+func (m *MockcourierRepository) ReleaseCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseCourier indicates an expected call of ReleaseCourier.
+func (mr *MockcourierRepositoryMockRecorder) ReleaseCourier(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseCourier", reflect.TypeOf((*MockcourierRepository)(nil).ReleaseCourier), ctx, id)
+}
+
+// MockdeliveryRepository is a mock of deliveryRepository interface.
+type MockdeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeliveryRepositoryMockRecorder
+}
+
+// MockdeliveryRepositoryMockRecorder is the mock recorder for MockdeliveryRepository.
+type MockdeliveryRepositoryMockRecorder struct {
+	mock *MockdeliveryRepository
+}
+
+// NewMockdeliveryRepository creates a new mock instance.
+func NewMockdeliveryRepository(ctrl *gomock.Controller) *MockdeliveryRepository {
+	mock := &MockdeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockdeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeliveryRepository) EXPECT() *MockdeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CompleteDelivery mocks base method.
+func (m *MockdeliveryRepository) CompleteDelivery(ctx context.Context, orderID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDelivery", ctx, orderID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteDelivery indicates an expected call of CompleteDelivery.
+func (mr *MockdeliveryRepositoryMockRecorder) CompleteDelivery(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDelivery", reflect.TypeOf((*MockdeliveryRepository)(nil).CompleteDelivery), ctx, orderID)
+}
+
+// MocktxRunner is a mock of txRunner interface.
+type MocktxRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MocktxRunnerMockRecorder
+}
+
+// MocktxRunnerMockRecorder is the mock recorder for MocktxRunner.
+type MocktxRunnerMockRecorder struct {
+	mock *MocktxRunner
+}
+
+// NewMocktxRunner creates a new mock instance.
+func NewMocktxRunner(ctrl *gomock.Controller) *MocktxRunner {
+	mock := &MocktxRunner{ctrl: ctrl}
+	mock.recorder = &MocktxRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktxRunner) EXPECT() *MocktxRunnerMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MocktxRunner) Run(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MocktxRunnerMockRecorder) Run(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MocktxRunner)(nil).Run), ctx, fn)
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -source ${GOFILE} -package ${GOPACKAGE}_test -destination mocks_test.go
+package get
+
+import (
+	"context"
+
+	"courier-service/internal/model"
+)
+
+type deliveryRepository interface {
+	CouriersDelivery(ctx context.Context, orderID string) (model.Delivery, error)
+}
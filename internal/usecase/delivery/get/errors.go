@@ -0,0 +1,8 @@
+package get
+
+import "errors"
+
+var (
+	ErrNoOrderID       = errors.New("order id is required")
+	ErrOrderIDNotFound = errors.New("order id not found")
+)
@@ -0,0 +1,33 @@
+package get
+
+import (
+	"context"
+	"errors"
+
+	"courier-service/internal/model"
+	deliveryRepo "courier-service/internal/repository/delivery"
+)
+
+type GetDeliveryUseCase struct {
+	deliveryRepository deliveryRepository
+}
+
+func NewGetDeliveryUseCase(deliveryRepository deliveryRepository) *GetDeliveryUseCase {
+	return &GetDeliveryUseCase{deliveryRepository: deliveryRepository}
+}
+
+func (u *GetDeliveryUseCase) Get(ctx context.Context, OrderID string) (model.Delivery, error) {
+	if OrderID == "" {
+		return model.Delivery{}, ErrNoOrderID
+	}
+
+	delivery, err := u.deliveryRepository.CouriersDelivery(ctx, OrderID)
+	if err != nil {
+		if errors.Is(err, deliveryRepo.ErrOrderIDNotFound) {
+			return model.Delivery{}, ErrOrderIDNotFound
+		}
+		return model.Delivery{}, err
+	}
+
+	return delivery, nil
+}
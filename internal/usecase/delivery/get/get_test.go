@@ -0,0 +1,101 @@
+package get_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"courier-service/internal/model"
+	deliverystorage "courier-service/internal/repository/delivery"
+	"courier-service/internal/usecase/delivery/get"
+)
+
+func TestGetDelivery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		orderID      string
+		prepare      func(deliveryRepository *MockdeliveryRepository)
+		expectations func(t *testing.T, delivery model.Delivery, err error)
+	}{
+		{
+			name:    "success: delivery with order snapshot",
+			orderID: "550e8400-e29b-41d4-a716-446655440001",
+			prepare: func(deliveryRepository *MockdeliveryRepository) {
+				deliveryRepository.EXPECT().
+					CouriersDelivery(gomock.Any(), "550e8400-e29b-41d4-a716-446655440001").
+					Return(model.Delivery{
+						ID:        1,
+						CourierID: 2,
+						OrderID:   "550e8400-e29b-41d4-a716-446655440001",
+						Order: &model.Order{
+							ID:         "550e8400-e29b-41d4-a716-446655440001",
+							TotalPrice: 1500,
+						},
+					}, nil)
+			},
+			expectations: func(t *testing.T, delivery model.Delivery, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(2), delivery.CourierID)
+				if assert.NotNil(t, delivery.Order) {
+					assert.Equal(t, int64(1500), delivery.Order.TotalPrice)
+				}
+			},
+		},
+		{
+			name:    "error: no order ID",
+			orderID: "",
+			expectations: func(t *testing.T, delivery model.Delivery, err error) {
+				assert.Equal(t, get.ErrNoOrderID, err)
+			},
+		},
+		{
+			name:    "error: delivery not found",
+			orderID: "550e8400-e29b-41d4-a716-446655440002",
+			prepare: func(deliveryRepository *MockdeliveryRepository) {
+				deliveryRepository.EXPECT().
+					CouriersDelivery(gomock.Any(), "550e8400-e29b-41d4-a716-446655440002").
+					Return(model.Delivery{}, deliverystorage.ErrOrderIDNotFound)
+			},
+			expectations: func(t *testing.T, delivery model.Delivery, err error) {
+				assert.Equal(t, get.ErrOrderIDNotFound, err)
+				assert.Equal(t, model.Delivery{}, delivery)
+			},
+		},
+		{
+			name:    "error: database error",
+			orderID: "550e8400-e29b-41d4-a716-446655440003",
+			prepare: func(deliveryRepository *MockdeliveryRepository) {
+				deliveryRepository.EXPECT().
+					CouriersDelivery(gomock.Any(), gomock.Any()).
+					Return(model.Delivery{}, errors.New("connection refused"))
+			},
+			expectations: func(t *testing.T, delivery model.Delivery, err error) {
+				assert.EqualError(t, err, "connection refused")
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDeliveryRepo := NewMockdeliveryRepository(ctrl)
+			if tc.prepare != nil {
+				tc.prepare(mockDeliveryRepo)
+			}
+
+			uc := get.NewGetDeliveryUseCase(mockDeliveryRepo)
+			delivery, err := uc.Get(context.Background(), tc.orderID)
+			tc.expectations(t, delivery, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+
+// Package get_test is a generated GoMock package.
+package get_test
+
+import (
+	context "context"
+	model "courier-service/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockdeliveryRepository is a mock of deliveryRepository interface.
+type MockdeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeliveryRepositoryMockRecorder
+}
+
+// MockdeliveryRepositoryMockRecorder is the mock recorder for MockdeliveryRepository.
+type MockdeliveryRepositoryMockRecorder struct {
+	mock *MockdeliveryRepository
+}
+
+// NewMockdeliveryRepository creates a new mock instance.
+func NewMockdeliveryRepository(ctrl *gomock.Controller) *MockdeliveryRepository {
+	mock := &MockdeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockdeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeliveryRepository) EXPECT() *MockdeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CouriersDelivery mocks base method.
+func (m *MockdeliveryRepository) CouriersDelivery(ctx context.Context, orderID string) (model.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CouriersDelivery", ctx, orderID)
+	ret0, _ := ret[0].(model.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CouriersDelivery indicates an expected call of CouriersDelivery.
+func (mr *MockdeliveryRepositoryMockRecorder) CouriersDelivery(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CouriersDelivery", reflect.TypeOf((*MockdeliveryRepository)(nil).CouriersDelivery), ctx, orderID)
+}
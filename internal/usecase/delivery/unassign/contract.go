@@ -5,11 +5,12 @@ import (
 	"context"
 
 	"courier-service/internal/model"
+	"courier-service/internal/usecase/audit"
 )
 
 type courierRepository interface {
-	GetCourierById(ctx context.Context, id int64) (model.Courier, error)
-	UpdateCourier(ctx context.Context, courier model.Courier) error
+	GetCourierForUpdate(ctx context.Context, id int64) (model.Courier, error)
+	ReleaseCourier(ctx context.Context, id int64) error
 }
 
 type deliveryRepository interface {
@@ -21,3 +22,7 @@ type deliveryRepository interface {
 type txRunner interface {
 	Run(ctx context.Context, fn func(ctx context.Context) error) error
 }
+
+type auditRecorder interface {
+	Record(ctx context.Context, action model.AuditAction, entity model.AuditEntity, entityID string, before, after audit.Snapshot) error
+}
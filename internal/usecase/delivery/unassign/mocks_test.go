@@ -7,6 +7,7 @@ package unassign_test
 import (
 	context "context"
 	model "courier-service/internal/model"
+	audit "courier-service/internal/usecase/audit"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,33 +36,33 @@ func (m *MockcourierRepository) EXPECT() *MockcourierRepositoryMockRecorder {
 	return m.recorder
 }
 
-// GetCourierById mocks base method.
-func (m *MockcourierRepository) GetCourierById(ctx context.Context, id int64) (model.Courier, error) {
+// GetCourierForUpdate mocks base method.
+func (m *MockcourierRepository) GetCourierForUpdate(ctx context.Context, id int64) (model.Courier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCourierById", ctx, id)
+	ret := m.ctrl.Call(m, "GetCourierForUpdate", ctx, id)
 	ret0, _ := ret[0].(model.Courier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCourierById indicates an expected call of GetCourierById.
-func (mr *MockcourierRepositoryMockRecorder) GetCourierById(ctx, id interface{}) *gomock.Call {
+// GetCourierForUpdate indicates an expected call of GetCourierForUpdate.
+func (mr *MockcourierRepositoryMockRecorder) GetCourierForUpdate(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierById", reflect.TypeOf((*MockcourierRepository)(nil).GetCourierById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierForUpdate", reflect.TypeOf((*MockcourierRepository)(nil).GetCourierForUpdate), ctx, id)
 }
 
-// UpdateCourier mocks base method.
-func (m *MockcourierRepository) UpdateCourier(ctx context.Context, courier model.Courier) error {
+// ReleaseCourier mocks base method.
+func (m *MockcourierRepository) ReleaseCourier(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateCourier", ctx, courier)
+	ret := m.ctrl.Call(m, "ReleaseCourier", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateCourier indicates an expected call of UpdateCourier.
-func (mr *MockcourierRepositoryMockRecorder) UpdateCourier(ctx, courier interface{}) *gomock.Call {
+// ReleaseCourier indicates an expected call of ReleaseCourier.
+func (mr *MockcourierRepositoryMockRecorder) ReleaseCourier(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourier", reflect.TypeOf((*MockcourierRepository)(nil).UpdateCourier), ctx, courier)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseCourier", reflect.TypeOf((*MockcourierRepository)(nil).ReleaseCourier), ctx, id)
 }
 
 // MockdeliveryRepository is a mock of deliveryRepository interface.
@@ -167,3 +168,40 @@ func (mr *MocktxRunnerMockRecorder) Run(ctx, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MocktxRunner)(nil).Run), ctx, fn)
 }
+
+// MockauditRecorder is a mock of auditRecorder interface.
+type MockauditRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockauditRecorderMockRecorder
+}
+
+// MockauditRecorderMockRecorder is the mock recorder for MockauditRecorder.
+type MockauditRecorderMockRecorder struct {
+	mock *MockauditRecorder
+}
+
+// NewMockauditRecorder creates a new mock instance.
+func NewMockauditRecorder(ctrl *gomock.Controller) *MockauditRecorder {
+	mock := &MockauditRecorder{ctrl: ctrl}
+	mock.recorder = &MockauditRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauditRecorder) EXPECT() *MockauditRecorderMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockauditRecorder) Record(ctx context.Context, action model.AuditAction, entity model.AuditEntity, entityID string, before, after audit.Snapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, action, entity, entityID, before, after)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockauditRecorderMockRecorder) Record(ctx, action, entity, entityID, before, after interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockauditRecorder)(nil).Record), ctx, action, entity, entityID, before, after)
+}
@@ -6,23 +6,27 @@ import (
 
 	"courier-service/internal/model"
 	deliveryRepo "courier-service/internal/repository/delivery"
+	"courier-service/internal/usecase/audit"
 )
 
 type UnassignDelieveryUseCase struct {
 	courierRepository  courierRepository
 	deliveryRepository deliveryRepository
 	txRunner           txRunner
+	audit              auditRecorder
 }
 
 func NewUnassignDelieveryUseCase(
 	courierRepository courierRepository,
 	deliveryRepository deliveryRepository,
 	txRunner txRunner,
+	audit auditRecorder,
 ) *UnassignDelieveryUseCase {
 	return &UnassignDelieveryUseCase{
 		courierRepository:  courierRepository,
 		deliveryRepository: deliveryRepository,
 		txRunner:           txRunner,
+		audit:              audit,
 	}
 }
 
@@ -48,19 +52,19 @@ func (u *UnassignDelieveryUseCase) Unassign(ctx context.Context, OrderID string)
 			return err
 		}
 
-		courier, err := u.courierRepository.GetCourierById(txCtx, couriersDelivery.CourierID)
+		// курьер мог быть удален или деактивирован после освобождения по
+		// дедлайну: на линию возвращается только занятый доставкой курьер
+		courier, err := u.courierRepository.GetCourierForUpdate(txCtx, couriersDelivery.CourierID)
 		if err != nil {
 			return err
 		}
-
-		courier.ChangeStatus(model.CourierStatusAvailable)
-		if err := u.courierRepository.UpdateCourier(txCtx, courier); err != nil {
+		if err := u.courierRepository.ReleaseCourier(txCtx, courier.ID); err != nil {
 			return err
 		}
 
 		courierID = courier.ID
 
-		return nil
+		return u.audit.Record(txCtx, model.AuditActionDeliveryUnassign, model.AuditEntityDelivery, OrderID, audit.DeliverySnapshot(couriersDelivery), nil)
 	})
 	if err != nil {
 		return 0, err
@@ -3,6 +3,7 @@ package unassign_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -10,6 +11,7 @@ import (
 	"courier-service/internal/model"
 	courierstorage "courier-service/internal/repository/courier"
 	deliverystorage "courier-service/internal/repository/delivery"
+	"courier-service/internal/usecase/audit"
 	"courier-service/internal/usecase/delivery/unassign"
 )
 
@@ -53,7 +55,7 @@ func TestUnassignDelivery(t *testing.T) {
 					Return(nil)
 
 				courierRepository.EXPECT().
-					GetCourierById(gomock.Any(), int64(1)).
+					GetCourierForUpdate(gomock.Any(), int64(1)).
 					Return(model.Courier{
 						ID:            1,
 						Name:          "John",
@@ -63,11 +65,8 @@ func TestUnassignDelivery(t *testing.T) {
 					}, nil)
 
 				courierRepository.EXPECT().
-					UpdateCourier(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, c model.Courier) error {
-						assert.Equal(t, model.CourierStatusAvailable, c.Status)
-						return nil
-					})
+					ReleaseCourier(gomock.Any(), int64(1)).
+					Return(nil)
 			},
 			expectations: func(t *testing.T, resp int64, err error) {
 				assert.NoError(t, err)
@@ -173,7 +172,7 @@ func TestUnassignDelivery(t *testing.T) {
 					Return(nil)
 
 				courierRepository.EXPECT().
-					GetCourierById(gomock.Any(), int64(999)).
+					GetCourierForUpdate(gomock.Any(), int64(999)).
 					Return(model.Courier{}, courierstorage.ErrCourierNotFound)
 			},
 			expectations: func(t *testing.T, resp int64, err error) {
@@ -200,7 +199,13 @@ func TestUnassignDelivery(t *testing.T) {
 				tc.prepare(mockCourierRepo, mockDeliveryRepo, mockTxRunner)
 			}
 
-			uc := unassign.NewUnassignDelieveryUseCase(mockCourierRepo, mockDeliveryRepo, mockTxRunner)
+			mockAudit := NewMockauditRecorder(ctrl)
+			mockAudit.EXPECT().
+				Record(gomock.Any(), model.AuditActionDeliveryUnassign, model.AuditEntityDelivery, tc.orderID, gomock.Any(), nil).
+				Return(nil).
+				AnyTimes()
+
+			uc := unassign.NewUnassignDelieveryUseCase(mockCourierRepo, mockDeliveryRepo, mockTxRunner, mockAudit)
 
 			ctx := context.Background()
 
@@ -212,3 +217,56 @@ func TestUnassignDelivery(t *testing.T) {
 		})
 	}
 }
+
+func TestUnassignDelivery_Audit(t *testing.T) {
+	orderID := "550e8400-e29b-41d4-a716-446655440005"
+	delivery := model.Delivery{
+		CourierID:  1,
+		OrderID:    orderID,
+		AssignedAt: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		Deadline:   time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		auditErr error
+	}{
+		{name: "success: unassign recorded"},
+		{name: "error: audit failure aborts unassign", auditErr: assert.AnError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			courierRepository := NewMockcourierRepository(ctrl)
+			deliveryRepository := NewMockdeliveryRepository(ctrl)
+			txRunner := NewMocktxRunner(ctrl)
+			auditRecorder := NewMockauditRecorder(ctrl)
+
+			txRunner.EXPECT().
+				Run(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+			deliveryRepository.EXPECT().CouriersDelivery(gomock.Any(), orderID).Return(delivery, nil)
+			deliveryRepository.EXPECT().DeleteDelivery(gomock.Any(), orderID).Return(nil)
+			courierRepository.EXPECT().GetCourierForUpdate(gomock.Any(), int64(1)).
+				Return(model.Courier{ID: 1, Status: model.CourierStatusBusy}, nil)
+			courierRepository.EXPECT().ReleaseCourier(gomock.Any(), int64(1)).Return(nil)
+			auditRecorder.EXPECT().
+				Record(gomock.Any(), model.AuditActionDeliveryUnassign, model.AuditEntityDelivery, orderID,
+					audit.DeliverySnapshot(delivery), nil).
+				Return(tc.auditErr)
+
+			uc := unassign.NewUnassignDelieveryUseCase(courierRepository, deliveryRepository, txRunner, auditRecorder)
+			courierID, err := uc.Unassign(context.Background(), orderID)
+			if tc.auditErr != nil {
+				assert.ErrorIs(t, err, tc.auditErr)
+				assert.Zero(t, courierID)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), courierID)
+		})
+	}
+}
@@ -2,7 +2,9 @@ package changed
 
 import (
 	"context"
+	"errors"
 
+	ordergw "courier-service/internal/gateway/order"
 	"courier-service/internal/model"
 )
 
@@ -21,14 +23,18 @@ func NewOrderChangedUseCase(factory orderChangedFactory, gateway orderGateway, l
 }
 
 func (uc *OrderChangedUseCase) HandleOrderStatusChanged(ctx context.Context, status model.OrderStatus, orderID string) error {
+	// событие о смене статуса делает закэшированный заказ устаревшим
+	uc.orderGateway.Invalidate(orderID)
 	if status != model.OrderStatusCompleted {
 		uc.logger.Debugf("sending grpc request for checking status for order %s", orderID)
 		order, err := uc.orderGateway.GetOrderById(ctx, orderID)
-		if err != nil {
+		switch {
+		case errors.Is(err, ordergw.ErrServiceUnavailable):
+			// circuit breaker открыт: не блокируем обработку и доверяем статусу из события
+			uc.logger.Warnf("order service unavailable, trusting event status %s for order %s", status, orderID)
+		case err != nil:
 			return err
-		}
-
-		if order.Status != status {
+		case order.Status != status:
 			uc.logger.Warnf("order status mismatch: expected %s, got %s for order %s", status, order.Status, orderID)
 			return ErrOrderStatusMismatch
 		}
@@ -3,11 +3,13 @@ package changed_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	ordergw "courier-service/internal/gateway/order"
 	"courier-service/internal/model"
 	"courier-service/internal/usecase/order/changed"
 )
@@ -133,6 +135,33 @@ func TestOrderChangedUseCase_HandleOrderStatusChanged(t *testing.T) {
 				assert.EqualError(t, err, "gateway error")
 			},
 		},
+		{
+			name:    "success: order service unavailable, event status trusted",
+			status:  model.OrderStatusCancelled,
+			orderID: "550e8400-e29b-41d4-a716-446655440008",
+			prepare: func(factory *MockorderChangedFactory, gateway *MockorderGateway, logger *Mocklogger, processor *MockProcessor) {
+				logger.EXPECT().
+					Debugf("sending grpc request for checking status for order %s", "550e8400-e29b-41d4-a716-446655440008")
+
+				gateway.EXPECT().
+					GetOrderById(gomock.Any(), "550e8400-e29b-41d4-a716-446655440008").
+					Return(model.Order{}, fmt.Errorf("%w: circuit breaker is open", ordergw.ErrServiceUnavailable))
+
+				logger.EXPECT().
+					Warnf(gomock.Any(), model.OrderStatusCancelled, "550e8400-e29b-41d4-a716-446655440008")
+
+				factory.EXPECT().
+					Get(model.OrderStatusCancelled).
+					Return(processor, true)
+
+				processor.EXPECT().
+					HandleOrderStatusChanged(gomock.Any(), model.OrderStatusCancelled, "550e8400-e29b-41d4-a716-446655440008").
+					Return(nil)
+			},
+			expectations: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
 		{
 			name:    "error: status mismatch",
 			status:  model.OrderStatusCreated,
@@ -201,6 +230,8 @@ func TestOrderChangedUseCase_HandleOrderStatusChanged(t *testing.T) {
 			mockGateway := NewMockorderGateway(ctrl)
 			mockLogger := NewMocklogger(ctrl)
 			mockProcessor := NewMockProcessor(ctrl)
+			// каждое событие сбрасывает закэшированный заказ
+			mockGateway.EXPECT().Invalidate(tc.orderID)
 
 			uc := changed.NewOrderChangedUseCase(mockFactory, mockGateway, mockLogger)
 
@@ -218,3 +249,26 @@ func TestOrderChangedUseCase_HandleOrderStatusChanged(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderChangedUseCase_InvalidatesBeforeLookup(t *testing.T) {
+	const orderID = "550e8400-e29b-41d4-a716-446655440009"
+
+	ctrl := gomock.NewController(t)
+	factory := NewMockorderChangedFactory(ctrl)
+	gateway := NewMockorderGateway(ctrl)
+	logger := NewMocklogger(ctrl)
+	processor := NewMockProcessor(ctrl)
+
+	logger.EXPECT().Debugf(gomock.Any(), gomock.Any()).AnyTimes()
+	gomock.InOrder(
+		gateway.EXPECT().Invalidate(orderID),
+		gateway.EXPECT().
+			GetOrderById(gomock.Any(), orderID).
+			Return(model.Order{ID: orderID, Status: model.OrderStatusCancelled}, nil),
+	)
+	factory.EXPECT().Get(model.OrderStatusCancelled).Return(processor, true)
+	processor.EXPECT().HandleOrderStatusChanged(gomock.Any(), model.OrderStatusCancelled, orderID).Return(nil)
+
+	uc := changed.NewOrderChangedUseCase(factory, gateway, logger)
+	assert.NoError(t, uc.HandleOrderStatusChanged(context.Background(), model.OrderStatusCancelled, orderID))
+}
@@ -17,6 +17,7 @@ type orderChangedFactory interface {
 
 type orderGateway interface {
 	GetOrderById(ctx context.Context, orderID string) (model.Order, error)
+	Invalidate(orderID string)
 }
 
 type logger interface {
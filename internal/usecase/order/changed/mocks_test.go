@@ -126,6 +126,18 @@ func (mr *MockorderGatewayMockRecorder) GetOrderById(ctx, orderID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderById", reflect.TypeOf((*MockorderGateway)(nil).GetOrderById), ctx, orderID)
 }
 
+// Invalidate mocks base method.
+func (m *MockorderGateway) Invalidate(orderID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Invalidate", orderID)
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockorderGatewayMockRecorder) Invalidate(orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockorderGateway)(nil).Invalidate), orderID)
+}
+
 // Mocklogger is a mock of logger interface.
 type Mocklogger struct {
 	ctrl     *gomock.Controller
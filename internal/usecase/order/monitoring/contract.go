@@ -8,6 +8,7 @@ import (
 	"courier-service/internal/model"
 	assign "courier-service/internal/usecase/delivery/assign"
 	utils "courier-service/internal/usecase/utils"
+	l "courier-service/pkg/logger/zap"
 )
 
 type DeliveryCalculator = utils.DeliveryCalculator
@@ -17,7 +18,7 @@ type deliveryCalculatorFactory interface {
 }
 
 type orderGateway interface {
-	GetOrders(ctx context.Context, from time.Time) ([]model.Order, error)
+	StreamOrders(ctx context.Context, from time.Time, handle func(ctx context.Context, orders []model.Order) error) error
 }
 
 type courierRepository interface {
@@ -27,7 +28,7 @@ type courierRepository interface {
 	UpdateCourier(ctx context.Context, courier model.Courier) error
 	FindAvailableCourier(ctx context.Context) (model.Courier, error)
 	ExistsCourierByPhone(ctx context.Context, phone string) (bool, error)
-	FreeCouriersWithInterval(ctx context.Context) error
+	FreeCouriersWithInterval(ctx context.Context) (int64, error)
 }
 
 type deliveryRepository interface {
@@ -43,3 +44,11 @@ type txRunner interface {
 type assignUseCase interface {
 	Assign(ctx context.Context, orderID string) (assign.DeliveryAssignResponse, error)
 }
+
+type metricsWriter interface {
+	SetPendingOrders(count int)
+}
+
+type logger interface {
+	With(ctx context.Context) *l.Logger
+}
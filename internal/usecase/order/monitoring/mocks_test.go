@@ -9,6 +9,7 @@ import (
 	model "courier-service/internal/model"
 	assign "courier-service/internal/usecase/delivery/assign"
 	monitoring "courier-service/internal/usecase/order/monitoring"
+	zap "courier-service/pkg/logger/zap"
 	reflect "reflect"
 	time "time"
 
@@ -75,19 +76,18 @@ func (m *MockorderGateway) EXPECT() *MockorderGatewayMockRecorder {
 	return m.recorder
 }
 
-// GetOrders mocks base method.
-func (m *MockorderGateway) GetOrders(ctx context.Context, from time.Time) ([]model.Order, error) {
+// StreamOrders mocks base method.
+func (m *MockorderGateway) StreamOrders(ctx context.Context, from time.Time, handle func(context.Context, []model.Order) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrders", ctx, from)
-	ret0, _ := ret[0].([]model.Order)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "StreamOrders", ctx, from, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetOrders indicates an expected call of GetOrders.
-func (mr *MockorderGatewayMockRecorder) GetOrders(ctx, from interface{}) *gomock.Call {
+// StreamOrders indicates an expected call of StreamOrders.
+func (mr *MockorderGatewayMockRecorder) StreamOrders(ctx, from, handle interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockorderGateway)(nil).GetOrders), ctx, from)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamOrders", reflect.TypeOf((*MockorderGateway)(nil).StreamOrders), ctx, from, handle)
 }
 
 // MockcourierRepository is a mock of courierRepository interface.
@@ -159,11 +159,12 @@ func (mr *MockcourierRepositoryMockRecorder) FindAvailableCourier(ctx interface{
 }
 
 // FreeCouriersWithInterval mocks base method.
-func (m *MockcourierRepository) FreeCouriersWithInterval(ctx context.Context) error {
+func (m *MockcourierRepository) FreeCouriersWithInterval(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FreeCouriersWithInterval", ctx)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // FreeCouriersWithInterval indicates an expected call of FreeCouriersWithInterval.
@@ -357,3 +358,75 @@ func (mr *MockassignUseCaseMockRecorder) Assign(ctx, orderID interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockassignUseCase)(nil).Assign), ctx, orderID)
 }
+
+// MockmetricsWriter is a mock of metricsWriter interface.
+type MockmetricsWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockmetricsWriterMockRecorder
+}
+
+// MockmetricsWriterMockRecorder is the mock recorder for MockmetricsWriter.
+type MockmetricsWriterMockRecorder struct {
+	mock *MockmetricsWriter
+}
+
+// NewMockmetricsWriter creates a new mock instance.
+func NewMockmetricsWriter(ctrl *gomock.Controller) *MockmetricsWriter {
+	mock := &MockmetricsWriter{ctrl: ctrl}
+	mock.recorder = &MockmetricsWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmetricsWriter) EXPECT() *MockmetricsWriterMockRecorder {
+	return m.recorder
+}
+
+// SetPendingOrders mocks base method.
+func (m *MockmetricsWriter) SetPendingOrders(count int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPendingOrders", count)
+}
+
+// SetPendingOrders indicates an expected call of SetPendingOrders.
+func (mr *MockmetricsWriterMockRecorder) SetPendingOrders(count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingOrders", reflect.TypeOf((*MockmetricsWriter)(nil).SetPendingOrders), count)
+}
+
+// Mocklogger is a mock of logger interface.
+type Mocklogger struct {
+	ctrl     *gomock.Controller
+	recorder *MockloggerMockRecorder
+}
+
+// MockloggerMockRecorder is the mock recorder for Mocklogger.
+type MockloggerMockRecorder struct {
+	mock *Mocklogger
+}
+
+// NewMocklogger creates a new mock instance.
+func NewMocklogger(ctrl *gomock.Controller) *Mocklogger {
+	mock := &Mocklogger{ctrl: ctrl}
+	mock.recorder = &MockloggerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocklogger) EXPECT() *MockloggerMockRecorder {
+	return m.recorder
+}
+
+// With mocks base method.
+func (m *Mocklogger) With(ctx context.Context) *zap.Logger {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "With", ctx)
+	ret0, _ := ret[0].(*zap.Logger)
+	return ret0
+}
+
+// With indicates an expected call of With.
+func (mr *MockloggerMockRecorder) With(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "With", reflect.TypeOf((*Mocklogger)(nil).With), ctx)
+}
@@ -2,8 +2,12 @@ package ordermonitoring
 
 import (
 	"context"
-	"log"
+	"errors"
 	"time"
+
+	"courier-service/internal/model"
+	"courier-service/internal/usecase/delivery/assign"
+	l "courier-service/pkg/logger/zap"
 )
 
 type OrderMonitoringUseCase struct {
@@ -13,6 +17,8 @@ type OrderMonitoringUseCase struct {
 	deliveryRepository        deliveryRepository
 	deliveryCalculatorFactory deliveryCalculatorFactory
 	assignUseCase             assignUseCase
+	metrics                   metricsWriter
+	logger                    logger
 }
 
 func NewOrderMonitoringUseCase(
@@ -22,6 +28,8 @@ func NewOrderMonitoringUseCase(
 	txRunner txRunner,
 	deliveryCalculatorFactory deliveryCalculatorFactory,
 	assignUseCase assignUseCase,
+	metrics metricsWriter,
+	logger logger,
 ) *OrderMonitoringUseCase {
 	return &OrderMonitoringUseCase{
 		orderGateway:              orderGateway,
@@ -30,6 +38,8 @@ func NewOrderMonitoringUseCase(
 		txRunner:                  txRunner,
 		deliveryCalculatorFactory: deliveryCalculatorFactory,
 		assignUseCase:             assignUseCase,
+		metrics:                   metrics,
+		logger:                    logger,
 	}
 }
 
@@ -43,20 +53,41 @@ func (u *OrderMonitoringUseCase) MonitorOrders(ctx context.Context, interval tim
 			return
 		case <-ticker.C:
 			from := time.Now().Add(-interval)
-			log.Printf("getting orders from gateway, cursor: %s\n", from.Format(time.RFC3339))
-			orders, err := u.orderGateway.GetOrders(ctx, from)
+			u.logger.With(ctx).Debugw("getting orders from gateway", "cursor", from.Format(time.RFC3339))
+			pending := 0
+			err := u.orderGateway.StreamOrders(ctx, from, func(ctx context.Context, orders []model.Order) error {
+				n, err := u.assignOrders(ctx, orders)
+				pending += n
+				return err
+			})
 			if err != nil {
-				log.Printf("failed to get orders from gateway: %v\n", err)
+				u.logger.With(ctx).Errorw("failed to get orders from gateway", "error", err)
 				continue
 			}
-			for _, order := range orders {
-				assignment, err := u.assignUseCase.Assign(ctx, order.ID)
-				if err != nil {
-					log.Printf("failed to create assignment for order %s: %v\n", order.ID, err)
-					continue
-				}
-				log.Printf("applied courier %d to order %s", assignment.CourierID, order.ID)
+			u.metrics.SetPendingOrders(pending)
+		}
+	}
+}
+
+// assignOrders обрабатывает одну страницу заказов; следующая страница
+// запрашивается только после ее возврата. Возвращает число заказов,
+// оставшихся без курьера из-за отсутствия свободных.
+func (u *OrderMonitoringUseCase) assignOrders(ctx context.Context, orders []model.Order) (int, error) {
+	pending := 0
+	for _, order := range orders {
+		if err := ctx.Err(); err != nil {
+			return pending, err
+		}
+		orderCtx := l.WithOrderID(ctx, order.ID)
+		assignment, err := u.assignUseCase.Assign(orderCtx, order.ID)
+		if err != nil {
+			if errors.Is(err, assign.ErrCouriersBusy) {
+				pending++
 			}
+			u.logger.With(orderCtx).Warnw("failed to create assignment", "error", err)
+			continue
 		}
+		u.logger.With(l.WithCourierID(orderCtx, assignment.CourierID)).Infow("courier assigned")
 	}
+	return pending, nil
 }
@@ -14,6 +14,7 @@ import (
 	"courier-service/internal/model"
 	"courier-service/internal/usecase/delivery/assign"
 	ordermonitoring "courier-service/internal/usecase/order/monitoring"
+	l "courier-service/pkg/logger/zap"
 )
 
 func TestOrderMonitoringUseCase_MonitorOrders(t *testing.T) {
@@ -32,19 +33,17 @@ func TestOrderMonitoringUseCase_MonitorOrders(t *testing.T) {
 			cancelImmediately: false,
 			prepare: func(gateway *MockorderGateway, assignUC *MockassignUseCase) {
 				gateway.EXPECT().
-					GetOrders(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, from time.Time) ([]model.Order, error) {
-						return []model.Order{
-							{
-								ID:     "550e8400-e29b-41d4-a716-446655440001",
-								Status: model.OrderStatusCreated,
-							},
-							{
-								ID:     "550e8400-e29b-41d4-a716-446655440002",
-								Status: model.OrderStatusCreated,
-							},
-						}, nil
-					}).
+					StreamOrders(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(streamPages([]model.Order{
+						{
+							ID:     "550e8400-e29b-41d4-a716-446655440001",
+							Status: model.OrderStatusCreated,
+						},
+						{
+							ID:     "550e8400-e29b-41d4-a716-446655440002",
+							Status: model.OrderStatusCreated,
+						},
+					})).
 					MinTimes(2)
 
 				assignUC.EXPECT().
@@ -69,8 +68,8 @@ func TestOrderMonitoringUseCase_MonitorOrders(t *testing.T) {
 			cancelImmediately: false,
 			prepare: func(gateway *MockorderGateway, assignUC *MockassignUseCase) {
 				gateway.EXPECT().
-					GetOrders(gomock.Any(), gomock.Any()).
-					Return([]model.Order{}, nil).
+					StreamOrders(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(streamPages([]model.Order{})).
 					MinTimes(2)
 			},
 			expectations: func(t *testing.T) {
@@ -84,8 +83,8 @@ func TestOrderMonitoringUseCase_MonitorOrders(t *testing.T) {
 			cancelImmediately: false,
 			prepare: func(gateway *MockorderGateway, assignUC *MockassignUseCase) {
 				gateway.EXPECT().
-					GetOrders(gomock.Any(), gomock.Any()).
-					Return(nil, errors.New("gateway connection failed")).
+					StreamOrders(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("gateway connection failed")).
 					MinTimes(2)
 			},
 			expectations: func(t *testing.T) {
@@ -99,19 +98,17 @@ func TestOrderMonitoringUseCase_MonitorOrders(t *testing.T) {
 			cancelImmediately: false,
 			prepare: func(gateway *MockorderGateway, assignUC *MockassignUseCase) {
 				gateway.EXPECT().
-					GetOrders(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, from time.Time) ([]model.Order, error) {
-						return []model.Order{
-							{
-								ID:     "550e8400-e29b-41d4-a716-446655440001",
-								Status: model.OrderStatusCreated,
-							},
-							{
-								ID:     "550e8400-e29b-41d4-a716-446655440002",
-								Status: model.OrderStatusCreated,
-							},
-						}, nil
-					}).
+					StreamOrders(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(streamPages([]model.Order{
+						{
+							ID:     "550e8400-e29b-41d4-a716-446655440001",
+							Status: model.OrderStatusCreated,
+						},
+						{
+							ID:     "550e8400-e29b-41d4-a716-446655440002",
+							Status: model.OrderStatusCreated,
+						},
+					})).
 					MinTimes(2)
 
 				assignUC.EXPECT().
@@ -151,15 +148,13 @@ func TestOrderMonitoringUseCase_MonitorOrders(t *testing.T) {
 			cancelImmediately: false,
 			prepare: func(gateway *MockorderGateway, assignUC *MockassignUseCase) {
 				gateway.EXPECT().
-					GetOrders(gomock.Any(), gomock.Any()).
-					DoAndReturn(func(ctx context.Context, from time.Time) ([]model.Order, error) {
-						return []model.Order{
-							{
-								ID:     "550e8400-e29b-41d4-a716-446655440001",
-								Status: model.OrderStatusCreated,
-							},
-						}, nil
-					}).
+					StreamOrders(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(streamPages([]model.Order{
+						{
+							ID:     "550e8400-e29b-41d4-a716-446655440001",
+							Status: model.OrderStatusCreated,
+						},
+					})).
 					MinTimes(2)
 
 				assignUC.EXPECT().
@@ -191,6 +186,8 @@ func TestOrderMonitoringUseCase_MonitorOrders(t *testing.T) {
 			mockTxRunner := NewMocktxRunner(ctrl)
 			mockFactory := NewMockdeliveryCalculatorFactory(ctrl)
 			mockAssignUC := NewMockassignUseCase(ctrl)
+			mockMetrics := NewMockmetricsWriter(ctrl)
+			mockMetrics.EXPECT().SetPendingOrders(gomock.Any()).AnyTimes()
 
 			uc := ordermonitoring.NewOrderMonitoringUseCase(
 				mockGateway,
@@ -199,6 +196,8 @@ func TestOrderMonitoringUseCase_MonitorOrders(t *testing.T) {
 				mockTxRunner,
 				mockFactory,
 				mockAssignUC,
+				mockMetrics,
+				l.NewNop(),
 			)
 
 			ctx, cancel := context.WithCancel(context.Background())
@@ -237,6 +236,8 @@ func TestOrderMonitoringUseCase_MonitorOrders_TimeWindow(t *testing.T) {
 	mockTxRunner := NewMocktxRunner(ctrl)
 	mockFactory := NewMockdeliveryCalculatorFactory(ctrl)
 	mockAssignUC := NewMockassignUseCase(ctrl)
+	mockMetrics := NewMockmetricsWriter(ctrl)
+	mockMetrics.EXPECT().SetPendingOrders(0).AnyTimes()
 
 	uc := ordermonitoring.NewOrderMonitoringUseCase(
 		mockGateway,
@@ -245,6 +246,8 @@ func TestOrderMonitoringUseCase_MonitorOrders_TimeWindow(t *testing.T) {
 		mockTxRunner,
 		mockFactory,
 		mockAssignUC,
+		mockMetrics,
+		l.NewNop(),
 	)
 
 	interval := 100 * time.Millisecond
@@ -252,12 +255,12 @@ func TestOrderMonitoringUseCase_MonitorOrders_TimeWindow(t *testing.T) {
 	var capturedTime time.Time
 	var mu sync.Mutex
 	mockGateway.EXPECT().
-		GetOrders(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(ctx context.Context, from time.Time) ([]model.Order, error) {
+		StreamOrders(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, from time.Time, handle func(context.Context, []model.Order) error) error {
 			mu.Lock()
 			capturedTime = from
 			mu.Unlock()
-			return []model.Order{}, nil
+			return nil
 		}).
 		Times(1)
 
@@ -282,3 +285,123 @@ func TestOrderMonitoringUseCase_MonitorOrders_TimeWindow(t *testing.T) {
 	assert.True(t, timeDiff < 150*time.Millisecond && timeDiff > -150*time.Millisecond,
 		"Time window should be approximately %v ago, but was %v", interval, timeDiff)
 }
+
+func TestOrderMonitoringUseCase_MonitorOrders_Pages(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGateway := NewMockorderGateway(ctrl)
+	mockAssignUC := NewMockassignUseCase(ctrl)
+	mockMetrics := NewMockmetricsWriter(ctrl)
+	mockMetrics.EXPECT().SetPendingOrders(0).AnyTimes()
+
+	uc := ordermonitoring.NewOrderMonitoringUseCase(
+		mockGateway,
+		NewMockcourierRepository(ctrl),
+		NewMockdeliveryRepository(ctrl),
+		NewMocktxRunner(ctrl),
+		NewMockdeliveryCalculatorFactory(ctrl),
+		mockAssignUC,
+		mockMetrics,
+		l.NewNop(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		assigned []string
+	)
+	mockGateway.EXPECT().
+		StreamOrders(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, from time.Time, handle func(context.Context, []model.Order) error) error {
+			defer cancel()
+			return streamPages(
+				[]model.Order{{ID: "order-1"}, {ID: "order-2"}},
+				[]model.Order{{ID: "order-3"}},
+			)(ctx, from, handle)
+		}).
+		Times(1)
+	mockAssignUC.EXPECT().
+		Assign(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, orderID string) (assign.DeliveryAssignResponse, error) {
+			mu.Lock()
+			assigned = append(assigned, orderID)
+			mu.Unlock()
+			return assign.DeliveryAssignResponse{OrderID: orderID}, nil
+		}).
+		Times(3)
+
+	uc.MonitorOrders(ctx, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"order-1", "order-2", "order-3"}, assigned)
+}
+
+func TestOrderMonitoringUseCase_MonitorOrders_PendingOrders(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGateway := NewMockorderGateway(ctrl)
+	mockAssignUC := NewMockassignUseCase(ctrl)
+	mockMetrics := NewMockmetricsWriter(ctrl)
+
+	uc := ordermonitoring.NewOrderMonitoringUseCase(
+		mockGateway,
+		NewMockcourierRepository(ctrl),
+		NewMockdeliveryRepository(ctrl),
+		NewMocktxRunner(ctrl),
+		NewMockdeliveryCalculatorFactory(ctrl),
+		mockAssignUC,
+		mockMetrics,
+		l.NewNop(),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockGateway.EXPECT().
+		StreamOrders(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(streamPages(
+			[]model.Order{{ID: "order-1"}, {ID: "order-2"}},
+			[]model.Order{{ID: "order-3"}, {ID: "order-4"}},
+		)).
+		Times(1)
+	mockAssignUC.EXPECT().
+		Assign(gomock.Any(), "order-1").
+		Return(assign.DeliveryAssignResponse{OrderID: "order-1"}, nil)
+	mockAssignUC.EXPECT().
+		Assign(gomock.Any(), "order-2").
+		Return(assign.DeliveryAssignResponse{}, assign.ErrCouriersBusy)
+	mockAssignUC.EXPECT().
+		Assign(gomock.Any(), "order-3").
+		Return(assign.DeliveryAssignResponse{}, assign.ErrCouriersBusy)
+	// уже назначенный заказ не ждет курьера
+	mockAssignUC.EXPECT().
+		Assign(gomock.Any(), "order-4").
+		Return(assign.DeliveryAssignResponse{}, assign.ErrOrderIDExists)
+	mockMetrics.EXPECT().
+		SetPendingOrders(2).
+		Do(func(int) { cancel() })
+
+	uc.MonitorOrders(ctx, 10*time.Millisecond)
+}
+
+// streamPages имитирует потоковый gateway: страницы отдаются по одной,
+// пока обработчик не вернет ошибку.
+func streamPages(pages ...[]model.Order) func(context.Context, time.Time, func(context.Context, []model.Order) error) error {
+	return func(ctx context.Context, _ time.Time, handle func(context.Context, []model.Order) error) error {
+		for _, page := range pages {
+			if err := handle(ctx, page); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
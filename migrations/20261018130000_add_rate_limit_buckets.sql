@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Bucket state is ephemeral, so the table is unlogged to keep upserts cheap
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key         TEXT PRIMARY KEY,
+    tokens      DOUBLE PRECISION NOT NULL,
+    allowed     BOOLEAN NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp()
+);
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets (updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rate_limit_buckets;
+-- +goose StatementEnd
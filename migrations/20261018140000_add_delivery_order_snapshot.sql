@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Snapshot of the order taken at assignment time, NULL when the order service was unavailable
+ALTER TABLE delivery
+    ADD COLUMN IF NOT EXISTS order_snapshot JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE delivery DROP COLUMN IF EXISTS order_snapshot;
+-- +goose StatementEnd
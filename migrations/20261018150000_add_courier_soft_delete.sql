@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Soft deletion keeps the row so delivery history still references the courier;
+-- erased_at marks couriers whose name and phone were anonymized on request
+ALTER TABLE couriers
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS erased_at TIMESTAMPTZ;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE couriers
+    DROP COLUMN IF EXISTS erased_at,
+    DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- completed_at marks delivered orders; a delivery without it is still open and
+-- keeps its courier from being deleted or deactivated
+ALTER TABLE delivery ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ;
+
+-- only the latest delivery of a busy courier can still be in progress
+UPDATE delivery d
+SET completed_at = NOW()
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM couriers c
+    WHERE c.id = d.courier_id
+      AND c.status = 'busy'
+      AND d.assigned_at = (SELECT MAX(d2.assigned_at) FROM delivery d2 WHERE d2.courier_id = c.id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_open_courier_id ON delivery (courier_id) WHERE completed_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_delivery_open_courier_id;
+ALTER TABLE delivery DROP COLUMN IF EXISTS completed_at;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- locked_until is a short lease on an incomplete key: once it passes, the
+-- request that reserved the key is considered dead and a retry may take over
+ALTER TABLE idempotency_keys
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
+
+UPDATE idempotency_keys
+SET locked_until = created_at + INTERVAL '30 seconds'
+WHERE status_code = 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS locked_until;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+package actor
+
+import "context"
+
+const (
+	// Header — HTTP заголовок, в котором шлюз авторизации передает
+	// пользователя, выполняющего запрос.
+	Header = "X-Auth-User"
+	// Anonymous — актор HTTP запросов без пользователя.
+	Anonymous = "anonymous"
+	// System — актор действий вне HTTP запросов, например обработки событий
+	// заказов.
+	System = "system"
+
+	maxLength = 256
+)
+
+type ctxKey struct{}
+
+func NewContext(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, actor)
+}
+
+// FromContext возвращает актора из контекста или System, если его нет.
+func FromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(ctxKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return System
+}
+
+// Valid отсекает пустые, слишком длинные и непечатаемые значения, чтобы
+// они не попадали в журнал аудита как есть.
+func Valid(actor string) bool {
+	if actor == "" || len(actor) > maxLength {
+		return false
+	}
+	for i := 0; i < len(actor); i++ {
+		if actor[i] < 0x20 || actor[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
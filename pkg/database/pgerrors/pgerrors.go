@@ -0,0 +1,105 @@
+package pgerrors
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE классифицируемых ошибок.
+const (
+	CodeUniqueViolation      = "23505"
+	CodeForeignKeyViolation  = "23503"
+	CodeSerializationFailure = "40001"
+	CodeDeadlockDetected     = "40P01"
+)
+
+// Классы ошибок Postgres; проверяются через errors.Is.
+var (
+	ErrUniqueViolation      = errors.New("unique violation")
+	ErrForeignKeyViolation  = errors.New("foreign key violation")
+	ErrSerializationFailure = errors.New("serialization failure")
+	ErrDeadlock             = errors.New("deadlock detected")
+)
+
+// Constraints сопоставляет имя ограничения доменной ошибке репозитория.
+type Constraints map[string]error
+
+// Error — ошибка Postgres с известным SQLSTATE. errors.Is находит в ней
+// класс ошибки и доменную ошибку ограничения, errors.As — *pgconn.PgError.
+type Error struct {
+	// Kind — класс ошибки, например ErrUniqueViolation.
+	Kind error
+	// Domain — доменная ошибка по имени ограничения; nil, если
+	// ограничение не описано.
+	Domain     error
+	Constraint string
+	PgErr      *pgconn.PgError
+}
+
+func (e *Error) Error() string {
+	if e.Domain != nil {
+		return e.Domain.Error()
+	}
+	if e.Constraint != "" {
+		return fmt.Sprintf("%s on constraint %q: %s", e.Kind, e.Constraint, e.PgErr.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Kind, e.PgErr.Message)
+}
+
+func (e *Error) Unwrap() []error {
+	errs := []error{e.Kind, e.PgErr}
+	if e.Domain != nil {
+		errs = append(errs, e.Domain)
+	}
+	return errs
+}
+
+// Translate превращает ошибку Postgres с известным SQLSTATE в *Error.
+// Остальные ошибки, в том числе nil, возвращаются без изменений.
+func Translate(err error, constraints Constraints) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	kind := kindOf(pgErr.Code)
+	if kind == nil {
+		return err
+	}
+	return &Error{
+		Kind:       kind,
+		Domain:     constraints[pgErr.ConstraintName],
+		Constraint: pgErr.ConstraintName,
+		PgErr:      pgErr,
+	}
+}
+
+// Kind возвращает класс ошибки Postgres или nil, если SQLSTATE не
+// классифицируется; ошибку не обязательно предварительно переводить.
+func Kind(err error) error {
+	var translated *Error
+	if errors.As(err, &translated) {
+		return translated.Kind
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return kindOf(pgErr.Code)
+	}
+	return nil
+}
+
+func kindOf(code string) error {
+	switch code {
+	case CodeUniqueViolation:
+		return ErrUniqueViolation
+	case CodeForeignKeyViolation:
+		return ErrForeignKeyViolation
+	case CodeSerializationFailure:
+		return ErrSerializationFailure
+	case CodeDeadlockDetected:
+		return ErrDeadlock
+	default:
+		return nil
+	}
+}
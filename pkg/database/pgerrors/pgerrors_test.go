@@ -0,0 +1,86 @@
+package pgerrors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"courier-service/pkg/database/pgerrors"
+)
+
+var errPhoneExists = errors.New("phone number already exists")
+
+func TestTranslate(t *testing.T) {
+	constraints := pgerrors.Constraints{"couriers_phone_key": errPhoneExists}
+
+	tests := []struct {
+		name   string
+		pgErr  *pgconn.PgError
+		kind   error
+		domain error
+	}{
+		{
+			name:   "unique violation on known constraint",
+			pgErr:  &pgconn.PgError{Code: "23505", ConstraintName: "couriers_phone_key"},
+			kind:   pgerrors.ErrUniqueViolation,
+			domain: errPhoneExists,
+		},
+		{
+			name:  "unique violation on unknown constraint",
+			pgErr: &pgconn.PgError{Code: "23505", ConstraintName: "other_key"},
+			kind:  pgerrors.ErrUniqueViolation,
+		},
+		{
+			name:  "foreign key violation",
+			pgErr: &pgconn.PgError{Code: "23503", ConstraintName: "delivery_courier_id_fkey"},
+			kind:  pgerrors.ErrForeignKeyViolation,
+		},
+		{
+			name:  "serialization failure",
+			pgErr: &pgconn.PgError{Code: "40001"},
+			kind:  pgerrors.ErrSerializationFailure,
+		},
+		{
+			name:  "deadlock",
+			pgErr: &pgconn.PgError{Code: "40P01"},
+			kind:  pgerrors.ErrDeadlock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := pgerrors.Translate(fmt.Errorf("insert: %w", tt.pgErr), constraints)
+
+			assert.ErrorIs(t, err, tt.kind)
+			assert.Equal(t, tt.kind, pgerrors.Kind(err))
+			if tt.domain != nil {
+				assert.ErrorIs(t, err, tt.domain)
+				assert.Equal(t, tt.domain.Error(), err.Error())
+			}
+
+			var pgErr *pgconn.PgError
+			require.ErrorAs(t, err, &pgErr)
+			assert.Same(t, tt.pgErr, pgErr)
+		})
+	}
+}
+
+func TestTranslate_Passthrough(t *testing.T) {
+	plain := errors.New("connection reset")
+	assert.Same(t, plain, pgerrors.Translate(plain, nil))
+	assert.NoError(t, pgerrors.Translate(nil, nil))
+
+	checkViolation := &pgconn.PgError{Code: "23514"}
+	assert.Same(t, checkViolation, pgerrors.Translate(checkViolation, nil))
+	assert.Nil(t, pgerrors.Kind(checkViolation))
+}
+
+func TestKind_Untranslated(t *testing.T) {
+	err := fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40001"})
+	assert.Equal(t, pgerrors.ErrSerializationFailure, pgerrors.Kind(err))
+	assert.Nil(t, pgerrors.Kind(errors.New("other")))
+}
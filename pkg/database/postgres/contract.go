@@ -1,19 +1,12 @@
 package database
 
-type logger interface {
-	Debug(args ...interface{})
-	Debugf(format string, args ...interface{})
-	Debugw(msg string, keysAndValues ...interface{})
+import "time"
 
-	Info(args ...interface{})
+type logger interface {
 	Infof(format string, args ...interface{})
-
-	Warn(args ...interface{})
 	Warnf(format string, args ...interface{})
+}
 
-	Error(args ...interface{})
-	Errorf(format string, args ...interface{})
-
-	Fatal(args ...interface{})
-	Fatalf(format string, args ...interface{})
+type backoff interface {
+	NextDelay(attempt int) time.Duration
 }
@@ -2,50 +2,102 @@ package database
 
 import (
 	"context"
-	"log"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"courier-service/pkg/delay/fulljitter"
+	"courier-service/pkg/tracing"
 )
 
-func MustInitPool(connString string, l logger) *pgxpool.Pool {
-	cfg, err := pgxpool.ParseConfig(connString)
+// Config — параметры пула соединений и первого подключения.
+type Config struct {
+	DSN               string
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+
+	// ConnectTimeout ограничивает одну попытку подключения.
+	ConnectTimeout time.Duration
+	// ConnectAttempts — сколько раз пробовать подключиться при старте.
+	ConnectAttempts int
+	// Backoff задает паузу между попытками; по умолчанию экспоненциальная
+	// с полным джиттером от 200ms до 5s.
+	Backoff backoff
+}
+
+// NewPool создает пул и дожидается доступности базы: до ConnectAttempts
+// попыток с паузами Backoff. Если база так и не ответила, пул закрывается
+// и возвращается ошибка последней попытки.
+func NewPool(ctx context.Context, cfg Config, l logger) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.DSN)
 	if err != nil {
-		l.Fatal("Failed to parse database configuration: %v", err)
+		return nil, fmt.Errorf("parse database config: %w", err)
 	}
 
-	cfg.MaxConns = 10
-	cfg.MinConns = 2
-	cfg.MaxConnLifetime = time.Hour
-	cfg.MaxConnIdleTime = time.Minute * 30
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	poolCfg.MinConns = cfg.MinConns
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+	if cfg.HealthCheckPeriod > 0 {
+		poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+	poolCfg.ConnConfig.Tracer = tracing.NewPgxTracer()
 
-	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("create database pool: %w", err)
 	}
 
-	pingAttemptLimit := 3
-	var pingErr error
-	for i := 0; i < pingAttemptLimit; i++ {
-		pingCtx, pingCancel := context.WithTimeout(ctx, 5*time.Second)
-		pingErr = pool.Ping(pingCtx)
-		pingCancel()
-		if pingErr == nil {
-			break
-		}
-		l.Warnf("db ping attempt %d failed: %v", i+1, pingErr)
-		if i < pingAttemptLimit {
-			time.Sleep(500 * time.Millisecond)
-		}
+	if err := connect(ctx, pool, cfg, l); err != nil {
+		pool.Close()
+		return nil, err
 	}
+	l.Infof("Database connection pool established (max_conns=%d, min_conns=%d)",
+		poolCfg.MaxConns, poolCfg.MinConns)
+
+	return pool, nil
+}
 
-	if pingErr != nil {
-		l.Fatal("Unable to ping database")
+func connect(ctx context.Context, pool *pgxpool.Pool, cfg Config, l logger) error {
+	attempts := max(cfg.ConnectAttempts, 1)
+	timeout := cfg.ConnectTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
 	}
-	l.Info("Database connection pool established")
+	strategy := cfg.Backoff
+	if strategy == nil {
+		strategy = fulljitter.NewFullJitter(200*time.Millisecond, 5*time.Second, 2.0, nil)
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = pool.Ping(pingCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
 
-	return pool
+		delay := strategy.NextDelay(attempt)
+		l.Warnf("Database ping attempt %d/%d failed, retrying in %s: %v", attempt, attempts, delay, err)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("connect to database: %w", ctx.Err())
+		}
+	}
+	return fmt.Errorf("connect to database after %d attempts: %w", attempts, err)
 }
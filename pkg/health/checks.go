@@ -0,0 +1,78 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"google.golang.org/grpc/connectivity"
+)
+
+var ErrNoKafkaBrokers = errors.New("no kafka brokers available")
+
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping проверяет зависимость с методом Ping, например пул pgx.
+func Ping(p pinger) CheckFunc {
+	return p.Ping
+}
+
+type grpcConn interface {
+	GetState() connectivity.State
+	Connect()
+	WaitForStateChange(ctx context.Context, sourceState connectivity.State) bool
+}
+
+// GRPCConn ждет, пока соединение перейдет в Ready. Простаивающее соединение
+// поднимается: иначе проба всегда видела бы Idle.
+func GRPCConn(conn grpcConn) CheckFunc {
+	return func(ctx context.Context) error {
+		for {
+			state := conn.GetState()
+			switch state {
+			case connectivity.Ready:
+				return nil
+			case connectivity.Shutdown:
+				return fmt.Errorf("grpc connection is %s", state)
+			case connectivity.Idle:
+				conn.Connect()
+			}
+			if !conn.WaitForStateChange(ctx, state) {
+				return fmt.Errorf("grpc connection is %s", state)
+			}
+		}
+	}
+}
+
+type kafkaClient interface {
+	RefreshMetadata(topics ...string) error
+	Brokers() []*sarama.Broker
+}
+
+// KafkaMetadata запрашивает у брокеров метаданные топиков. У sarama нет
+// отмены по контексту, поэтому запрос ограничен еще и таймаутами клиента.
+func KafkaMetadata(client kafkaClient, topics ...string) CheckFunc {
+	return func(ctx context.Context) error {
+		done := make(chan error, 1)
+		go func() {
+			done <- client.RefreshMetadata(topics...)
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if len(client.Brokers()) == 0 {
+			return ErrNoKafkaBrokers
+		}
+		return nil
+	}
+}
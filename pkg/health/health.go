@@ -0,0 +1,120 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// CheckFunc проверяет одну зависимость; nil означает, что она доступна.
+type CheckFunc func(ctx context.Context) error
+
+type CheckResult struct {
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// Report — результат проверки всех зависимостей. Status равен ok, только
+// если доступны все.
+type Report struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks"`
+	CheckedAt time.Time              `json:"checked_at"`
+}
+
+func (r Report) Healthy() bool {
+	return r.Status == StatusOK
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker — реестр проверок зависимостей. Проверки идут параллельно, каждая
+// со своим таймаутом, а отчет кэшируется на cacheTTL, чтобы частые пробы
+// оркестратора не нагружали зависимости.
+type Checker struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu     sync.Mutex
+	checks []check
+	last   Report
+}
+
+func NewChecker(timeout, cacheTTL time.Duration, now func() time.Time) *Checker {
+	return &Checker{timeout: timeout, cacheTTL: cacheTTL, now: now}
+}
+
+func (c *Checker) Register(name string, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks = append(c.checks, check{name: name, fn: fn})
+	c.last = Report{}
+}
+
+// Check возвращает отчет из кэша или проверяет зависимости заново. Пока идет
+// проверка, остальные вызовы ждут ее результат, а не запускают свою.
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.last.CheckedAt.IsZero() && c.now().Sub(c.last.CheckedAt) < c.cacheTTL {
+		return c.last
+	}
+
+	// отмена запроса пробы не должна попасть в кэш как отказ зависимости
+	ctx = context.WithoutCancel(ctx)
+
+	results := make([]CheckResult, len(c.checks))
+	var wg sync.WaitGroup
+	for i, ch := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, ch.fn)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:    StatusOK,
+		Checks:    make(map[string]CheckResult, len(c.checks)),
+		CheckedAt: c.now(),
+	}
+	for i, ch := range c.checks {
+		report.Checks[ch.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	c.last = report
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, fn CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(ctx)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Status: StatusOK, DurationMs: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
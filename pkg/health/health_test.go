@@ -0,0 +1,134 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/connectivity"
+
+	"courier-service/pkg/health"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestChecker_Report(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	checker := health.NewChecker(time.Second, 5*time.Second, clock.Now)
+	checker.Register("postgres", func(context.Context) error { return nil })
+	checker.Register("kafka", func(context.Context) error { return errors.New("broker down") })
+
+	report := checker.Check(context.Background())
+
+	assert.False(t, report.Healthy())
+	assert.Equal(t, health.StatusOK, report.Checks["postgres"].Status)
+	assert.Equal(t, health.StatusFail, report.Checks["kafka"].Status)
+	assert.Equal(t, "broker down", report.Checks["kafka"].Error)
+}
+
+func TestChecker_CachesReport(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	checker := health.NewChecker(time.Second, 5*time.Second, clock.Now)
+
+	var calls atomic.Int32
+	checker.Register("postgres", func(context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+
+	assert.True(t, checker.Check(context.Background()).Healthy())
+	clock.now = clock.now.Add(4 * time.Second)
+	assert.True(t, checker.Check(context.Background()).Healthy())
+	assert.EqualValues(t, 1, calls.Load())
+
+	clock.now = clock.now.Add(time.Second)
+	checker.Check(context.Background())
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	checker := health.NewChecker(20*time.Millisecond, 0, time.Now)
+	checker.Register("order_service", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := checker.Check(context.Background())
+
+	assert.Equal(t, health.StatusFail, report.Checks["order_service"].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["order_service"].Error)
+}
+
+func TestChecker_IgnoresProbeCancellation(t *testing.T) {
+	checker := health.NewChecker(time.Second, time.Minute, time.Now)
+	checker.Register("postgres", func(ctx context.Context) error { return ctx.Err() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.True(t, checker.Check(ctx).Healthy())
+}
+
+type fakeConn struct {
+	states    []connectivity.State
+	connected bool
+}
+
+func (c *fakeConn) GetState() connectivity.State { return c.states[0] }
+
+func (c *fakeConn) Connect() { c.connected = true }
+
+func (c *fakeConn) WaitForStateChange(ctx context.Context, _ connectivity.State) bool {
+	if len(c.states) == 1 {
+		<-ctx.Done()
+		return false
+	}
+	c.states = c.states[1:]
+	return true
+}
+
+func TestGRPCConn(t *testing.T) {
+	t.Run("idle connection is dialed until ready", func(t *testing.T) {
+		conn := &fakeConn{states: []connectivity.State{connectivity.Idle, connectivity.Connecting, connectivity.Ready}}
+
+		assert.NoError(t, health.GRPCConn(conn)(context.Background()))
+		assert.True(t, conn.connected)
+	})
+
+	t.Run("transient failure until deadline", func(t *testing.T) {
+		conn := &fakeConn{states: []connectivity.State{connectivity.TransientFailure}}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		assert.ErrorContains(t, health.GRPCConn(conn)(ctx), "TRANSIENT_FAILURE")
+	})
+}
+
+type fakeKafkaClient struct {
+	err     error
+	brokers []*sarama.Broker
+}
+
+func (c *fakeKafkaClient) RefreshMetadata(...string) error {
+	return c.err
+}
+
+func (c *fakeKafkaClient) Brokers() []*sarama.Broker { return c.brokers }
+
+func TestKafkaMetadata(t *testing.T) {
+	broker := sarama.NewBroker("localhost:9092")
+
+	assert.NoError(t, health.KafkaMetadata(&fakeKafkaClient{brokers: []*sarama.Broker{broker}}, "orders")(context.Background()))
+	assert.ErrorIs(t, health.KafkaMetadata(&fakeKafkaClient{}, "orders")(context.Background()), health.ErrNoKafkaBrokers)
+
+	metadataErr := errors.New("leader not available")
+	assert.ErrorIs(t, health.KafkaMetadata(&fakeKafkaClient{err: metadataErr}, "orders")(context.Background()), metadataErr)
+}
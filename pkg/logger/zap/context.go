@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"context"
+
+	"courier-service/pkg/requestid"
+)
+
+type ctxKey struct{}
+
+// fields — поля запроса, которые With добавляет к каждой записи.
+type fields struct {
+	route     string
+	courierID int64
+	orderID   string
+}
+
+func fieldsFromContext(ctx context.Context) fields {
+	f, _ := ctx.Value(ctxKey{}).(fields)
+	return f
+}
+
+// WithRoute кладет в контекст шаблон маршрута HTTP запроса.
+func WithRoute(ctx context.Context, route string) context.Context {
+	f := fieldsFromContext(ctx)
+	f.route = route
+	return context.WithValue(ctx, ctxKey{}, f)
+}
+
+// WithCourierID кладет в контекст id курьера, с которым работает запрос.
+func WithCourierID(ctx context.Context, id int64) context.Context {
+	f := fieldsFromContext(ctx)
+	f.courierID = id
+	return context.WithValue(ctx, ctxKey{}, f)
+}
+
+// WithOrderID кладет в контекст id заказа, с которым работает запрос.
+func WithOrderID(ctx context.Context, id string) context.Context {
+	f := fieldsFromContext(ctx)
+	f.orderID = id
+	return context.WithValue(ctx, ctxKey{}, f)
+}
+
+func contextFields(ctx context.Context) []interface{} {
+	var kv []interface{}
+	if id := requestid.FromContext(ctx); id != "" {
+		kv = append(kv, "request_id", id)
+	}
+
+	f := fieldsFromContext(ctx)
+	if f.route != "" {
+		kv = append(kv, "route", f.route)
+	}
+	if f.courierID != 0 {
+		kv = append(kv, "courier_id", f.courierID)
+	}
+	if f.orderID != "" {
+		kv = append(kv, "order_id", f.orderID)
+	}
+	return kv
+}
@@ -0,0 +1,139 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Levels — уровни логирования, которые меняются на лету: общий и
+// переопределения для модулей. Модуль без переопределения следует общему.
+type Levels struct {
+	root zap.AtomicLevel
+
+	mu      sync.RWMutex
+	modules map[string]*moduleLevel
+}
+
+func newLevels(level zapcore.Level) *Levels {
+	return &Levels{
+		root:    zap.NewAtomicLevelAt(level),
+		modules: make(map[string]*moduleLevel),
+	}
+}
+
+func (lv *Levels) Level() LogLevel {
+	return LogLevel(lv.root.Level().String())
+}
+
+func (lv *Levels) SetLevel(level LogLevel) error {
+	zapLevel, err := parseLevel(level)
+	if err != nil || level == "" {
+		return ErrUnknownLevel
+	}
+	lv.root.SetLevel(zapLevel)
+	return nil
+}
+
+// ModuleLevels возвращает только переопределенные уровни модулей.
+func (lv *Levels) ModuleLevels() map[string]LogLevel {
+	lv.mu.RLock()
+	defer lv.mu.RUnlock()
+
+	levels := make(map[string]LogLevel)
+	for name, m := range lv.modules {
+		if m.overridden.Load() {
+			levels[name] = LogLevel(m.level.Level().String())
+		}
+	}
+	return levels
+}
+
+func (lv *Levels) SetModuleLevel(module string, level LogLevel) error {
+	zapLevel, err := parseLevel(level)
+	if err != nil || level == "" {
+		return ErrUnknownLevel
+	}
+	m, ok := lv.lookup(module)
+	if !ok {
+		return ErrUnknownModule
+	}
+	m.level.SetLevel(zapLevel)
+	m.overridden.Store(true)
+	return nil
+}
+
+// ResetModuleLevel снимает переопределение: модуль снова следует общему уровню.
+func (lv *Levels) ResetModuleLevel(module string) error {
+	m, ok := lv.lookup(module)
+	if !ok {
+		return ErrUnknownModule
+	}
+	m.overridden.Store(false)
+	return nil
+}
+
+func (lv *Levels) lookup(module string) (*moduleLevel, bool) {
+	lv.mu.RLock()
+	defer lv.mu.RUnlock()
+	m, ok := lv.modules[module]
+	return m, ok
+}
+
+// module возвращает уровень модуля, регистрируя модуль при первом обращении.
+func (lv *Levels) module(name string) *moduleLevel {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	m, ok := lv.modules[name]
+	if !ok {
+		m = &moduleLevel{root: lv.root, level: zap.NewAtomicLevel()}
+		lv.modules[name] = m
+	}
+	return m
+}
+
+type moduleLevel struct {
+	root       zap.AtomicLevel
+	level      zap.AtomicLevel
+	overridden atomic.Bool
+}
+
+func (m *moduleLevel) Enabled(l zapcore.Level) bool {
+	return m.Level().Enabled(l)
+}
+
+func (m *moduleLevel) Level() zapcore.Level {
+	if m.overridden.Load() {
+		return m.level.Level()
+	}
+	return m.root.Level()
+}
+
+// leveledCore отсекает записи по уровню, который можно поменять на лету;
+// сам core пишет все уровни.
+type leveledCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+func (c *leveledCore) Enabled(l zapcore.Level) bool {
+	return c.enabler.Enabled(l)
+}
+
+func (c *leveledCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.enabler)
+}
+
+func (c *leveledCore) With(fields []zapcore.Field) zapcore.Core {
+	return &leveledCore{Core: c.Core.With(fields), enabler: c.enabler}
+}
+
+func (c *leveledCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+	"errors"
 	"os"
 	"time"
 
@@ -17,6 +19,21 @@ const (
 	LogLevelDebug LogLevel = "debug"
 )
 
+// Format — кодировщик записей: json для сборщиков логов, console для
+// локальной разработки.
+type Format string
+
+const (
+	FormatJSON    Format = "json"
+	FormatConsole Format = "console"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown log format")
+	ErrUnknownLevel  = errors.New("unknown log level")
+	ErrUnknownModule = errors.New("unknown log module")
+)
+
 type Color string
 
 const (
@@ -29,18 +46,19 @@ const (
 	ColorLightPink   Color = "\x1b[95m"
 )
 
-func parseLevel(level LogLevel) zapcore.Level {
+// parseLevel переводит уровень в zap; пустой уровень означает info.
+func parseLevel(level LogLevel) (zapcore.Level, error) {
 	switch level {
-	case LogLevelInfo:
-		return zapcore.InfoLevel
+	case LogLevelInfo, "":
+		return zapcore.InfoLevel, nil
 	case LogLevelError:
-		return zapcore.ErrorLevel
+		return zapcore.ErrorLevel, nil
 	case LogLevelWarn:
-		return zapcore.WarnLevel
+		return zapcore.WarnLevel, nil
 	case LogLevelDebug:
-		return zapcore.DebugLevel
+		return zapcore.DebugLevel, nil
 	default:
-		return zapcore.InfoLevel
+		return zapcore.InfoLevel, ErrUnknownLevel
 	}
 }
 
@@ -64,31 +82,130 @@ func levelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
 	enc.AppendString(string(levelColor(l)) + "[" + l.CapitalString() + "]" + reset)
 }
 
+// Config — параметры логгера. Modules задает уровни модулей, отличные от
+// общего; Sampling ограничивает поток debug записей.
+type Config struct {
+	Level    LogLevel
+	Format   Format
+	Modules  map[string]LogLevel
+	Sampling Sampling
+}
+
+// Sampling: в секунду пишутся первые First одинаковых debug записей, затем
+// каждая Thereafter-я. First <= 0 отключает семплирование.
+type Sampling struct {
+	First      int
+	Thereafter int
+}
+
 type Logger struct {
-	l *zap.SugaredLogger
+	l      *zap.SugaredLogger
+	levels *Levels
+}
+
+func New(cfg Config) (*Logger, error) {
+	return newLogger(cfg, zapcore.AddSync(os.Stdout))
 }
 
-func New(level string) (*Logger, error) {
-	zapLevel := parseLevel(LogLevel(level))
+// NewNop возвращает логгер, который ничего не пишет.
+func NewNop() *Logger {
+	return &Logger{l: zap.NewNop().Sugar(), levels: newLevels(zapcore.InfoLevel)}
+}
+
+func newLogger(cfg Config, out zapcore.WriteSyncer) (*Logger, error) {
+	level, err := parseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	encoder, err := newEncoder(cfg.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := newLevels(level)
+	for module, moduleLevel := range cfg.Modules {
+		levels.module(module)
+		if err := levels.SetModuleLevel(module, moduleLevel); err != nil {
+			return nil, err
+		}
+	}
+
+	core := &leveledCore{Core: newSampledCore(encoder, out, cfg.Sampling), enabler: levels.root}
+	return &Logger{l: zap.New(core).Sugar(), levels: levels}, nil
+}
 
-	encoderCfg := zapcore.EncoderConfig{
-		TimeKey:        zapcore.OmitKey,
-		LevelKey:       "level",
-		MessageKey:     "msg",
-		CallerKey:      "caller",
-		EncodeTime:     timeEncoder,
-		EncodeDuration: zapcore.StringDurationEncoder,
-		EncodeLevel:    levelEncoder,
+// newSampledCore пишет все уровни; семплируются только debug записи, чтобы
+// под нагрузкой не потерять предупреждения и ошибки.
+func newSampledCore(encoder zapcore.Encoder, out zapcore.WriteSyncer, sampling Sampling) zapcore.Core {
+	if sampling.First <= 0 {
+		return zapcore.NewCore(encoder, out, zapcore.DebugLevel)
 	}
 
-	core := zapcore.NewCore(
-		zapcore.NewConsoleEncoder(encoderCfg),
-		zapcore.AddSync(os.Stdout),
-		zapLevel,
+	debug := zapcore.NewCore(encoder, out, zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return l == zapcore.DebugLevel
+	}))
+	rest := zapcore.NewCore(encoder.Clone(), out, zapcore.InfoLevel)
+	return zapcore.NewTee(
+		zapcore.NewSamplerWithOptions(debug, time.Second, sampling.First, sampling.Thereafter),
+		rest,
 	)
+}
+
+func newEncoder(format Format) (zapcore.Encoder, error) {
+	switch format {
+	case FormatJSON:
+		return zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			TimeKey:        "ts",
+			LevelKey:       "level",
+			NameKey:        "module",
+			MessageKey:     "msg",
+			CallerKey:      "caller",
+			EncodeTime:     zapcore.ISO8601TimeEncoder,
+			EncodeDuration: zapcore.StringDurationEncoder,
+			EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		}), nil
+	case FormatConsole:
+		return zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
+			TimeKey:        "time",
+			LevelKey:       "level",
+			NameKey:        "module",
+			MessageKey:     "msg",
+			CallerKey:      "caller",
+			EncodeTime:     timeEncoder,
+			EncodeDuration: zapcore.StringDurationEncoder,
+			EncodeLevel:    levelEncoder,
+		}), nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// With возвращает логгер с полями запроса из контекста: request id,
+// маршрут, id курьера и заказа. Без полей возвращается сам логгер.
+func (l *Logger) With(ctx context.Context) *Logger {
+	fields := contextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	return &Logger{l: l.l.With(fields...), levels: l.levels}
+}
+
+// Named возвращает логгер модуля: записи помечаются именем модуля, а уровень
+// берется из переопределения модуля или общий.
+func (l *Logger) Named(module string) *Logger {
+	level := l.levels.module(module)
+	named := l.l.Desugar().WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		if lc, ok := c.(*leveledCore); ok {
+			return &leveledCore{Core: lc.Core, enabler: level}
+		}
+		return c
+	})).Named(module)
+	return &Logger{l: named.Sugar(), levels: l.levels}
+}
 
-	logger := zap.New(core)
-	return &Logger{l: logger.Sugar()}, nil
+// Levels возвращает уровни, общие для логгера и всех его модулей.
+func (l *Logger) Levels() *Levels {
+	return l.levels
 }
 
 func timeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
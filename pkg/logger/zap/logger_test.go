@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"courier-service/pkg/requestid"
+)
+
+func TestLogger_JSONWithContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(Config{Format: FormatJSON}, zapcore.AddSync(&buf))
+	require.NoError(t, err)
+
+	ctx := requestid.NewContext(context.Background(), "req-1")
+	ctx = WithRoute(ctx, "/courier/{id}")
+	ctx = WithCourierID(ctx, 42)
+	ctx = WithOrderID(ctx, "order-1")
+
+	logger.With(ctx).Infow("courier loaded", "attempt", 1)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "courier loaded", entry["msg"])
+	assert.NotEmpty(t, entry["ts"])
+	assert.Equal(t, "req-1", entry["request_id"])
+	assert.Equal(t, "/courier/{id}", entry["route"])
+	assert.EqualValues(t, 42, entry["courier_id"])
+	assert.Equal(t, "order-1", entry["order_id"])
+	assert.EqualValues(t, 1, entry["attempt"])
+	assert.NotContains(t, buf.String(), "\x1b[")
+}
+
+func TestLogger_WithoutContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(Config{Format: FormatJSON}, zapcore.AddSync(&buf))
+	require.NoError(t, err)
+
+	assert.Same(t, logger, logger.With(context.Background()))
+}
+
+func TestLogger_ConsoleHasTimestamp(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(Config{Format: FormatConsole}, zapcore.AddSync(&buf))
+	require.NoError(t, err)
+
+	logger.Info("started")
+
+	assert.Regexp(t, `^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}`, buf.String())
+}
+
+func TestNew_UnknownFormat(t *testing.T) {
+	_, err := New(Config{Level: LogLevelInfo, Format: "xml"})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestLogger_RuntimeLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(Config{Level: LogLevelInfo, Format: FormatJSON}, zapcore.AddSync(&buf))
+	require.NoError(t, err)
+
+	logger.Debug("hidden")
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, logger.Levels().SetLevel(LogLevelDebug))
+	logger.Debug("shown")
+	assert.Contains(t, buf.String(), "shown")
+	assert.Equal(t, LogLevelDebug, logger.Levels().Level())
+
+	assert.ErrorIs(t, logger.Levels().SetLevel("verbose"), ErrUnknownLevel)
+}
+
+func TestLogger_ModuleLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(Config{
+		Level:   LogLevelInfo,
+		Format:  FormatJSON,
+		Modules: map[string]LogLevel{"gateway": LogLevelDebug},
+	}, zapcore.AddSync(&buf))
+	require.NoError(t, err)
+
+	gateway := logger.Named("gateway")
+	repository := logger.Named("repository")
+
+	gateway.Debug("gateway debug")
+	repository.Debug("repository debug")
+	assert.Contains(t, buf.String(), `"module":"gateway"`)
+	assert.NotContains(t, buf.String(), "repository debug")
+
+	require.NoError(t, logger.Levels().SetModuleLevel("repository", LogLevelError))
+	repository.Warn("repository warn")
+	assert.NotContains(t, buf.String(), "repository warn")
+	assert.Equal(t, map[string]LogLevel{
+		"gateway":    LogLevelDebug,
+		"repository": LogLevelError,
+	}, logger.Levels().ModuleLevels())
+
+	// без переопределения модуль следует общему уровню
+	require.NoError(t, logger.Levels().ResetModuleLevel("repository"))
+	repository.Warn("repository warn after reset")
+	assert.Contains(t, buf.String(), "repository warn after reset")
+
+	assert.ErrorIs(t, logger.Levels().SetModuleLevel("kafka", LogLevelDebug), ErrUnknownModule)
+	assert.ErrorIs(t, logger.Levels().ResetModuleLevel("kafka"), ErrUnknownModule)
+}
+
+func TestLogger_DebugSampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := newLogger(Config{
+		Level:    LogLevelDebug,
+		Format:   FormatJSON,
+		Sampling: Sampling{First: 2, Thereafter: 100},
+	}, zapcore.AddSync(&buf))
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		logger.Debugw("Request allowed", "path", "/couriers")
+		logger.Warn("rate limit exceeded")
+	}
+
+	assert.Equal(t, 2, bytes.Count(buf.Bytes(), []byte("Request allowed")))
+	assert.Equal(t, 10, bytes.Count(buf.Bytes(), []byte("rate limit exceeded")))
+}
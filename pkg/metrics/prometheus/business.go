@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// BusinessMetrics — доменные метрики доставки: назначения, дедлайны,
+// свободные курьеры, обработка событий заказов и повторы транзакций.
+type BusinessMetrics struct {
+	Assignments           *prometheus.CounterVec
+	OrderToAssignment     *prometheus.HistogramVec
+	DeadlineMisses        prometheus.Counter
+	AvailableCouriers     *prometheus.GaugeVec
+	PendingOrders         prometheus.Gauge
+	ConsumerLag           *prometheus.GaugeVec
+	MessageProcessingTime *prometheus.HistogramVec
+	TxRetries             prometheus.Counter
+}
+
+func NewBusinessMetrics(reg prometheus.Registerer) *BusinessMetrics {
+	metrics := &BusinessMetrics{
+		Assignments: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "delivery_assignments_total",
+				Help: "Total number of delivery assignment attempts by courier transport type and outcome",
+			},
+			[]string{"transport_type", "outcome"},
+		),
+		OrderToAssignment: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "delivery_order_to_assignment_seconds",
+				Help:    "Time from order creation to courier assignment",
+				Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+			},
+			[]string{"transport_type"},
+		),
+		DeadlineMisses: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "delivery_deadline_misses_total",
+				Help: "Total number of deliveries released after their deadline passed",
+			},
+		),
+		AvailableCouriers: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "couriers_available",
+				Help: "Number of available couriers by transport type",
+			},
+			[]string{"transport_type"},
+		),
+		PendingOrders: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "orders_pending",
+				Help: "Number of created orders left without a courier in the last monitoring pass",
+			},
+		),
+		ConsumerLag: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "kafka_consumer_lag",
+				Help: "Messages between the last processed offset and the partition high water mark",
+			},
+			[]string{"topic", "partition"},
+		),
+		MessageProcessingTime: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "kafka_message_processing_duration_seconds",
+				Help:    "Order event processing duration by order status",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"status"},
+		),
+		TxRetries: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "db_transaction_retries_total",
+				Help: "Total number of transactions retried after a serialization conflict or deadlock",
+			},
+		),
+	}
+	reg.MustRegister(
+		metrics.Assignments,
+		metrics.OrderToAssignment,
+		metrics.DeadlineMisses,
+		metrics.AvailableCouriers,
+		metrics.PendingOrders,
+		metrics.ConsumerLag,
+		metrics.MessageProcessingTime,
+		metrics.TxRetries,
+	)
+	return metrics
+}
+
+type BusinessMetricsWriter struct {
+	metrics *BusinessMetrics
+}
+
+func NewBusinessMetricsWriter(metrics *BusinessMetrics) *BusinessMetricsWriter {
+	return &BusinessMetricsWriter{
+		metrics: metrics,
+	}
+}
+
+func (w *BusinessMetricsWriter) RecordAssignment(transportType, outcome string) {
+	w.metrics.Assignments.WithLabelValues(transportType, outcome).Inc()
+}
+
+func (w *BusinessMetricsWriter) RecordOrderToAssignment(transportType string, duration float64) {
+	w.metrics.OrderToAssignment.WithLabelValues(transportType).Observe(duration)
+}
+
+func (w *BusinessMetricsWriter) RecordDeadlineMisses(count int64) {
+	w.metrics.DeadlineMisses.Add(float64(count))
+}
+
+func (w *BusinessMetricsWriter) SetAvailableCouriers(transportType string, count int) {
+	w.metrics.AvailableCouriers.WithLabelValues(transportType).Set(float64(count))
+}
+
+func (w *BusinessMetricsWriter) SetPendingOrders(count int) {
+	w.metrics.PendingOrders.Set(float64(count))
+}
+
+func (w *BusinessMetricsWriter) SetConsumerLag(topic string, partition int32, lag int64) {
+	w.metrics.ConsumerLag.WithLabelValues(topic, strconv.Itoa(int(partition))).Set(float64(lag))
+}
+
+func (w *BusinessMetricsWriter) RecordMessageProcessing(status string, duration float64) {
+	w.metrics.MessageProcessingTime.WithLabelValues(status).Observe(duration)
+}
+
+func (w *BusinessMetricsWriter) RecordTxRetry() {
+	w.metrics.TxRetries.Inc()
+}
@@ -3,6 +3,8 @@ package metrics
 import (
 	"net/http"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -10,12 +12,23 @@ type MetricsHandler struct {
 	handler http.Handler
 }
 
-func NewMetricsHandler() *MetricsHandler {
+func NewMetricsHandler(gatherer prometheus.Gatherer) *MetricsHandler {
 	return &MetricsHandler{
-		handler: promhttp.Handler(),
+		handler: promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}),
 	}
 }
 
 func (h *MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.handler.ServeHTTP(w, r)
 }
+
+// NewRegistry создает реестр процесса с метриками рантайма Go и процесса,
+// которые раньше отдавал глобальный реестр по умолчанию.
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
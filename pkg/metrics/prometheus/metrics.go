@@ -9,6 +9,10 @@ type HTTPMetrics struct {
 	RequestDuration        *prometheus.HistogramVec
 	RateLimitExceededTotal *prometheus.CounterVec
 	GatewayRetries         *prometheus.CounterVec
+	CircuitBreakerState    *prometheus.GaugeVec
+	CacheRequests          *prometheus.CounterVec
+	GRPCClientRequests     *prometheus.CounterVec
+	GRPCClientDuration     *prometheus.HistogramVec
 }
 
 func NewHTTPMetrics(reg prometheus.Registerer) *HTTPMetrics {
@@ -42,12 +46,45 @@ func NewHTTPMetrics(reg prometheus.Registerer) *HTTPMetrics {
 			},
 			[]string{"method", "path"},
 		),
+		CircuitBreakerState: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "circuit_breaker_state",
+				Help: "Circuit breaker state: 0 - closed, 1 - half-open, 2 - open",
+			},
+			[]string{"name"},
+		),
+		CacheRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cache_requests_total",
+				Help: "Total number of cache lookups by result",
+			},
+			[]string{"cache", "result"},
+		),
+		GRPCClientRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "grpc_client_requests_total",
+				Help: "Total number of outgoing gRPC calls by method and status code",
+			},
+			[]string{"method", "code"},
+		),
+		GRPCClientDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "grpc_client_request_duration_seconds",
+				Help:    "Outgoing gRPC call duration, for streams until the last message",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"method", "code"},
+		),
 	}
 	reg.MustRegister(
 		metrics.RequestTotal,
 		metrics.RequestDuration,
 		metrics.RateLimitExceededTotal,
 		metrics.GatewayRetries,
+		metrics.CircuitBreakerState,
+		metrics.CacheRequests,
+		metrics.GRPCClientRequests,
+		metrics.GRPCClientDuration,
 	)
 	return metrics
 }
@@ -77,3 +114,20 @@ func (w *MetricsWriter) RecordRetry(method, path string) {
 func (w *MetricsWriter) RecordRateLimitExceeded(method, path string) {
 	w.metrics.RateLimitExceededTotal.WithLabelValues(method, path).Inc()
 }
+
+func (w *MetricsWriter) RecordCircuitBreakerState(name string, state int) {
+	w.metrics.CircuitBreakerState.WithLabelValues(name).Set(float64(state))
+}
+
+func (w *MetricsWriter) RecordCacheHit(cache string) {
+	w.metrics.CacheRequests.WithLabelValues(cache, "hit").Inc()
+}
+
+func (w *MetricsWriter) RecordCacheMiss(cache string) {
+	w.metrics.CacheRequests.WithLabelValues(cache, "miss").Inc()
+}
+
+func (w *MetricsWriter) RecordGRPCRequest(method, code string, duration float64) {
+	w.metrics.GRPCClientRequests.WithLabelValues(method, code).Inc()
+	w.metrics.GRPCClientDuration.WithLabelValues(method, code).Observe(duration)
+}
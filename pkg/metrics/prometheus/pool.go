@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolStatter — пул pgx; статистика снимается на каждом scrape.
+type poolStatter interface {
+	Stat() *pgxpool.Stat
+}
+
+// PoolStatsCollector отдает статистику пула pgx. Метка pool различает пулы
+// одного процесса.
+type PoolStatsCollector struct {
+	pool poolStatter
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	emptyAcquireWaitTime *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	newConnsCount        *prometheus.Desc
+	maxLifetimeDestroys  *prometheus.Desc
+	maxIdleDestroys      *prometheus.Desc
+}
+
+func NewPoolStatsCollector(name string, pool poolStatter) *PoolStatsCollector {
+	labels := prometheus.Labels{"pool": name}
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc("pgxpool_"+metric, help, nil, labels)
+	}
+
+	return &PoolStatsCollector{
+		pool: pool,
+
+		acquiredConns:        desc("acquired_conns", "Number of connections currently acquired from the pool"),
+		idleConns:            desc("idle_conns", "Number of idle connections in the pool"),
+		constructingConns:    desc("constructing_conns", "Number of connections being established"),
+		totalConns:           desc("total_conns", "Total number of connections in the pool"),
+		maxConns:             desc("max_conns", "Maximum size of the pool"),
+		acquireCount:         desc("acquire_count_total", "Total number of successful acquires from the pool"),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Total time spent acquiring connections"),
+		emptyAcquireCount:    desc("empty_acquire_count_total", "Total number of acquires that had to wait for a connection"),
+		emptyAcquireWaitTime: desc("empty_acquire_wait_seconds_total", "Total time spent waiting for a connection when the pool was empty"),
+		canceledAcquireCount: desc("canceled_acquire_count_total", "Total number of acquires canceled by context"),
+		newConnsCount:        desc("new_conns_total", "Total number of connections opened"),
+		maxLifetimeDestroys:  desc("max_lifetime_destroy_count_total", "Total number of connections closed by max lifetime"),
+		maxIdleDestroys:      desc("max_idle_destroy_count_total", "Total number of connections closed by max idle time"),
+	}
+}
+
+func (c *PoolStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *PoolStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.pool.Stat()
+
+	gauge := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+	counter := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value)
+	}
+
+	gauge(c.acquiredConns, float64(s.AcquiredConns()))
+	gauge(c.idleConns, float64(s.IdleConns()))
+	gauge(c.constructingConns, float64(s.ConstructingConns()))
+	gauge(c.totalConns, float64(s.TotalConns()))
+	gauge(c.maxConns, float64(s.MaxConns()))
+	counter(c.acquireCount, float64(s.AcquireCount()))
+	counter(c.acquireDuration, s.AcquireDuration().Seconds())
+	counter(c.emptyAcquireCount, float64(s.EmptyAcquireCount()))
+	counter(c.emptyAcquireWaitTime, s.EmptyAcquireWaitTime().Seconds())
+	counter(c.canceledAcquireCount, float64(s.CanceledAcquireCount()))
+	counter(c.newConnsCount, float64(s.NewConnsCount()))
+	counter(c.maxLifetimeDestroys, float64(s.MaxLifetimeDestroyCount()))
+	counter(c.maxIdleDestroys, float64(s.MaxIdleDestroyCount()))
+}
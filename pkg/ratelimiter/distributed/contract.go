@@ -0,0 +1,17 @@
+package distributed
+
+import (
+	"context"
+
+	"courier-service/pkg/ratelimiter"
+)
+
+// bucketStore атомарно пополняет бакет ключа и пытается взять из него токен,
+// возвращая остаток токенов после попытки.
+type bucketStore interface {
+	Take(ctx context.Context, key string, limit ratelimiter.Limit) (float64, bool, error)
+}
+
+type logger interface {
+	Warnf(template string, args ...interface{})
+}
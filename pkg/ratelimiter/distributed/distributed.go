@@ -0,0 +1,65 @@
+package distributed
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"courier-service/pkg/ratelimiter"
+)
+
+const defaultTimeout = 100 * time.Millisecond
+
+// Limiter хранит состояние бакетов во внешнем хранилище, поэтому лимит
+// общий для всех реплик сервиса. При недоступности хранилища запрос
+// пропускается: rate limiting не должен ронять API вместе с БД.
+type Limiter struct {
+	store   bucketStore
+	timeout time.Duration
+	logger  logger
+}
+
+func NewLimiter(store bucketStore, timeout time.Duration, logger logger) *Limiter {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Limiter{
+		store:   store,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+func (l *Limiter) Allow(key string, limit ratelimiter.Limit) ratelimiter.Status {
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	tokens, allowed, err := l.store.Take(ctx, key, limit)
+	if err != nil {
+		l.logger.Warnf("Rate limit store unavailable, allowing request for %s: %v", key, err)
+		return ratelimiter.Status{Allowed: true, Limit: limit.Capacity, Remaining: limit.Capacity}
+	}
+
+	status := ratelimiter.Status{
+		Allowed:    allowed,
+		Limit:      limit.Capacity,
+		Remaining:  max(int(tokens), 0),
+		ResetAfter: timeUntil(tokens, float64(limit.Capacity), limit.RefillRate),
+	}
+	if !allowed {
+		status.RetryAfter = timeUntil(tokens, 1, limit.RefillRate)
+	}
+	return status
+}
+
+// timeUntil возвращает время, через которое в бакете будет не меньше want токенов.
+func timeUntil(tokens, want float64, refillRate int) time.Duration {
+	missing := want - tokens
+	if missing <= 0 {
+		return 0
+	}
+	if refillRate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(missing / float64(refillRate) * float64(time.Second))
+}
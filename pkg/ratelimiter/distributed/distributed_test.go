@@ -0,0 +1,88 @@
+package distributed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"courier-service/pkg/ratelimiter"
+	"courier-service/pkg/ratelimiter/distributed"
+)
+
+type fakeStore struct {
+	tokens  float64
+	allowed bool
+	err     error
+
+	key string
+}
+
+func (s *fakeStore) Take(_ context.Context, key string, _ ratelimiter.Limit) (float64, bool, error) {
+	s.key = key
+	return s.tokens, s.allowed, s.err
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Warnf(string, ...interface{}) {}
+
+func TestLimiter_Allow(t *testing.T) {
+	limit := ratelimiter.Limit{Capacity: 4, RefillRate: 2}
+
+	tests := []struct {
+		name  string
+		store *fakeStore
+		want  ratelimiter.Status
+	}{
+		{
+			name:  "allowed",
+			store: &fakeStore{tokens: 3, allowed: true},
+			want: ratelimiter.Status{
+				Allowed:    true,
+				Limit:      4,
+				Remaining:  3,
+				ResetAfter: 500 * time.Millisecond,
+			},
+		},
+		{
+			name:  "denied with partial token",
+			store: &fakeStore{tokens: 0.5, allowed: false},
+			want: ratelimiter.Status{
+				Allowed:    false,
+				Limit:      4,
+				Remaining:  0,
+				ResetAfter: 1750 * time.Millisecond,
+				RetryAfter: 250 * time.Millisecond,
+			},
+		},
+		{
+			name:  "store error fails open",
+			store: &fakeStore{err: errors.New("connection refused")},
+			want: ratelimiter.Status{
+				Allowed:   true,
+				Limit:     4,
+				Remaining: 4,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := distributed.NewLimiter(tt.store, time.Second, nopLogger{})
+
+			assert.Equal(t, tt.want, l.Allow("route|client", limit))
+			assert.Equal(t, "route|client", tt.store.key)
+		})
+	}
+}
+
+func TestLimiter_ZeroRefillNeverResets(t *testing.T) {
+	l := distributed.NewLimiter(&fakeStore{tokens: 0, allowed: false}, 0, nopLogger{})
+
+	st := l.Allow("k", ratelimiter.Limit{Capacity: 1, RefillRate: 0})
+	assert.False(t, st.Allowed)
+	assert.Greater(t, st.RetryAfter, 24*time.Hour)
+}
@@ -0,0 +1,8 @@
+package ratelimiter
+
+import "errors"
+
+var (
+	ErrExceedsCapacity     = errors.New("requested tokens exceed limiter capacity")
+	ErrWaitExceedsDeadline = errors.New("wait would exceed context deadline")
+)
@@ -0,0 +1,174 @@
+package gcra
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"courier-service/pkg/ratelimiter"
+)
+
+// Limiter реализует GCRA (generic cell rate algorithm). Вместо счетчика
+// токенов хранится одно время tat — когда лимитер снова станет полностью
+// свободным. Каждый запрос сдвигает tat на period, запрос пропускается,
+// если tat уходит в будущее не дальше чем на capacity*period.
+// Это скользящее окно без дискретных шагов пополнения.
+//
+// Лимитер с rate <= 0 не пропускает ничего.
+type Limiter struct {
+	capacity int
+	rate     int
+	period   time.Duration
+	tat      time.Time
+	mu       sync.Mutex
+
+	now func() time.Time
+}
+
+func NewLimiter(capacity, rate int, nowFn func() time.Time) *Limiter {
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	l := &Limiter{
+		capacity: capacity,
+		rate:     rate,
+		now:      nowFn,
+	}
+	if rate > 0 {
+		l.period = time.Second / time.Duration(rate)
+	}
+	l.tat = l.now()
+	return l
+}
+
+func (l *Limiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+func (l *Limiter) AllowN(n int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	newTat, ok := l.advance(now, n)
+	if !ok || newTat.Sub(now) > l.tolerance() {
+		return false
+	}
+
+	l.tat = newTat
+	return true
+}
+
+// AllowWithStatus работает как Allow, но дополнительно возвращает состояние
+// лимитера для заголовков X-RateLimit-* и Retry-After.
+func (l *Limiter) AllowWithStatus() ratelimiter.Status {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	newTat, ok := l.advance(now, 1)
+	allowed := ok && newTat.Sub(now) <= l.tolerance()
+	if allowed {
+		l.tat = newTat
+	}
+
+	status := ratelimiter.Status{
+		Allowed:    allowed,
+		Limit:      l.capacity,
+		Remaining:  l.remaining(now),
+		ResetAfter: l.debt(now),
+	}
+	if !allowed {
+		status.RetryAfter = time.Duration(math.MaxInt64)
+		if ok {
+			status.RetryAfter = newTat.Sub(now) - l.tolerance()
+		}
+	}
+	return status
+}
+
+func (l *Limiter) Reserve() *ratelimiter.Reservation {
+	return l.ReserveN(1)
+}
+
+// ReserveN сдвигает tat на n периодов и возвращает время, когда запрос
+// уложится в лимит. Если n больше емкости, резервация не OK.
+func (l *Limiter) ReserveN(n int) *ratelimiter.Reservation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	newTat, ok := l.advance(now, n)
+	if !ok || n > l.capacity {
+		return ratelimiter.NewReservation(false, n, now, l.now, nil)
+	}
+
+	timeToAct := newTat.Add(-l.tolerance())
+	if timeToAct.Before(now) {
+		timeToAct = now
+	}
+	l.tat = newTat
+
+	return ratelimiter.NewReservation(true, n, timeToAct, l.now, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.tat = l.tat.Add(-time.Duration(n) * l.period)
+	})
+}
+
+func (l *Limiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+// WaitN блокируется, пока лимит не пропустит n запросов, или до отмены ctx.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return l.ReserveN(n).Wait(ctx)
+}
+
+func (l *Limiter) Capacity() int {
+	return l.capacity
+}
+
+// Remaining возвращает, сколько запросов пройдет прямо сейчас.
+func (l *Limiter) Remaining() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.remaining(l.now())
+}
+
+// advance возвращает новое tat после n запросов.
+func (l *Limiter) advance(now time.Time, n int) (time.Time, bool) {
+	if l.period <= 0 {
+		return now, false
+	}
+	tat := l.tat
+	if tat.Before(now) {
+		tat = now
+	}
+	return tat.Add(time.Duration(n) * l.period), true
+}
+
+// tolerance — насколько tat может опережать текущее время.
+func (l *Limiter) tolerance() time.Duration {
+	return time.Duration(l.capacity) * l.period
+}
+
+// debt возвращает время до полного восстановления лимита.
+func (l *Limiter) debt(now time.Time) time.Duration {
+	if l.tat.After(now) {
+		return l.tat.Sub(now)
+	}
+	return 0
+}
+
+func (l *Limiter) remaining(now time.Time) int {
+	if l.period <= 0 {
+		return 0
+	}
+	return max(int((l.tolerance()-l.debt(now))/l.period), 0)
+}
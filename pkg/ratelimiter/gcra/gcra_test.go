@@ -0,0 +1,195 @@
+package gcra_test
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"courier-service/pkg/ratelimiter"
+	"courier-service/pkg/ratelimiter/gcra"
+	"courier-service/pkg/ratelimiter/tokenbucket"
+)
+
+func TestLimiter_Burst(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := gcra.NewLimiter(3, 1, func() time.Time { return fake })
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.Allow(), "request %d", i)
+	}
+	assert.False(t, l.Allow())
+	assert.Zero(t, l.Remaining())
+
+	fake = fake.Add(time.Second)
+	assert.Equal(t, 1, l.Remaining())
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+}
+
+func TestLimiter_SubSecondRate(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := gcra.NewLimiter(1, 10, func() time.Time { return fake })
+
+	assert.True(t, l.Allow())
+	fake = fake.Add(99 * time.Millisecond)
+	assert.False(t, l.Allow())
+	fake = fake.Add(time.Millisecond)
+	assert.True(t, l.Allow())
+}
+
+func TestLimiter_AllowN(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := gcra.NewLimiter(5, 2, func() time.Time { return fake })
+
+	assert.True(t, l.AllowN(3))
+	assert.False(t, l.AllowN(3))
+	assert.Equal(t, 2, l.Remaining())
+
+	fake = fake.Add(500 * time.Millisecond)
+	assert.True(t, l.AllowN(3))
+	assert.False(t, l.AllowN(6))
+}
+
+func TestLimiter_Status(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := gcra.NewLimiter(2, 1, func() time.Time { return fake })
+
+	st := l.AllowWithStatus()
+	assert.Equal(t, ratelimiter.Status{
+		Allowed:    true,
+		Limit:      2,
+		Remaining:  1,
+		ResetAfter: time.Second,
+	}, st)
+
+	l.Allow()
+	st = l.AllowWithStatus()
+	assert.False(t, st.Allowed)
+	assert.Equal(t, 2*time.Second, st.ResetAfter)
+	assert.Equal(t, time.Second, st.RetryAfter)
+}
+
+func TestLimiter_Reserve(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := gcra.NewLimiter(2, 4, func() time.Time { return fake })
+
+	r := l.ReserveN(2)
+	require.True(t, r.OK())
+	assert.Zero(t, r.Delay())
+
+	r = l.Reserve()
+	assert.Equal(t, 250*time.Millisecond, r.Delay())
+
+	r2 := l.Reserve()
+	assert.Equal(t, 500*time.Millisecond, r2.Delay())
+
+	r2.Cancel()
+	assert.Equal(t, 500*time.Millisecond, l.Reserve().Delay())
+
+	assert.False(t, l.ReserveN(3).OK())
+}
+
+func TestLimiter_Wait(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := gcra.NewLimiter(1, 1, func() time.Time { return fake })
+
+	require.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx), ratelimiter.ErrWaitExceedsDeadline)
+
+	fake = fake.Add(time.Second)
+	assert.True(t, l.Allow())
+}
+
+func TestLimiter_ZeroRate(t *testing.T) {
+	l := gcra.NewLimiter(5, 0, nil)
+
+	assert.False(t, l.Allow())
+	assert.False(t, l.Reserve().OK())
+	assert.Zero(t, l.Remaining())
+}
+
+type step struct {
+	Advance time.Duration
+	N       int
+}
+
+type scenario struct {
+	Capacity int
+	Rate     int
+	Steps    []step
+}
+
+// делители секунды, чтобы период GCRA считался без округления
+var rates = []int{1, 2, 4, 5, 8, 10, 20, 25, 50, 100}
+
+func (scenario) Generate(r *rand.Rand, size int) reflect.Value {
+	s := scenario{
+		Capacity: 1 + r.Intn(20),
+		Rate:     rates[r.Intn(len(rates))],
+	}
+	for i := 0; i < size; i++ {
+		s.Steps = append(s.Steps, step{
+			Advance: time.Duration(r.Int63n(int64(1500 * time.Millisecond))),
+			N:       1 + r.Intn(s.Capacity),
+		})
+	}
+	return reflect.ValueOf(s)
+}
+
+// За время t лимитер не может пропустить больше capacity + rate*t запросов.
+func TestLimiter_PropertyNeverExceedsRate(t *testing.T) {
+	property := func(s scenario) bool {
+		start := time.Unix(0, 0)
+		fake := start
+		l := gcra.NewLimiter(s.Capacity, s.Rate, func() time.Time { return fake })
+
+		allowed := 0
+		for _, st := range s.Steps {
+			fake = fake.Add(st.Advance)
+			if l.AllowN(st.N) {
+				allowed += st.N
+			}
+
+			limit := float64(s.Capacity) + fake.Sub(start).Seconds()*float64(s.Rate)
+			if float64(allowed) > limit {
+				return false
+			}
+			if remaining := l.Remaining(); remaining < 0 || remaining > s.Capacity {
+				return false
+			}
+		}
+		return true
+	}
+
+	require.NoError(t, quick.Check(property, nil))
+}
+
+// При целом периоде GCRA и непрерывный token bucket принимают одинаковые
+// решения на любой последовательности запросов.
+func TestLimiter_PropertyMatchesTokenBucket(t *testing.T) {
+	property := func(s scenario) bool {
+		fake := time.Unix(0, 0)
+		nowFn := func() time.Time { return fake }
+		l := gcra.NewLimiter(s.Capacity, s.Rate, nowFn)
+		tb := tokenbucket.NewTokenBucket(s.Capacity, s.Rate, nowFn)
+
+		for _, st := range s.Steps {
+			fake = fake.Add(st.Advance)
+			if l.AllowN(st.N) != tb.AllowN(st.N) {
+				return false
+			}
+		}
+		return true
+	}
+
+	require.NoError(t, quick.Check(property, nil))
+}
@@ -0,0 +1,106 @@
+package keyed
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"courier-service/pkg/ratelimiter"
+	"courier-service/pkg/ratelimiter/tokenbucket"
+)
+
+// Limiter хранит отдельный TokenBucket на каждый ключ (клиент, маршрут).
+// Бакеты лежат в LRU: при превышении maxKeys вытесняется давно не
+// использованный, а бакеты, простаивающие дольше idleTimeout, удаляются.
+type Limiter struct {
+	mu          sync.Mutex
+	entries     map[string]*list.Element
+	lru         *list.List
+	maxKeys     int
+	idleTimeout time.Duration
+
+	now func() time.Time
+}
+
+type entry struct {
+	key      string
+	limit    ratelimiter.Limit
+	bucket   *tokenbucket.TokenBucket
+	lastSeen time.Time
+}
+
+func NewLimiter(maxKeys int, idleTimeout time.Duration, nowFn func() time.Time) *Limiter {
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	return &Limiter{
+		entries:     make(map[string]*list.Element),
+		lru:         list.New(),
+		maxKeys:     maxKeys,
+		idleTimeout: idleTimeout,
+		now:         nowFn,
+	}
+}
+
+func (l *Limiter) Allow(key string, limit ratelimiter.Limit) ratelimiter.Status {
+	l.mu.Lock()
+	bucket := l.bucket(key, limit)
+	l.mu.Unlock()
+
+	return bucket.AllowWithStatus()
+}
+
+// Len возвращает количество бакетов в памяти.
+func (l *Limiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lru.Len()
+}
+
+func (l *Limiter) bucket(key string, limit ratelimiter.Limit) *tokenbucket.TokenBucket {
+	now := l.now()
+	l.evictIdle(now)
+
+	if el, ok := l.entries[key]; ok {
+		e := el.Value.(*entry)
+		// лимит для ключа поменялся (например, после перезагрузки конфига):
+		// потраченные клиентом токены сохраняются
+		if e.limit != limit {
+			e.limit = limit
+			e.bucket.SetLimit(limit.Capacity, limit.RefillRate)
+		}
+		e.lastSeen = now
+		l.lru.MoveToFront(el)
+		return e.bucket
+	}
+
+	e := &entry{
+		key:      key,
+		limit:    limit,
+		bucket:   tokenbucket.NewTokenBucket(limit.Capacity, limit.RefillRate, l.now),
+		lastSeen: now,
+	}
+	l.entries[key] = l.lru.PushFront(e)
+
+	for l.maxKeys > 0 && l.lru.Len() > l.maxKeys {
+		l.remove(l.lru.Back())
+	}
+	return e.bucket
+}
+
+func (l *Limiter) evictIdle(now time.Time) {
+	if l.idleTimeout <= 0 {
+		return
+	}
+	for el := l.lru.Back(); el != nil; el = l.lru.Back() {
+		if now.Sub(el.Value.(*entry).lastSeen) < l.idleTimeout {
+			return
+		}
+		l.remove(el)
+	}
+}
+
+func (l *Limiter) remove(el *list.Element) {
+	l.lru.Remove(el)
+	delete(l.entries, el.Value.(*entry).key)
+}
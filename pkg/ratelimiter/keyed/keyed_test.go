@@ -0,0 +1,88 @@
+package keyed_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"courier-service/pkg/ratelimiter"
+	"courier-service/pkg/ratelimiter/keyed"
+)
+
+func TestLimiter_SeparateBucketsPerKey(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := keyed.NewLimiter(10, time.Minute, func() time.Time { return fake })
+	limit := ratelimiter.Limit{Capacity: 2, RefillRate: 1}
+
+	assert.True(t, l.Allow("a", limit).Allowed)
+	assert.True(t, l.Allow("a", limit).Allowed)
+	assert.False(t, l.Allow("a", limit).Allowed)
+
+	// другой клиент не делит бакет с первым
+	assert.True(t, l.Allow("b", limit).Allowed)
+	assert.Equal(t, 2, l.Len())
+}
+
+func TestLimiter_Status(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := keyed.NewLimiter(10, time.Minute, func() time.Time { return fake })
+	limit := ratelimiter.Limit{Capacity: 3, RefillRate: 1}
+
+	st := l.Allow("a", limit)
+	assert.Equal(t, 3, st.Limit)
+	assert.Equal(t, 2, st.Remaining)
+	assert.Equal(t, time.Second, st.ResetAfter)
+	assert.Zero(t, st.RetryAfter)
+
+	l.Allow("a", limit)
+	l.Allow("a", limit)
+
+	fake = fake.Add(400 * time.Millisecond)
+	st = l.Allow("a", limit)
+	assert.False(t, st.Allowed)
+	assert.Equal(t, 0, st.Remaining)
+	assert.Equal(t, 600*time.Millisecond, st.RetryAfter)
+	assert.Equal(t, 2600*time.Millisecond, st.ResetAfter)
+}
+
+func TestLimiter_LRUEviction(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := keyed.NewLimiter(2, 0, func() time.Time { return fake })
+	limit := ratelimiter.Limit{Capacity: 1, RefillRate: 1}
+
+	assert.True(t, l.Allow("a", limit).Allowed)
+	assert.True(t, l.Allow("b", limit).Allowed)
+	assert.False(t, l.Allow("a", limit).Allowed) // "a" снова самый свежий
+	assert.True(t, l.Allow("c", limit).Allowed)  // вытесняет "b"
+
+	assert.Equal(t, 2, l.Len())
+	assert.True(t, l.Allow("b", limit).Allowed, "evicted key starts with a full bucket")
+}
+
+func TestLimiter_IdleEviction(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := keyed.NewLimiter(100, time.Minute, func() time.Time { return fake })
+	limit := ratelimiter.Limit{Capacity: 1, RefillRate: 0}
+
+	l.Allow("a", limit)
+	fake = fake.Add(30 * time.Second)
+	l.Allow("b", limit)
+	fake = fake.Add(45 * time.Second)
+	l.Allow("c", limit)
+
+	// "a" простаивал 75s, "b" — 45s
+	assert.Equal(t, 2, l.Len())
+}
+
+func TestLimiter_LimitChange(t *testing.T) {
+	fake := time.Unix(0, 0)
+	l := keyed.NewLimiter(10, time.Minute, func() time.Time { return fake })
+
+	assert.True(t, l.Allow("a", ratelimiter.Limit{Capacity: 1, RefillRate: 1}).Allowed)
+	assert.False(t, l.Allow("a", ratelimiter.Limit{Capacity: 1, RefillRate: 1}).Allowed)
+
+	st := l.Allow("a", ratelimiter.Limit{Capacity: 5, RefillRate: 1})
+	assert.True(t, st.Allowed)
+	assert.Equal(t, 5, st.Limit)
+}
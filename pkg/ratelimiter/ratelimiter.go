@@ -0,0 +1,20 @@
+package ratelimiter
+
+import "time"
+
+// Limit описывает емкость бакета и скорость пополнения в токенах в секунду.
+type Limit struct {
+	Capacity   int
+	RefillRate int
+}
+
+// Status описывает состояние лимита после попытки взять токен.
+type Status struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter — через сколько бакет снова будет полным.
+	ResetAfter time.Duration
+	// RetryAfter — через сколько появится следующий токен, 0 если запрос пропущен.
+	RetryAfter time.Duration
+}
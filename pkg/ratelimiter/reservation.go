@@ -0,0 +1,108 @@
+package ratelimiter
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Reservation описывает токены, взятые в долг у лимитера: действие можно
+// выполнить не раньше TimeToAct. Отмена возвращает токены лимитеру.
+type Reservation struct {
+	ok        bool
+	tokens    int
+	timeToAct time.Time
+	now       func() time.Time
+	cancel    func()
+	canceled  bool
+}
+
+// NewReservation используется реализациями лимитеров. cancel вызывается
+// не больше одного раза и только пока время резервации не наступило.
+func NewReservation(ok bool, tokens int, timeToAct time.Time, nowFn func() time.Time, cancel func()) *Reservation {
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	return &Reservation{
+		ok:        ok,
+		tokens:    tokens,
+		timeToAct: timeToAct,
+		now:       nowFn,
+		cancel:    cancel,
+	}
+}
+
+// OK возвращает false, если запрошено больше токенов, чем вмещает лимитер.
+func (r *Reservation) OK() bool {
+	return r.ok
+}
+
+func (r *Reservation) Tokens() int {
+	return r.tokens
+}
+
+func (r *Reservation) TimeToAct() time.Time {
+	return r.timeToAct
+}
+
+// Delay возвращает, сколько нужно подождать перед действием.
+func (r *Reservation) Delay() time.Duration {
+	return r.DelayFrom(r.now())
+}
+
+func (r *Reservation) DelayFrom(t time.Time) time.Duration {
+	if !r.ok {
+		return time.Duration(math.MaxInt64)
+	}
+	delay := r.timeToAct.Sub(t)
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// Cancel возвращает токены, если действие еще не выполнено.
+func (r *Reservation) Cancel() {
+	if !r.ok || r.canceled || r.cancel == nil {
+		return
+	}
+	if !r.timeToAct.After(r.now()) {
+		return
+	}
+	r.canceled = true
+	r.cancel()
+}
+
+// Wait блокируется до наступления времени резервации. Если ctx закончится
+// раньше, резервация отменяется и возвращается ошибка.
+func (r *Reservation) Wait(ctx context.Context) error {
+	if !r.ok {
+		return ErrExceedsCapacity
+	}
+
+	if err := ctx.Err(); err != nil {
+		r.Cancel()
+		return err
+	}
+
+	delay := r.Delay()
+	// задержка считается по часам лимитера, а дедлайн — по реальным часам
+	if deadline, ok := ctx.Deadline(); ok && delay > time.Until(deadline) {
+		r.Cancel()
+		return ErrWaitExceedsDeadline
+	}
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
@@ -1,13 +1,24 @@
 package tokenbucket
 
 import (
+	"context"
+	"math"
 	"sync"
 	"time"
+
+	"courier-service/pkg/ratelimiter"
 )
 
+// unit — количество долей токена в одном токене. Бакет считает токены
+// в миллиардных долях, чтобы пополнение шло каждую наносекунду без
+// накопления ошибок округления.
+const unit = int64(time.Second)
+
+// TokenBucket пополняется непрерывно: refillRate токенов в секунду,
+// поэтому дробные токены не теряются между вызовами.
 type TokenBucket struct {
 	capacity   int
-	tokens     int
+	tokens     int64
 	refillRate int
 	lastRefill time.Time
 	mu         sync.Mutex
@@ -21,7 +32,7 @@ func NewTokenBucket(capacity, refillRate int, nowFn func() time.Time) *TokenBuck
 	}
 	tb := &TokenBucket{
 		capacity:   capacity,
-		tokens:     capacity,
+		tokens:     int64(capacity) * unit,
 		refillRate: refillRate,
 		now:        nowFn,
 	}
@@ -30,35 +41,153 @@ func NewTokenBucket(capacity, refillRate int, nowFn func() time.Time) *TokenBuck
 }
 
 func (tb *TokenBucket) Allow() bool {
+	return tb.AllowN(1)
+}
+
+// AllowN берет n токенов, если они есть прямо сейчас.
+func (tb *TokenBucket) AllowN(n int) bool {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
 
-	tb.refill()
+	tb.refill(tb.now())
 
-	if tb.tokens > 0 {
-		tb.tokens--
+	if tb.tokens >= int64(n)*unit {
+		tb.tokens -= int64(n) * unit
 		return true
 	}
 
 	return false
 }
 
-func (tb *TokenBucket) refill() {
+// AllowWithStatus работает как Allow, но дополнительно возвращает состояние
+// бакета для заголовков X-RateLimit-* и Retry-After.
+func (tb *TokenBucket) AllowWithStatus() ratelimiter.Status {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill(tb.now())
+
+	allowed := tb.tokens >= unit
+	if allowed {
+		tb.tokens -= unit
+	}
+
+	status := ratelimiter.Status{
+		Allowed:    allowed,
+		Limit:      tb.capacity,
+		Remaining:  tb.whole(),
+		ResetAfter: tb.timeUntil(tb.capacity),
+	}
+	if !allowed {
+		status.RetryAfter = tb.timeUntil(1)
+	}
+	return status
+}
+
+func (tb *TokenBucket) Reserve() *ratelimiter.Reservation {
+	return tb.ReserveN(1)
+}
+
+// ReserveN берет n токенов в долг и возвращает резервацию со временем,
+// когда их можно использовать. Если n больше емкости, резервация не OK.
+func (tb *TokenBucket) ReserveN(n int) *ratelimiter.Reservation {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
 	now := tb.now()
+	tb.refill(now)
+	if n > tb.capacity || (tb.refillRate <= 0 && int64(n)*unit > tb.tokens) {
+		return ratelimiter.NewReservation(false, n, now, tb.now, nil)
+	}
+
+	tb.tokens -= int64(n) * unit
+	timeToAct := now.Add(tb.timeUntil(0))
+
+	return ratelimiter.NewReservation(true, n, timeToAct, tb.now, func() {
+		tb.mu.Lock()
+		defer tb.mu.Unlock()
+
+		tb.refill(tb.now())
+		tb.tokens = min(tb.tokens+int64(n)*unit, int64(tb.capacity)*unit)
+	})
+}
+
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	return tb.WaitN(ctx, 1)
+}
+
+// WaitN блокируется, пока не появятся n токенов, или до отмены ctx.
+func (tb *TokenBucket) WaitN(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return tb.ReserveN(n).Wait(ctx)
+}
+
+// timeUntil возвращает время, через которое в бакете будет не меньше want токенов.
+func (tb *TokenBucket) timeUntil(want int) time.Duration {
+	missing := int64(want)*unit - tb.tokens
+	if missing <= 0 {
+		return 0
+	}
+	if tb.refillRate <= 0 {
+		return time.Duration(math.MaxInt64)
+	}
+	rate := int64(tb.refillRate)
+	return time.Duration((missing + rate - 1) / rate)
+}
+
+// SetLimit меняет емкость и скорость пополнения, не сбрасывая бакет:
+// накопленное до смены пополняется по старой скорости, при увеличении
+// емкости добавляется разница, при уменьшении токены обрезаются до нее.
+func (tb *TokenBucket) SetLimit(capacity, refillRate int) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill(tb.now())
+	if delta := capacity - tb.capacity; delta > 0 {
+		tb.tokens += int64(delta) * unit
+	}
+	tb.tokens = min(tb.tokens, int64(capacity)*unit)
+	tb.capacity = capacity
+	tb.refillRate = refillRate
+}
+
+func (tb *TokenBucket) Capacity() int {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	return tb.capacity
+}
+
+func (tb *TokenBucket) refill(now time.Time) {
 	elapsed := now.Sub(tb.lastRefill)
+	if elapsed <= 0 {
+		return
+	}
+
+	// за elapsed наносекунд добавляется elapsed*refillRate долей токена
+	full := int64(tb.capacity) * unit
+	if missing := full - tb.tokens; missing <= 0 || tb.refillRate <= 0 {
+		tb.tokens = min(tb.tokens, full)
+	} else if rate := int64(tb.refillRate); int64(elapsed) >= (missing+rate-1)/rate {
+		// проверка до умножения защищает от переполнения после долгого простоя
+		tb.tokens = full
+	} else {
+		tb.tokens = min(tb.tokens+int64(elapsed)*rate, full)
+	}
+	tb.lastRefill = now
+}
 
-	tokensToAdd := int(elapsed.Seconds()) * tb.refillRate
-	if tokensToAdd > 0 {
-		tb.tokens += tokensToAdd
-		if tb.tokens > tb.capacity {
-			tb.tokens = tb.capacity
-		}
-		tb.lastRefill = now
+func (tb *TokenBucket) whole() int {
+	if tb.tokens <= 0 {
+		return 0
 	}
+	return int(tb.tokens / unit)
 }
 
+// Tokens возвращает количество целых токенов без пополнения.
 func (tb *TokenBucket) Tokens() int {
 	tb.mu.Lock()
 	defer tb.mu.Unlock()
-	return tb.tokens
+	return tb.whole()
 }
@@ -1,9 +1,17 @@
 package tokenbucket_test
 
 import (
+	"context"
+	"math/rand"
+	"reflect"
 	"testing"
+	"testing/quick"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"courier-service/pkg/ratelimiter"
 	"courier-service/pkg/ratelimiter/tokenbucket"
 )
 
@@ -107,3 +115,186 @@ func TestTokenbucket(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenbucket_SubSecondRefill(t *testing.T) {
+	fake := time.Unix(0, 0)
+	tb := tokenbucket.NewTokenBucket(1, 10, func() time.Time { return fake })
+
+	assert.True(t, tb.Allow())
+	assert.False(t, tb.Allow())
+
+	// 10 токенов в секунду — токен появляется каждые 100мс
+	fake = fake.Add(99 * time.Millisecond)
+	assert.False(t, tb.Allow())
+	fake = fake.Add(time.Millisecond)
+	assert.True(t, tb.Allow())
+}
+
+func TestTokenbucket_FractionsAccumulate(t *testing.T) {
+	fake := time.Unix(0, 0)
+	tb := tokenbucket.NewTokenBucket(1, 1, func() time.Time { return fake })
+	require.True(t, tb.Allow())
+
+	// частые вызовы не должны сбрасывать накопленную долю токена
+	for i := 0; i < 9; i++ {
+		fake = fake.Add(100 * time.Millisecond)
+		assert.False(t, tb.Allow(), "call %d", i)
+	}
+	fake = fake.Add(100 * time.Millisecond)
+	assert.True(t, tb.Allow())
+}
+
+func TestTokenbucket_SetLimit(t *testing.T) {
+	fake := time.Unix(0, 0)
+	tb := tokenbucket.NewTokenBucket(5, 1, func() time.Time { return fake })
+	require.True(t, tb.AllowN(4))
+
+	// увеличение емкости добавляет разницу, потраченное не возвращается
+	tb.SetLimit(10, 1)
+	assert.Equal(t, 6, tb.Tokens())
+	assert.Equal(t, 10, tb.Capacity())
+
+	// уменьшение обрезает токены до новой емкости
+	tb.SetLimit(3, 2)
+	assert.Equal(t, 3, tb.Tokens())
+	require.True(t, tb.AllowN(3))
+
+	// новая скорость действует с момента смены
+	fake = fake.Add(500 * time.Millisecond)
+	assert.True(t, tb.Allow())
+	assert.False(t, tb.Allow())
+}
+
+func TestTokenbucket_AllowN(t *testing.T) {
+	fake := time.Unix(0, 0)
+	tb := tokenbucket.NewTokenBucket(5, 2, func() time.Time { return fake })
+
+	assert.True(t, tb.AllowN(3))
+	assert.False(t, tb.AllowN(3))
+	assert.Equal(t, 2, tb.Tokens())
+
+	fake = fake.Add(500 * time.Millisecond)
+	assert.True(t, tb.AllowN(3))
+	assert.Equal(t, 0, tb.Tokens())
+}
+
+func TestTokenbucket_Reserve(t *testing.T) {
+	fake := time.Unix(0, 0)
+	tb := tokenbucket.NewTokenBucket(2, 4, func() time.Time { return fake })
+
+	r := tb.ReserveN(2)
+	require.True(t, r.OK())
+	assert.Zero(t, r.Delay())
+
+	// бакет пуст, следующий токен через 250мс, еще один — через 500мс
+	r = tb.Reserve()
+	require.True(t, r.OK())
+	assert.Equal(t, 250*time.Millisecond, r.Delay())
+
+	r2 := tb.Reserve()
+	assert.Equal(t, 500*time.Millisecond, r2.Delay())
+
+	// отмена возвращает токены, и следующий резерв ждет меньше
+	r2.Cancel()
+	r2.Cancel()
+	assert.Equal(t, 500*time.Millisecond, tb.Reserve().Delay())
+
+	assert.False(t, tb.ReserveN(3).OK())
+}
+
+func TestTokenbucket_Wait(t *testing.T) {
+	fake := time.Unix(0, 0)
+	tb := tokenbucket.NewTokenBucket(1, 1, func() time.Time { return fake })
+
+	require.NoError(t, tb.Wait(context.Background()))
+
+	// токен появится через секунду, дедлайн раньше — ждать нет смысла
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, tb.Wait(ctx), ratelimiter.ErrWaitExceedsDeadline)
+
+	// неудачное ожидание вернуло токен: через секунду он снова доступен
+	fake = fake.Add(time.Second)
+	assert.True(t, tb.Allow())
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, tb.Wait(canceled), context.Canceled)
+
+	assert.ErrorIs(t, tb.WaitN(context.Background(), 2), ratelimiter.ErrExceedsCapacity)
+}
+
+type step struct {
+	Advance time.Duration
+	N       int
+}
+
+type scenario struct {
+	Capacity int
+	Rate     int
+	Steps    []step
+}
+
+func (scenario) Generate(r *rand.Rand, size int) reflect.Value {
+	s := scenario{
+		Capacity: 1 + r.Intn(20),
+		Rate:     1 + r.Intn(20),
+	}
+	for i := 0; i < size; i++ {
+		s.Steps = append(s.Steps, step{
+			Advance: time.Duration(r.Int63n(int64(1500 * time.Millisecond))),
+			N:       1 + r.Intn(s.Capacity),
+		})
+	}
+	return reflect.ValueOf(s)
+}
+
+// За время t бакет не может пропустить больше capacity + rate*t токенов,
+// а остаток всегда лежит в [0, capacity].
+func TestTokenbucket_PropertyNeverExceedsRate(t *testing.T) {
+	property := func(s scenario) bool {
+		start := time.Unix(0, 0)
+		fake := start
+		tb := tokenbucket.NewTokenBucket(s.Capacity, s.Rate, func() time.Time { return fake })
+
+		allowed := 0
+		for _, st := range s.Steps {
+			fake = fake.Add(st.Advance)
+			if tb.AllowN(st.N) {
+				allowed += st.N
+			}
+
+			elapsed := fake.Sub(start)
+			limit := float64(s.Capacity) + elapsed.Seconds()*float64(s.Rate)
+			if float64(allowed) > limit {
+				return false
+			}
+			if tokens := tb.Tokens(); tokens < 0 || tokens > s.Capacity {
+				return false
+			}
+		}
+		return true
+	}
+
+	require.NoError(t, quick.Check(property, nil))
+}
+
+// После простоя capacity/rate бакет полон, сколько бы ни было взято до этого.
+func TestTokenbucket_PropertyRefillsToCapacity(t *testing.T) {
+	property := func(s scenario) bool {
+		fake := time.Unix(0, 0)
+		tb := tokenbucket.NewTokenBucket(s.Capacity, s.Rate, func() time.Time { return fake })
+
+		for _, st := range s.Steps {
+			fake = fake.Add(st.Advance)
+			tb.AllowN(st.N)
+		}
+
+		// capacity/rate секунд с округлением вверх до наносекунды
+		rate := time.Duration(s.Rate)
+		fake = fake.Add((time.Duration(s.Capacity)*time.Second + rate - 1) / rate)
+		return tb.AllowN(s.Capacity) && !tb.Allow()
+	}
+
+	require.NoError(t, quick.Check(property, nil))
+}
@@ -0,0 +1,88 @@
+package retrypolicy
+
+import (
+	"sync"
+	"time"
+)
+
+const budgetBuckets = 10
+
+// Budget ограничивает долю повторов среди всех вызовов за скользящее окно.
+// Когда сервис лежит, ретраи каждого вызова умножают нагрузку на него;
+// бюджет разрешает не больше minRetries + ratio*requests повторов за окно.
+type Budget struct {
+	ratio      float64
+	minRetries int
+	bucketSize time.Duration
+
+	mu      sync.Mutex
+	buckets [budgetBuckets]budgetBucket
+	now     func() time.Time
+}
+
+type budgetBucket struct {
+	start    time.Time
+	requests int
+	retries  int
+}
+
+func NewBudget(ratio float64, minRetries int, window time.Duration, nowFn func() time.Time) *Budget {
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	if window < budgetBuckets {
+		window = budgetBuckets
+	}
+	return &Budget{
+		ratio:      ratio,
+		minRetries: minRetries,
+		bucketSize: window / budgetBuckets,
+		now:        nowFn,
+	}
+}
+
+// RecordRequest учитывает новый вызов (не повтор).
+func (b *Budget) RecordRequest() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current().requests++
+}
+
+// TryRetry списывает повтор из бюджета, если он еще не исчерпан.
+func (b *Budget) TryRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bucket := b.current()
+
+	var requests, retries int
+	windowStart := b.now().Add(-b.bucketSize * budgetBuckets)
+	for i := range b.buckets {
+		if b.buckets[i].start.After(windowStart) {
+			requests += b.buckets[i].requests
+			retries += b.buckets[i].retries
+		}
+	}
+
+	if float64(retries) >= float64(b.minRetries)+b.ratio*float64(requests) {
+		return false
+	}
+	bucket.retries++
+	return true
+}
+
+func (b *Budget) current() *budgetBucket {
+	now := b.now()
+	start := now.Truncate(b.bucketSize)
+	idx := int(start.UnixNano()/int64(b.bucketSize)) % budgetBuckets
+	if idx < 0 {
+		idx += budgetBuckets
+	}
+
+	bucket := &b.buckets[idx]
+	if !bucket.start.Equal(start) {
+		*bucket = budgetBucket{start: start}
+	}
+	return bucket
+}
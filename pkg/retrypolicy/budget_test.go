@@ -0,0 +1,37 @@
+package retrypolicy_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"courier-service/pkg/retrypolicy"
+)
+
+func TestBudget(t *testing.T) {
+	fake := time.Unix(100, 0)
+	budget := retrypolicy.NewBudget(0.1, 2, 10*time.Second, func() time.Time { return fake })
+
+	for i := 0; i < 10; i++ {
+		budget.RecordRequest()
+	}
+
+	// 2 повтора сверх доли + 10% от 10 вызовов
+	assert.True(t, budget.TryRetry())
+	assert.True(t, budget.TryRetry())
+	assert.True(t, budget.TryRetry())
+	assert.False(t, budget.TryRetry())
+
+	for i := 0; i < 10; i++ {
+		budget.RecordRequest()
+	}
+	assert.True(t, budget.TryRetry())
+	assert.False(t, budget.TryRetry())
+
+	// старые вызовы и повторы выходят из окна
+	fake = fake.Add(10 * time.Second)
+	assert.True(t, budget.TryRetry())
+	assert.True(t, budget.TryRetry())
+	assert.False(t, budget.TryRetry())
+}
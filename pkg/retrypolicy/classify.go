@@ -0,0 +1,113 @@
+package retrypolicy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"courier-service/pkg/database/pgerrors"
+)
+
+// DefaultRetryableCodes — коды gRPC, при которых повтор безопасен и имеет смысл.
+var DefaultRetryableCodes = []codes.Code{
+	codes.Unavailable,
+	codes.DeadlineExceeded,
+	codes.ResourceExhausted,
+	codes.Aborted,
+	codes.Internal,
+}
+
+// IsRetryable классифицирует ошибку: статус gRPC, SQLSTATE Postgres или
+// сетевая ошибка. Отмена контекста вызывающей стороной не повторяется.
+func IsRetryable(err error) bool {
+	return isRetryable(err, DefaultRetryableCodes)
+}
+
+func isRetryable(err error, retryableCodes []codes.Code) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if st, ok := grpcStatus(err); ok {
+		return containsCode(retryableCodes, st.Code())
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return IsRetryableSQLState(pgErr.Code)
+	}
+
+	return isNetworkError(err)
+}
+
+// IsRetryableCode сообщает, входит ли код в DefaultRetryableCodes.
+func IsRetryableCode(code codes.Code) bool {
+	return containsCode(DefaultRetryableCodes, code)
+}
+
+// IsRetryableSQLState сообщает, стоит ли повторять запрос с таким SQLSTATE:
+// конфликты транзакций, потеря соединения, нехватка ресурсов и остановка сервера.
+func IsRetryableSQLState(code string) bool {
+	switch {
+	case code == pgerrors.CodeSerializationFailure, code == pgerrors.CodeDeadlockDetected:
+		return true
+	case strings.HasPrefix(code, "08"): // connection exception
+		return true
+	case strings.HasPrefix(code, "53"): // insufficient resources
+		return true
+	case code == "57P01", code == "57P02", code == "57P03": // admin/crash shutdown, cannot connect now
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTransactionConflict сообщает, что транзакцию откатили из-за конфликта
+// сериализации или дедлока и ее можно выполнить заново целиком.
+func IsTransactionConflict(err error) bool {
+	kind := pgerrors.Kind(err)
+	return kind == pgerrors.ErrSerializationFailure || kind == pgerrors.ErrDeadlock
+}
+
+func isNetworkError(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+func grpcStatus(err error) (*status.Status, bool) {
+	type grpcstatus interface{ GRPCStatus() *status.Status }
+
+	var gs grpcstatus
+	if !errors.As(err, &gs) || gs.GRPCStatus() == nil {
+		return nil, false
+	}
+	return gs.GRPCStatus(), true
+}
+
+func containsCode(list []codes.Code, code codes.Code) bool {
+	for _, c := range list {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package retrypolicy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"courier-service/pkg/retrypolicy"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "plain error", err: errors.New("boom"), want: false},
+		{name: "context canceled", err: context.Canceled, want: false},
+		{name: "grpc unavailable", err: status.Error(codes.Unavailable, "down"), want: true},
+		{name: "grpc deadline exceeded", err: status.Error(codes.DeadlineExceeded, "slow"), want: true},
+		{name: "grpc not found", err: status.Error(codes.NotFound, "no order"), want: false},
+		{name: "grpc invalid argument", err: status.Error(codes.InvalidArgument, "bad id"), want: false},
+		{
+			name: "wrapped grpc status",
+			err:  fmt.Errorf("failed to get order by id: %w", status.Error(codes.Unavailable, "down")),
+			want: true,
+		},
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, want: true},
+		{name: "deadlock", err: &pgconn.PgError{Code: "40P01"}, want: true},
+		{name: "connection failure", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "too many connections", err: &pgconn.PgError{Code: "53300"}, want: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, want: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, want: false},
+		{name: "connection refused", err: fmt.Errorf("dial: %w", syscall.ECONNREFUSED), want: true},
+		{name: "unexpected eof", err: io.ErrUnexpectedEOF, want: true},
+		{name: "net op error", err: &net.OpError{Op: "read", Err: errors.New("reset")}, want: true},
+		{name: "dns timeout", err: &net.DNSError{IsTimeout: true}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retrypolicy.IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestIsTransactionConflict(t *testing.T) {
+	assert.True(t, retrypolicy.IsTransactionConflict(fmt.Errorf("tx: %w", &pgconn.PgError{Code: "40001"})))
+	assert.True(t, retrypolicy.IsTransactionConflict(&pgconn.PgError{Code: "40P01"}))
+	// потеря соединения посреди транзакции — не повод перезапускать ее целиком
+	assert.False(t, retrypolicy.IsTransactionConflict(&pgconn.PgError{Code: "08006"}))
+	assert.False(t, retrypolicy.IsTransactionConflict(errors.New("boom")))
+}
@@ -0,0 +1,72 @@
+package retrypolicy
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Policy описывает, какие ошибки метода повторять и сколько раз.
+type Policy struct {
+	// MaxAttempts ограничивает число попыток метода; 0 — ограничение исполнителя.
+	MaxAttempts int
+	// RetryableCodes заменяет DefaultRetryableCodes для метода.
+	RetryableCodes []codes.Code
+	// Classify, если задан, полностью заменяет классификацию ошибок.
+	Classify func(error) bool
+}
+
+// Policies выбирает политику по имени gRPC метода из контекста.
+type Policies struct {
+	Default Policy
+	Methods map[string]Policy
+}
+
+func (p Policies) For(method string) Policy {
+	if policy, ok := p.Methods[method]; ok {
+		return policy
+	}
+	return p.Default
+}
+
+// ShouldRetry решает, повторять ли вызов после attempt неудачных попыток.
+// Если сервер запросил задержку, она возвращается вторым значением, иначе 0.
+func (p Policies) ShouldRetry(ctx context.Context, attempt int, err error) (bool, time.Duration) {
+	policy := p.For(MethodFromContext(ctx))
+
+	if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+		return false, 0
+	}
+	if !policy.retryable(err) {
+		return false, 0
+	}
+
+	delay, stop, ok := Pushback(err)
+	if !ok {
+		return true, 0
+	}
+	return !stop, delay
+}
+
+func (p Policy) retryable(err error) bool {
+	if p.Classify != nil {
+		return p.Classify(err)
+	}
+	if p.RetryableCodes != nil {
+		return isRetryable(err, p.RetryableCodes)
+	}
+	return IsRetryable(err)
+}
+
+type methodKey struct{}
+
+// WithMethod кладет в контекст имя метода, по которому выбирается политика.
+func WithMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, methodKey{}, method)
+}
+
+func MethodFromContext(ctx context.Context) string {
+	method, _ := ctx.Value(methodKey{}).(string)
+	return method
+}
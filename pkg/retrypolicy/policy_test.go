@@ -0,0 +1,97 @@
+package retrypolicy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"courier-service/pkg/retrypolicy"
+)
+
+const (
+	getOrders    = "/orders.v1.OrdersService/GetOrders"
+	getOrderByID = "/orders.v1.OrdersService/GetOrderById"
+)
+
+func TestPolicies_ShouldRetry(t *testing.T) {
+	policies := retrypolicy.Policies{
+		Methods: map[string]retrypolicy.Policy{
+			getOrders:    {MaxAttempts: 1},
+			getOrderByID: {RetryableCodes: []codes.Code{codes.NotFound}},
+		},
+	}
+
+	unavailable := status.Error(codes.Unavailable, "down")
+	notFound := status.Error(codes.NotFound, "no order")
+
+	withInfo, err := status.New(codes.ResourceExhausted, "slow down").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(2 * time.Second)})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		method    string
+		attempt   int
+		err       error
+		wantRetry bool
+		wantDelay time.Duration
+	}{
+		{name: "default policy retries unavailable", method: "", attempt: 1, err: unavailable, wantRetry: true},
+		{name: "default policy skips not found", method: "", attempt: 1, err: notFound, wantRetry: false},
+		{name: "method max attempts", method: getOrders, attempt: 1, err: unavailable, wantRetry: false},
+		{name: "method retryable codes", method: getOrderByID, attempt: 1, err: notFound, wantRetry: true},
+		{name: "method codes replace defaults", method: getOrderByID, attempt: 1, err: unavailable, wantRetry: false},
+		{
+			name:      "trailer pushback",
+			attempt:   1,
+			err:       retrypolicy.WithTrailerPushback(unavailable, metadata.Pairs(retrypolicy.PushbackTrailer, "1500")),
+			wantRetry: true,
+			wantDelay: 1500 * time.Millisecond,
+		},
+		{
+			name:      "negative pushback forbids retry",
+			attempt:   1,
+			err:       retrypolicy.WithTrailerPushback(unavailable, metadata.Pairs(retrypolicy.PushbackTrailer, "-1")),
+			wantRetry: false,
+		},
+		{
+			name:      "retry info in status details",
+			attempt:   1,
+			err:       withInfo.Err(),
+			wantRetry: true,
+			wantDelay: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := retrypolicy.WithMethod(context.Background(), tt.method)
+
+			retry, delay := policies.ShouldRetry(ctx, tt.attempt, tt.err)
+			assert.Equal(t, tt.wantRetry, retry)
+			assert.Equal(t, tt.wantDelay, delay)
+		})
+	}
+}
+
+func TestWithTrailerPushback_KeepsStatus(t *testing.T) {
+	err := retrypolicy.WithTrailerPushback(
+		status.Error(codes.Unavailable, "down"),
+		metadata.Pairs(retrypolicy.PushbackTrailer, "100"),
+	)
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.True(t, retrypolicy.IsRetryable(err))
+
+	plain := errors.New("boom")
+	assert.Same(t, plain, retrypolicy.WithTrailerPushback(plain, metadata.MD{}))
+}
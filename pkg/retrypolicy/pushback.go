@@ -0,0 +1,70 @@
+package retrypolicy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+)
+
+// PushbackTrailer — трейлер, которым сервер просит клиента повторить
+// запрос не раньше указанного числа миллисекунд (или не повторять вовсе,
+// если значение отрицательное или не число).
+const PushbackTrailer = "grpc-retry-pushback-ms"
+
+// PushbackError переносит задержку, запрошенную сервером, вместе с ошибкой.
+type PushbackError struct {
+	Err   error
+	Delay time.Duration
+	// Stop — сервер запретил повторять запрос.
+	Stop bool
+}
+
+func (e *PushbackError) Error() string {
+	if e.Stop {
+		return fmt.Sprintf("%v (server asked not to retry)", e.Err)
+	}
+	return fmt.Sprintf("%v (server asked to retry after %s)", e.Err, e.Delay)
+}
+
+func (e *PushbackError) Unwrap() error {
+	return e.Err
+}
+
+// WithTrailerPushback оборачивает ошибку вызова, если сервер прислал трейлер
+// grpc-retry-pushback-ms.
+func WithTrailerPushback(err error, trailer metadata.MD) error {
+	values := trailer.Get(PushbackTrailer)
+	if err == nil || len(values) == 0 {
+		return err
+	}
+
+	ms, parseErr := strconv.Atoi(values[0])
+	if parseErr != nil || ms < 0 {
+		return &PushbackError{Err: err, Stop: true}
+	}
+	return &PushbackError{Err: err, Delay: time.Duration(ms) * time.Millisecond}
+}
+
+// Pushback возвращает решение сервера о повторе: из PushbackError или из
+// google.rpc.RetryInfo в деталях статуса. ok=false — сервер ничего не просил.
+func Pushback(err error) (delay time.Duration, stop bool, ok bool) {
+	var pbErr *PushbackError
+	if errors.As(err, &pbErr) {
+		return pbErr.Delay, pbErr.Stop, true
+	}
+
+	st, isStatus := grpcStatus(err)
+	if !isStatus {
+		return 0, false, false
+	}
+	for _, detail := range st.Details() {
+		if info, isInfo := detail.(*errdetails.RetryInfo); isInfo && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), false, true
+		}
+	}
+	return 0, false, false
+}
@@ -0,0 +1,101 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"courier-service/pkg/tracing"
+)
+
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+func TestInit_FileExporter(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName: "courier-test",
+		Exporter:    tracing.ExporterFile,
+		FilePath:    path,
+		SampleRatio: 1,
+	})
+	require.NoError(t, err)
+
+	_, span := tracing.Tracer().Start(context.Background(), "assign")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"assign"`)
+	assert.Contains(t, string(data), "courier-test")
+}
+
+func TestInit_UnknownExporter(t *testing.T) {
+	_, err := tracing.Init(context.Background(), tracing.Config{Exporter: "zipkin"})
+	assert.ErrorIs(t, err, tracing.ErrUnknownExporter)
+}
+
+func TestPgxTracer(t *testing.T) {
+	tests := []struct {
+		name       string
+		sql        string
+		err        error
+		wantName   string
+		wantStatus codes.Code
+	}{
+		{
+			name:     "query span named by operation",
+			sql:      "SELECT id FROM couriers WHERE id = $1",
+			wantName: "db SELECT",
+		},
+		{
+			name:     "no rows is not an error",
+			sql:      "select id from delivery",
+			err:      pgx.ErrNoRows,
+			wantName: "db SELECT",
+		},
+		{
+			name:       "query error marks span",
+			sql:        "INSERT INTO delivery (order_id) VALUES ($1)",
+			err:        errors.New("duplicate key"),
+			wantName:   "db INSERT",
+			wantStatus: codes.Error,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := newRecorder(t)
+			tracer := tracing.NewPgxTracer()
+
+			ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: tc.sql})
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: tc.err})
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, tc.wantName, spans[0].Name())
+			assert.Equal(t, tc.wantStatus, spans[0].Status().Code)
+		})
+	}
+}
@@ -300,7 +300,9 @@ func (x *Order) GetEstimatedDelivery() *timestamppb.Timestamp {
 // Запрос на получение списка заказов
 type GetOrdersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // Фильтрация заказов по дате
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                            // Фильтрация заказов по дате
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // Максимум заказов в странице, 0 — на усмотрение сервера
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token предыдущей страницы, пусто для первой
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -342,6 +344,20 @@ func (x *GetOrdersRequest) GetFrom() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GetOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 // Запрос на получение заказ по id
 type GetOrderByIdRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -391,6 +407,7 @@ func (x *GetOrderByIdRequest) GetId() string {
 type GetOrdersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Пусто на последней странице
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -432,6 +449,13 @@ func (x *GetOrdersResponse) GetOrders() []*Order {
 	return nil
 }
 
+func (x *GetOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 // Ответ на запрос получения заказов
 type GetOrderByIdResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -508,18 +532,23 @@ const file_proto_order_order_proto_rawDesc = "" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12I\n" +
-	"\x12estimated_delivery\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x11estimatedDelivery\"B\n" +
+	"\x12estimated_delivery\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x11estimatedDelivery\"~\n" +
 	"\x10GetOrdersRequest\x12.\n" +
-	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\"%\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"%\n" +
 	"\x13GetOrderByIdRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"e\n" +
 	"\x11GetOrdersResponse\x12(\n" +
-	"\x06orders\x18\x01 \x03(\v2\x10.orders.v1.OrderR\x06orders\">\n" +
+	"\x06orders\x18\x01 \x03(\v2\x10.orders.v1.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\">\n" +
 	"\x14GetOrderByIdResponse\x12&\n" +
-	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order2\xa8\x01\n" +
+	"\x05order\x18\x01 \x01(\v2\x10.orders.v1.OrderR\x05order2\xf5\x01\n" +
 	"\rOrdersService\x12F\n" +
 	"\tGetOrders\x12\x1b.orders.v1.GetOrdersRequest\x1a\x1c.orders.v1.GetOrdersResponse\x12O\n" +
-	"\fGetOrderById\x12\x1e.orders.v1.GetOrderByIdRequest\x1a\x1f.orders.v1.GetOrderByIdResponseB\rZ\vproto/orderb\x06proto3"
+	"\fGetOrderById\x12\x1e.orders.v1.GetOrderByIdRequest\x1a\x1f.orders.v1.GetOrderByIdResponse\x12K\n" +
+	"\fStreamOrders\x12\x1b.orders.v1.GetOrdersRequest\x1a\x1c.orders.v1.GetOrdersResponse0\x01B\rZ\vproto/orderb\x06proto3"
 
 var (
 	file_proto_order_order_proto_rawDescOnce sync.Once
@@ -555,10 +584,12 @@ var file_proto_order_order_proto_depIdxs = []int32{
 	2,  // 7: orders.v1.GetOrderByIdResponse.order:type_name -> orders.v1.Order
 	3,  // 8: orders.v1.OrdersService.GetOrders:input_type -> orders.v1.GetOrdersRequest
 	4,  // 9: orders.v1.OrdersService.GetOrderById:input_type -> orders.v1.GetOrderByIdRequest
-	5,  // 10: orders.v1.OrdersService.GetOrders:output_type -> orders.v1.GetOrdersResponse
-	6,  // 11: orders.v1.OrdersService.GetOrderById:output_type -> orders.v1.GetOrderByIdResponse
-	10, // [10:12] is the sub-list for method output_type
-	8,  // [8:10] is the sub-list for method input_type
+	3,  // 10: orders.v1.OrdersService.StreamOrders:input_type -> orders.v1.GetOrdersRequest
+	5,  // 11: orders.v1.OrdersService.GetOrders:output_type -> orders.v1.GetOrdersResponse
+	6,  // 12: orders.v1.OrdersService.GetOrderById:output_type -> orders.v1.GetOrderByIdResponse
+	5,  // 13: orders.v1.OrdersService.StreamOrders:output_type -> orders.v1.GetOrdersResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
 	8,  // [8:8] is the sub-list for extension type_name
 	8,  // [8:8] is the sub-list for extension extendee
 	0,  // [0:8] is the sub-list for field type_name
@@ -25,6 +25,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	OrdersService_GetOrders_FullMethodName    = "/orders.v1.OrdersService/GetOrders"
 	OrdersService_GetOrderById_FullMethodName = "/orders.v1.OrdersService/GetOrderById"
+	OrdersService_StreamOrders_FullMethodName = "/orders.v1.OrdersService/StreamOrders"
 )
 
 // OrdersServiceClient is the client API for OrdersService service.
@@ -35,6 +36,9 @@ const (
 type OrdersServiceClient interface {
 	GetOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (*GetOrdersResponse, error)
 	GetOrderById(ctx context.Context, in *GetOrderByIdRequest, opts ...grpc.CallOption) (*GetOrderByIdResponse, error)
+	// Потоковая выдача заказов постранично: клиент читает следующую страницу
+	// только после обработки текущей
+	StreamOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetOrdersResponse], error)
 }
 
 type ordersServiceClient struct {
@@ -65,6 +69,25 @@ func (c *ordersServiceClient) GetOrderById(ctx context.Context, in *GetOrderById
 	return out, nil
 }
 
+func (c *ordersServiceClient) StreamOrders(ctx context.Context, in *GetOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetOrdersResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrdersService_ServiceDesc.Streams[0], OrdersService_StreamOrders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetOrdersRequest, GetOrdersResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrdersService_StreamOrdersClient = grpc.ServerStreamingClient[GetOrdersResponse]
+
 // OrdersServiceServer is the server API for OrdersService service.
 // All implementations must embed UnimplementedOrdersServiceServer
 // for forward compatibility.
@@ -73,6 +96,9 @@ func (c *ordersServiceClient) GetOrderById(ctx context.Context, in *GetOrderById
 type OrdersServiceServer interface {
 	GetOrders(context.Context, *GetOrdersRequest) (*GetOrdersResponse, error)
 	GetOrderById(context.Context, *GetOrderByIdRequest) (*GetOrderByIdResponse, error)
+	// Потоковая выдача заказов постранично: клиент читает следующую страницу
+	// только после обработки текущей
+	StreamOrders(*GetOrdersRequest, grpc.ServerStreamingServer[GetOrdersResponse]) error
 	mustEmbedUnimplementedOrdersServiceServer()
 }
 
@@ -89,6 +115,9 @@ func (UnimplementedOrdersServiceServer) GetOrders(context.Context, *GetOrdersReq
 func (UnimplementedOrdersServiceServer) GetOrderById(context.Context, *GetOrderByIdRequest) (*GetOrderByIdResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOrderById not implemented")
 }
+func (UnimplementedOrdersServiceServer) StreamOrders(*GetOrdersRequest, grpc.ServerStreamingServer[GetOrdersResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamOrders not implemented")
+}
 func (UnimplementedOrdersServiceServer) mustEmbedUnimplementedOrdersServiceServer() {}
 func (UnimplementedOrdersServiceServer) testEmbeddedByValue()                       {}
 
@@ -146,6 +175,17 @@ func _OrdersService_GetOrderById_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrdersService_StreamOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrdersServiceServer).StreamOrders(m, &grpc.GenericServerStream[GetOrdersRequest, GetOrdersResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrdersService_StreamOrdersServer = grpc.ServerStreamingServer[GetOrdersResponse]
+
 // OrdersService_ServiceDesc is the grpc.ServiceDesc for OrdersService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -162,6 +202,12 @@ var OrdersService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _OrdersService_GetOrderById_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOrders",
+			Handler:       _OrdersService_StreamOrders_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "proto/order/order.proto",
 }